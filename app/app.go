@@ -75,6 +75,8 @@ import (
 	tmlog "github.com/tendermint/tendermint/libs/log"
 	dbm "github.com/tendermint/tm-db"
 
+	"github.com/serv-chain/serv/x/epochs"
+	epochskeeper "github.com/serv-chain/serv/x/epochs/keeper"
 	"github.com/serv-chain/serv/x/noderewards"
 	noderewardskeeper "github.com/serv-chain/serv/x/noderewards/keeper"
 	noderewardstypes "github.com/serv-chain/serv/x/noderewards/types"
@@ -116,6 +118,7 @@ var (
 		evidence.AppModuleBasic{},
 		transfer.AppModuleBasic{},
 		// Custom modules
+		epochs.AppModuleBasic{},
 		servrewards.AppModuleBasic{},
 		proofofservice.AppModuleBasic{},
 		noderewards.AppModuleBasic{},
@@ -175,6 +178,7 @@ type ServApp struct {
 	TransferKeeper   ibctransferkeeper.Keeper
 
 	// Custom keepers
+	EpochsKeeper         epochskeeper.Keeper
 	ServRewardsKeeper    servrewardskeeper.Keeper
 	ProofOfServiceKeeper proofofservicekeeper.Keeper
 	NodeRewardsKeeper    noderewardskeeper.Keeper
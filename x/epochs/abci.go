@@ -0,0 +1,61 @@
+package epochs
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/serv-chain/serv/x/epochs/keeper"
+	"github.com/serv-chain/serv/x/epochs/types"
+)
+
+// BeginBlocker is called at the beginning of every block
+func BeginBlocker(ctx sdk.Context, k keeper.Keeper) {
+	// Collect the epochs first, the store must not be written while iterating it
+	for _, epoch := range k.AllEpochInfos(ctx) {
+		// Epochs do nothing until block time reaches their start time
+		if ctx.BlockTime().Before(epoch.StartTime) {
+			continue
+		}
+
+		epochEndTime := epoch.CurrentEpochStartTime.Add(epoch.Duration)
+		shouldInitialEpochStart := !epoch.EpochCountingStarted
+		shouldEpochEnd := epoch.EpochCountingStarted && !ctx.BlockTime().Before(epochEndTime)
+		if !shouldInitialEpochStart && !shouldEpochEnd {
+			continue
+		}
+
+		if shouldInitialEpochStart {
+			epoch.EpochCountingStarted = true
+			epoch.CurrentEpoch = 1
+			epoch.CurrentEpochStartTime = epoch.StartTime
+		} else {
+			ctx.EventManager().EmitEvent(
+				sdk.NewEvent(
+					types.EventTypeEpochEnd,
+					sdk.NewAttribute(types.AttributeKeyEpochIdentifier, epoch.Identifier),
+					sdk.NewAttribute(types.AttributeKeyEpochNumber, fmt.Sprintf("%d", epoch.CurrentEpoch)),
+				),
+			)
+			k.AfterEpochEnd(ctx, epoch.Identifier, epoch.CurrentEpoch)
+
+			// Epochs stay aligned to their start time rather than to the block
+			// that ended them. After a halt the missed epochs end one per block.
+			epoch.CurrentEpoch++
+			epoch.CurrentEpochStartTime = epochEndTime
+		}
+
+		epoch.CurrentEpochStartHeight = ctx.BlockHeight()
+		k.SetEpochInfo(ctx, epoch)
+
+		ctx.EventManager().EmitEvent(
+			sdk.NewEvent(
+				types.EventTypeEpochStart,
+				sdk.NewAttribute(types.AttributeKeyEpochIdentifier, epoch.Identifier),
+				sdk.NewAttribute(types.AttributeKeyEpochNumber, fmt.Sprintf("%d", epoch.CurrentEpoch)),
+				sdk.NewAttribute(types.AttributeKeyEpochStartTime, epoch.CurrentEpochStartTime.String()),
+				sdk.NewAttribute(types.AttributeKeyEpochStartHeight, fmt.Sprintf("%d", epoch.CurrentEpochStartHeight)),
+			),
+		)
+		k.BeforeEpochStart(ctx, epoch.Identifier, epoch.CurrentEpoch)
+	}
+}
@@ -0,0 +1,85 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/serv-chain/serv/x/epochs/types"
+)
+
+// GetQueryCmd returns the query commands for the epochs module
+func GetQueryCmd(queryRoute string) *cobra.Command {
+	epochsQueryCmd := &cobra.Command{
+		Use:                        types.ModuleName,
+		Short:                      fmt.Sprintf("Querying commands for the %s module", types.ModuleName),
+		DisableFlagParsing:         true,
+		SuggestionsMinimumDistance: 2,
+		RunE:                       client.ValidateCmd,
+	}
+
+	epochsQueryCmd.AddCommand(
+		GetCmdQueryEpochInfos(),
+		GetCmdQueryCurrentEpoch(),
+	)
+
+	return epochsQueryCmd
+}
+
+// GetCmdQueryEpochInfos implements the query epoch infos command handler
+func GetCmdQueryEpochInfos() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "epoch-infos",
+		Short: "Query all running epochs",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			queryClient := types.NewQueryClient(clientCtx)
+			res, err := queryClient.EpochInfos(cmd.Context(), &types.QueryEpochInfosRequest{})
+			if err != nil {
+				return err
+			}
+
+			return clientCtx.PrintProto(res)
+		},
+	}
+
+	flags.AddQueryFlagsToCmd(cmd)
+
+	return cmd
+}
+
+// GetCmdQueryCurrentEpoch implements the query current epoch command handler
+func GetCmdQueryCurrentEpoch() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "current-epoch [identifier]",
+		Short: "Query the current epoch number of an epoch identifier, e.g. day or week",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			queryClient := types.NewQueryClient(clientCtx)
+			res, err := queryClient.CurrentEpoch(cmd.Context(), &types.QueryCurrentEpochRequest{
+				Identifier: args[0],
+			})
+			if err != nil {
+				return err
+			}
+
+			return clientCtx.PrintProto(res)
+		},
+	}
+
+	flags.AddQueryFlagsToCmd(cmd)
+
+	return cmd
+}
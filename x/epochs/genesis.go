@@ -0,0 +1,23 @@
+package epochs
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/serv-chain/serv/x/epochs/keeper"
+	"github.com/serv-chain/serv/x/epochs/types"
+)
+
+// InitGenesis initializes the epochs module's state from a provided genesis state.
+func InitGenesis(ctx sdk.Context, k keeper.Keeper, genState types.GenesisState) {
+	for _, epoch := range genState.Epochs {
+		if err := k.AddEpochInfo(ctx, epoch); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// ExportGenesis returns the epochs module's exported genesis.
+func ExportGenesis(ctx sdk.Context, k keeper.Keeper) *types.GenesisState {
+	return &types.GenesisState{
+		Epochs: k.AllEpochInfos(ctx),
+	}
+}
@@ -0,0 +1,23 @@
+package epochs
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/serv-chain/serv/x/epochs/keeper"
+	"github.com/serv-chain/serv/x/epochs/types"
+)
+
+// NewHandler returns a handler for "epochs" type messages.
+func NewHandler(k keeper.Keeper) sdk.Handler {
+	// Epochs module doesn't have any messages to handle
+	// It only advances epochs in BeginBlocker and notifies subscribers through hooks
+
+	return func(ctx sdk.Context, msg sdk.Msg) (*sdk.Result, error) {
+		ctx = ctx.WithEventManager(sdk.NewEventManager())
+
+		errMsg := fmt.Sprintf("unrecognized %s message type: %T", types.ModuleName, msg)
+		return nil, sdkerrors.Wrap(sdkerrors.ErrUnknownRequest, errMsg)
+	}
+}
@@ -0,0 +1,55 @@
+package keeper
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/serv-chain/serv/x/epochs/types"
+)
+
+// Querier is used for implementing the Query gRPC service
+type Querier struct {
+	Keeper
+}
+
+// NewQueryServer creates a new gRPC query server for the epochs module
+func NewQueryServer(k Keeper) types.QueryServer {
+	return &Querier{Keeper: k}
+}
+
+// EpochInfos implements the Query/EpochInfos gRPC method
+func (q Querier) EpochInfos(c context.Context, req *types.QueryEpochInfosRequest) (*types.QueryEpochInfosResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+
+	ctx := sdk.UnwrapSDKContext(c)
+
+	return &types.QueryEpochInfosResponse{
+		Epochs: q.Keeper.AllEpochInfos(ctx),
+	}, nil
+}
+
+// CurrentEpoch implements the Query/CurrentEpoch gRPC method
+func (q Querier) CurrentEpoch(c context.Context, req *types.QueryCurrentEpochRequest) (*types.QueryCurrentEpochResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+
+	if req.Identifier == "" {
+		return nil, status.Error(codes.InvalidArgument, "epoch identifier cannot be empty")
+	}
+
+	ctx := sdk.UnwrapSDKContext(c)
+	epoch, found := q.Keeper.GetEpochInfo(ctx, req.Identifier)
+	if !found {
+		return nil, status.Errorf(codes.NotFound, "epoch %s not found", req.Identifier)
+	}
+
+	return &types.QueryCurrentEpochResponse{
+		CurrentEpoch: epoch.CurrentEpoch,
+	}, nil
+}
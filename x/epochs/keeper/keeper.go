@@ -0,0 +1,125 @@
+package keeper
+
+import (
+	"fmt"
+
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/serv-chain/serv/x/epochs/types"
+)
+
+// Keeper of the epochs store
+type Keeper struct {
+	storeKey sdk.StoreKey
+	cdc      codec.BinaryCodec
+
+	hooks types.EpochHooks
+}
+
+// NewKeeper creates a new epochs Keeper instance
+func NewKeeper(
+	cdc codec.BinaryCodec,
+	storeKey sdk.StoreKey,
+) *Keeper {
+	return &Keeper{
+		storeKey: storeKey,
+		cdc:      cdc,
+	}
+}
+
+// Logger returns a module-specific logger.
+func (k Keeper) Logger(ctx sdk.Context) log.Logger {
+	return ctx.Logger().With("module", fmt.Sprintf("x/%s", types.ModuleName))
+}
+
+// SetHooks sets the epochs hooks
+func (k *Keeper) SetHooks(h types.EpochHooks) *Keeper {
+	if k.hooks != nil {
+		panic("cannot set epochs hooks twice")
+	}
+	k.hooks = h
+	return k
+}
+
+// GetEpochInfo returns the epoch info for an identifier
+func (k Keeper) GetEpochInfo(ctx sdk.Context, identifier string) (types.EpochInfo, bool) {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(types.GetEpochInfoKey(identifier))
+	if bz == nil {
+		return types.EpochInfo{}, false
+	}
+
+	var epoch types.EpochInfo
+	k.cdc.MustUnmarshal(bz, &epoch)
+	return epoch, true
+}
+
+// SetEpochInfo sets the epoch info for its identifier
+func (k Keeper) SetEpochInfo(ctx sdk.Context, epoch types.EpochInfo) {
+	store := ctx.KVStore(k.storeKey)
+	bz := k.cdc.MustMarshal(&epoch)
+	store.Set(types.GetEpochInfoKey(epoch.Identifier), bz)
+}
+
+// AddEpochInfo starts tracking a new epoch. An epoch without a start time
+// starts at the current block time.
+func (k Keeper) AddEpochInfo(ctx sdk.Context, epoch types.EpochInfo) error {
+	if err := epoch.Validate(); err != nil {
+		return err
+	}
+
+	if _, found := k.GetEpochInfo(ctx, epoch.Identifier); found {
+		return fmt.Errorf("epoch with identifier %s already exists", epoch.Identifier)
+	}
+
+	if epoch.StartTime.IsZero() {
+		epoch.StartTime = ctx.BlockTime()
+	}
+	if epoch.CurrentEpochStartHeight == 0 {
+		epoch.CurrentEpochStartHeight = ctx.BlockHeight()
+	}
+
+	k.SetEpochInfo(ctx, epoch)
+	return nil
+}
+
+// IterateEpochInfos iterates over all epoch infos ordered by identifier
+func (k Keeper) IterateEpochInfos(ctx sdk.Context, cb func(epoch types.EpochInfo) (stop bool)) {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, types.EpochInfoPrefix)
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		var epoch types.EpochInfo
+		k.cdc.MustUnmarshal(iterator.Value(), &epoch)
+		if cb(epoch) {
+			break
+		}
+	}
+}
+
+// AllEpochInfos returns all epoch infos ordered by identifier
+func (k Keeper) AllEpochInfos(ctx sdk.Context) []types.EpochInfo {
+	epochs := []types.EpochInfo{}
+	k.IterateEpochInfos(ctx, func(epoch types.EpochInfo) bool {
+		epochs = append(epochs, epoch)
+		return false
+	})
+	return epochs
+}
+
+// AfterEpochEnd calls the AfterEpochEnd hook if hooks are set
+func (k Keeper) AfterEpochEnd(ctx sdk.Context, identifier string, epochNumber uint64) {
+	if k.hooks != nil {
+		k.hooks.AfterEpochEnd(ctx, identifier, epochNumber)
+	}
+}
+
+// BeforeEpochStart calls the BeforeEpochStart hook if hooks are set
+func (k Keeper) BeforeEpochStart(ctx sdk.Context, identifier string, epochNumber uint64) {
+	if k.hooks != nil {
+		k.hooks.BeforeEpochStart(ctx, identifier, epochNumber)
+	}
+}
@@ -0,0 +1,137 @@
+package test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/serv-chain/serv/x/epochs"
+	"github.com/serv-chain/serv/x/epochs/keeper"
+	"github.com/serv-chain/serv/x/epochs/types"
+)
+
+var genesisTime = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// Setup initializes a test keeper with a hooks recorder
+func Setup(t *testing.T) (*keeper.Keeper, sdk.Context, *MockEpochHooks) {
+	encodingConfig := MakeTestEncodingConfig()
+	storeKey := sdk.NewKVStoreKey(types.StoreKey)
+
+	hooks := NewMockEpochHooks()
+	k := keeper.NewKeeper(encodingConfig.Marshaler, storeKey)
+	k.SetHooks(hooks)
+
+	ctx := sdk.NewContext(
+		initMultiStore(t, storeKey),
+		tmproto.Header{Height: 1, Time: genesisTime},
+		false,
+		nil,
+	)
+
+	return k, ctx, hooks
+}
+
+// nextBlock returns the context of the next block at the given block time
+func nextBlock(ctx sdk.Context, blockTime time.Time) sdk.Context {
+	return ctx.WithBlockHeight(ctx.BlockHeight() + 1).WithBlockTime(blockTime)
+}
+
+// TestAddEpochInfo tests adding epochs
+func TestAddEpochInfo(t *testing.T) {
+	k, ctx, _ := Setup(t)
+
+	// An epoch without a start time starts at the current block time
+	require.NoError(t, k.AddEpochInfo(ctx, types.NewGenesisEpochInfo(types.DayEpochIdentifier, 24*time.Hour)))
+	epoch, found := k.GetEpochInfo(ctx, types.DayEpochIdentifier)
+	require.True(t, found)
+	require.Equal(t, genesisTime, epoch.StartTime)
+	require.Equal(t, int64(1), epoch.CurrentEpochStartHeight)
+
+	// Identifiers are unique
+	err := k.AddEpochInfo(ctx, types.NewGenesisEpochInfo(types.DayEpochIdentifier, time.Hour))
+	require.Error(t, err)
+
+	// Durations must be positive
+	err = k.AddEpochInfo(ctx, types.NewGenesisEpochInfo("never", 0))
+	require.Error(t, err)
+
+	require.Len(t, k.AllEpochInfos(ctx), 1)
+}
+
+// TestBeginBlockerEpochs tests that epochs start and end on block time and fire hooks
+func TestBeginBlockerEpochs(t *testing.T) {
+	k, ctx, hooks := Setup(t)
+	require.NoError(t, k.AddEpochInfo(ctx, types.NewGenesisEpochInfo(types.DayEpochIdentifier, 24*time.Hour)))
+
+	// The first epoch starts at its start time
+	epochs.BeginBlocker(ctx, *k)
+	require.Equal(t, []EpochHookCall{{"BeforeEpochStart", types.DayEpochIdentifier, 1, 1}}, hooks.Calls)
+
+	epoch, _ := k.GetEpochInfo(ctx, types.DayEpochIdentifier)
+	require.True(t, epoch.EpochCountingStarted)
+	require.Equal(t, uint64(1), epoch.CurrentEpoch)
+	require.Equal(t, genesisTime, epoch.CurrentEpochStartTime)
+
+	// Nothing happens before the epoch has lasted its duration
+	hooks.Reset()
+	ctx = nextBlock(ctx, genesisTime.Add(23*time.Hour))
+	epochs.BeginBlocker(ctx, *k)
+	require.Empty(t, hooks.Calls)
+
+	// The first block at or after the end time ends the epoch and starts the next
+	ctx = nextBlock(ctx, genesisTime.Add(24*time.Hour+5*time.Second))
+	epochs.BeginBlocker(ctx, *k)
+	require.Equal(t, []EpochHookCall{
+		{"AfterEpochEnd", types.DayEpochIdentifier, 1, 3},
+		{"BeforeEpochStart", types.DayEpochIdentifier, 2, 3},
+	}, hooks.Calls)
+
+	// Epochs stay aligned to their start time and record the height they started at
+	epoch, _ = k.GetEpochInfo(ctx, types.DayEpochIdentifier)
+	require.Equal(t, uint64(2), epoch.CurrentEpoch)
+	require.Equal(t, genesisTime.Add(24*time.Hour), epoch.CurrentEpochStartTime)
+	require.Equal(t, int64(3), epoch.CurrentEpochStartHeight)
+}
+
+// TestBeginBlockerCatchUp tests that epochs missed during a halt end one per block
+func TestBeginBlockerCatchUp(t *testing.T) {
+	k, ctx, hooks := Setup(t)
+	require.NoError(t, k.AddEpochInfo(ctx, types.NewGenesisEpochInfo(types.DayEpochIdentifier, 24*time.Hour)))
+	epochs.BeginBlocker(ctx, *k)
+
+	// The chain resumes three days later
+	ctx = nextBlock(ctx, genesisTime.Add(72*time.Hour))
+	for i := 0; i < 3; i++ {
+		hooks.Reset()
+		epochs.BeginBlocker(ctx, *k)
+		require.Len(t, hooks.Calls, 2)
+		ctx = nextBlock(ctx, ctx.BlockTime().Add(5*time.Second))
+	}
+
+	hooks.Reset()
+	epochs.BeginBlocker(ctx, *k)
+	require.Empty(t, hooks.Calls)
+
+	epoch, _ := k.GetEpochInfo(ctx, types.DayEpochIdentifier)
+	require.Equal(t, uint64(4), epoch.CurrentEpoch)
+	require.Equal(t, genesisTime.Add(72*time.Hour), epoch.CurrentEpochStartTime)
+}
+
+// TestGenesisValidate tests the genesis validation of epochs
+func TestGenesisValidate(t *testing.T) {
+	require.NoError(t, types.DefaultGenesis().Validate())
+
+	duplicate := types.GenesisState{Epochs: []types.EpochInfo{
+		types.NewGenesisEpochInfo(types.DayEpochIdentifier, 24*time.Hour),
+		types.NewGenesisEpochInfo(types.DayEpochIdentifier, time.Hour),
+	}}
+	require.Error(t, duplicate.Validate())
+
+	empty := types.GenesisState{Epochs: []types.EpochInfo{
+		types.NewGenesisEpochInfo("", time.Hour),
+	}}
+	require.Error(t, empty.Validate())
+}
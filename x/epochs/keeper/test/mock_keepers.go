@@ -0,0 +1,76 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/store"
+	storetypes "github.com/cosmos/cosmos-sdk/store/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	tmdb "github.com/tendermint/tm-db"
+)
+
+// EpochHookCall records a single hook invocation
+type EpochHookCall struct {
+	Hook        string
+	Identifier  string
+	EpochNumber uint64
+	Height      int64
+}
+
+// MockEpochHooks is a mock of the epoch hooks that records every call
+type MockEpochHooks struct {
+	Calls []EpochHookCall
+}
+
+// NewMockEpochHooks returns a new mock epoch hooks recorder
+func NewMockEpochHooks() *MockEpochHooks {
+	return &MockEpochHooks{}
+}
+
+// AfterEpochEnd implements the EpochHooks interface
+func (h *MockEpochHooks) AfterEpochEnd(ctx sdk.Context, epochIdentifier string, epochNumber uint64) {
+	h.Calls = append(h.Calls, EpochHookCall{"AfterEpochEnd", epochIdentifier, epochNumber, ctx.BlockHeight()})
+}
+
+// BeforeEpochStart implements the EpochHooks interface
+func (h *MockEpochHooks) BeforeEpochStart(ctx sdk.Context, epochIdentifier string, epochNumber uint64) {
+	h.Calls = append(h.Calls, EpochHookCall{"BeforeEpochStart", epochIdentifier, epochNumber, ctx.BlockHeight()})
+}
+
+// Reset clears the recorded calls
+func (h *MockEpochHooks) Reset() {
+	h.Calls = nil
+}
+
+// MakeTestEncodingConfig creates an EncodingConfig for testing
+func MakeTestEncodingConfig() TestEncodingConfig {
+	cdc := codec.NewLegacyAmino()
+	interfaceRegistry := codec.NewInterfaceRegistry()
+	marshaler := codec.NewProtoCodec(interfaceRegistry)
+
+	return TestEncodingConfig{
+		Marshaler:         marshaler,
+		Amino:             cdc,
+		InterfaceRegistry: interfaceRegistry,
+	}
+}
+
+// TestEncodingConfig specifies the concrete encoding types to use for a given app.
+// This is provided for compatibility between protobuf and amino implementations.
+type TestEncodingConfig struct {
+	Marshaler         codec.Codec
+	Amino             *codec.LegacyAmino
+	InterfaceRegistry codec.InterfaceRegistry
+}
+
+func initMultiStore(t *testing.T, storeKey storetypes.StoreKey) storetypes.CommitMultiStore {
+	db := tmdb.NewMemDB()
+	stateStore := store.NewCommitMultiStore(db)
+	stateStore.MountStoreWithDB(storeKey, storetypes.StoreTypeIAVL, db)
+	err := stateStore.LoadLatestVersion()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return stateStore
+}
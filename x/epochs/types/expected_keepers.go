@@ -0,0 +1,13 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// EpochHooks event hooks for epochs module
+type EpochHooks interface {
+	// AfterEpochEnd is called when an epoch ends, before the next one starts
+	AfterEpochEnd(ctx sdk.Context, epochIdentifier string, epochNumber uint64)
+	// BeforeEpochStart is called when an epoch starts, including the first one
+	BeforeEpochStart(ctx sdk.Context, epochIdentifier string, epochNumber uint64)
+}
@@ -0,0 +1,34 @@
+package types
+
+import (
+	"fmt"
+)
+
+// DefaultGenesis returns the default genesis state for the epochs module.
+func DefaultGenesis() *GenesisState {
+	return &GenesisState{
+		Epochs: DefaultEpochInfos(),
+	}
+}
+
+// GenesisState defines the epochs module's genesis state.
+type GenesisState struct {
+	Epochs []EpochInfo `json:"epochs"`
+}
+
+// Validate performs basic genesis state validation.
+func (gs GenesisState) Validate() error {
+	identifiers := make(map[string]bool)
+	for _, epoch := range gs.Epochs {
+		if err := epoch.Validate(); err != nil {
+			return err
+		}
+
+		if _, exists := identifiers[epoch.Identifier]; exists {
+			return fmt.Errorf("duplicate epoch identifier: %s", epoch.Identifier)
+		}
+		identifiers[epoch.Identifier] = true
+	}
+
+	return nil
+}
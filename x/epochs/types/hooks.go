@@ -0,0 +1,29 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+var _ EpochHooks = MultiEpochHooks{}
+
+// MultiEpochHooks combines multiple epoch hooks, all hook functions are run in array sequence
+type MultiEpochHooks []EpochHooks
+
+// NewMultiEpochHooks returns the hooks of several subscribing modules as one
+func NewMultiEpochHooks(hooks ...EpochHooks) MultiEpochHooks {
+	return hooks
+}
+
+// AfterEpochEnd implements EpochHooks
+func (h MultiEpochHooks) AfterEpochEnd(ctx sdk.Context, epochIdentifier string, epochNumber uint64) {
+	for i := range h {
+		h[i].AfterEpochEnd(ctx, epochIdentifier, epochNumber)
+	}
+}
+
+// BeforeEpochStart implements EpochHooks
+func (h MultiEpochHooks) BeforeEpochStart(ctx sdk.Context, epochIdentifier string, epochNumber uint64) {
+	for i := range h {
+		h[i].BeforeEpochStart(ctx, epochIdentifier, epochNumber)
+	}
+}
@@ -0,0 +1,28 @@
+package types
+
+const (
+	// ModuleName defines the module name
+	ModuleName = "epochs"
+
+	// StoreKey defines the primary module store key
+	StoreKey = ModuleName
+
+	// RouterKey defines the module's message routing key
+	RouterKey = ModuleName
+
+	// QuerierRoute defines the module's query routing key
+	QuerierRoute = ModuleName
+
+	// MemStoreKey defines the in-memory store key
+	MemStoreKey = "mem_epochs"
+)
+
+var (
+	// EpochInfoPrefix is the prefix for storing epoch infos
+	EpochInfoPrefix = []byte{0x01}
+)
+
+// GetEpochInfoKey returns the key for storing an epoch info
+func GetEpochInfoKey(identifier string) []byte {
+	return append(EpochInfoPrefix, []byte(identifier)...)
+}
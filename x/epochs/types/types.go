@@ -0,0 +1,74 @@
+package types
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	// DayEpochIdentifier identifies the epoch that ends once a day
+	DayEpochIdentifier = "day"
+
+	// WeekEpochIdentifier identifies the epoch that ends once a week
+	WeekEpochIdentifier = "week"
+)
+
+// EpochInfo represents the state of a time-based epoch
+type EpochInfo struct {
+	Identifier              string        `json:"identifier"`
+	StartTime               time.Time     `json:"start_time"`                 // Block time from which the first epoch starts
+	Duration                time.Duration `json:"duration"`                   // Wall-clock length of each epoch
+	CurrentEpoch            uint64        `json:"current_epoch"`              // Number of the running epoch, starting at 1
+	CurrentEpochStartTime   time.Time     `json:"current_epoch_start_time"`   // Scheduled start time of the running epoch
+	EpochCountingStarted    bool          `json:"epoch_counting_started"`     // Whether the first epoch has started
+	CurrentEpochStartHeight int64         `json:"current_epoch_start_height"` // Block height at which the running epoch started
+}
+
+// NewGenesisEpochInfo returns an epoch info that has not started yet. A zero
+// start time makes the first epoch start at the genesis block time.
+func NewGenesisEpochInfo(identifier string, duration time.Duration) EpochInfo {
+	return EpochInfo{
+		Identifier:              identifier,
+		StartTime:               time.Time{},
+		Duration:                duration,
+		CurrentEpoch:            0,
+		CurrentEpochStartTime:   time.Time{},
+		EpochCountingStarted:    false,
+		CurrentEpochStartHeight: 0,
+	}
+}
+
+// DefaultEpochInfos returns the epochs tracked by default
+func DefaultEpochInfos() []EpochInfo {
+	return []EpochInfo{
+		NewGenesisEpochInfo(DayEpochIdentifier, 24*time.Hour),
+		NewGenesisEpochInfo(WeekEpochIdentifier, 7*24*time.Hour),
+	}
+}
+
+// Validate performs basic validation of an epoch info
+func (e EpochInfo) Validate() error {
+	if err := ValidateEpochIdentifierString(e.Identifier); err != nil {
+		return err
+	}
+
+	if e.Duration <= 0 {
+		return fmt.Errorf("epoch duration must be positive: %s", e.Duration)
+	}
+
+	if e.CurrentEpochStartHeight < 0 {
+		return fmt.Errorf("epoch start height cannot be negative: %d", e.CurrentEpochStartHeight)
+	}
+
+	return nil
+}
+
+// ValidateEpochIdentifierString checks that an epoch identifier is set. Modules
+// subscribing to an epoch use it to validate their identifier params.
+func ValidateEpochIdentifierString(identifier string) error {
+	if identifier == "" {
+		return fmt.Errorf("epoch identifier cannot be empty")
+	}
+
+	return nil
+}
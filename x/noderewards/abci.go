@@ -1,20 +1,33 @@
 package noderewards
 
 import (
+	abci "github.com/tendermint/tendermint/abci/types"
+
 	"github.com/serv-chain/serv/x/noderewards/keeper"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 )
 
-// BeginBlocker is called at the beginning of every block
-func BeginBlocker(ctx sdk.Context, k keeper.Keeper) {
-	// Update node performance metrics at the beginning of each block
-	// This ensures that rewards distributed in this block use the latest metrics
-	k.UpdateAllNodePerformances(ctx)
+// BeginBlocker is called at the beginning of every block. It must run before
+// the x/distribution BeginBlocker, whose fee allocation it replaces.
+func BeginBlocker(ctx sdk.Context, req abci.RequestBeginBlock, k keeper.Keeper) {
+	// Refresh the performance metrics of the bonded validators every
+	// UpdateInterval blocks. Verified proofs and slashes update the affected
+	// validator as they happen, see the keeper hooks. Checkpoints and
+	// modifier normalizations that fell out of the retention window are
+	// pruned along with the update.
+	if k.IsNodePerformanceUpdateHeight(ctx) {
+		k.UpdateBondedNodePerformances(ctx)
+		k.PrunePerformanceCheckpoints(ctx)
+		k.PruneModifierNormalizations(ctx)
+	}
+
+	// Allocate the fees of the previous block to the validators in its last commit
+	k.AllocateTokens(ctx, req.LastCommitInfo.GetVotes())
 }
 
 // EndBlocker is called at the end of every block
 func EndBlocker(ctx sdk.Context, k keeper.Keeper) []sdk.ValidatorUpdate {
 	// No specific actions needed at the end of the block for noderewards
-	// The actual reward modification happens through hooks into the distribution module
+	// Rewards are modified when fees are allocated in BeginBlocker
 	return []sdk.ValidatorUpdate{}
 }
@@ -1,15 +1,33 @@
 package cli
 
 import (
+	"encoding/csv"
 	"fmt"
+	"strconv"
 
 	"github.com/spf13/cobra"
 
 	"github.com/cosmos/cosmos-sdk/client"
 	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/cosmos/cosmos-sdk/types/query"
+	"github.com/cosmos/cosmos-sdk/version"
 	"github.com/serv-chain/serv/x/noderewards/types"
 )
 
+// Flags of the export performance command
+const (
+	FlagFromHeight = "from-height"
+	FlagToHeight   = "to-height"
+	FlagFormat     = "format"
+	FlagPageSize   = "page-size"
+)
+
+// Formats the export performance command writes
+const (
+	ExportFormatCSV  = "csv"
+	ExportFormatJSON = "json"
+)
+
 // GetQueryCmd returns the query commands for the noderewards module
 func GetQueryCmd(queryRoute string) *cobra.Command {
 	nodeRewardsQueryCmd := &cobra.Command{
@@ -24,6 +42,10 @@ func GetQueryCmd(queryRoute string) *cobra.Command {
 		GetCmdQueryRewardModifier(),
 		GetCmdQueryNodePerformance(),
 		GetCmdQueryRewardModifierForValidator(),
+		GetCmdQueryModifierNormalization(),
+		GetCmdQueryValidatorTier(),
+		GetCmdQueryTierHistory(),
+		GetCmdExportPerformance(),
 	)
 
 	return nodeRewardsQueryCmd
@@ -60,7 +82,7 @@ func GetCmdQueryRewardModifier() *cobra.Command {
 func GetCmdQueryNodePerformance() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "performance [validator-address]",
-		Short: "Query the performance metrics for a validator node",
+		Short: "Query the raw and smoothed performance metrics for a validator node",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			clientCtx, err := client.GetClientQueryContext(cmd)
@@ -113,3 +135,232 @@ func GetCmdQueryRewardModifierForValidator() *cobra.Command {
 
 	return cmd
 }
+
+// GetCmdQueryModifierNormalization implements the query modifier normalization command handler
+func GetCmdQueryModifierNormalization() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "normalization [block-height]",
+		Short: "Query the factor the reward modifiers were normalized by in a block, or the latest one",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			var height int64
+			if len(args) > 0 {
+				height, err = strconv.ParseInt(args[0], 10, 64)
+				if err != nil {
+					return fmt.Errorf("invalid block height %s: %w", args[0], err)
+				}
+			}
+
+			queryClient := types.NewQueryClient(clientCtx)
+			res, err := queryClient.ModifierNormalization(cmd.Context(), &types.QueryModifierNormalizationRequest{Height: height})
+			if err != nil {
+				return err
+			}
+
+			return clientCtx.PrintProto(res)
+		},
+	}
+
+	flags.AddQueryFlagsToCmd(cmd)
+
+	return cmd
+}
+
+// GetCmdQueryValidatorTier implements the query validator tier command handler
+func GetCmdQueryValidatorTier() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "tier [validator-address]",
+		Short: "Query the performance tier a validator was last ranked in and whether it is on probation",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			queryClient := types.NewQueryClient(clientCtx)
+			res, err := queryClient.ValidatorTier(cmd.Context(), &types.QueryValidatorTierRequest{
+				ValidatorAddr: args[0],
+			})
+			if err != nil {
+				return err
+			}
+
+			return clientCtx.PrintProto(res)
+		},
+	}
+
+	flags.AddQueryFlagsToCmd(cmd)
+
+	return cmd
+}
+
+// GetCmdQueryTierHistory implements the query tier history command handler
+func GetCmdQueryTierHistory() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "tier-history [validator-address]",
+		Short: "Query the performance tiers a validator was ranked in per epoch",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			pageReq, err := client.ReadPageRequest(cmd.Flags())
+			if err != nil {
+				return err
+			}
+
+			queryClient := types.NewQueryClient(clientCtx)
+			res, err := queryClient.TierHistory(cmd.Context(), &types.QueryTierHistoryRequest{
+				ValidatorAddr: args[0],
+				Pagination:    pageReq,
+			})
+			if err != nil {
+				return err
+			}
+
+			return clientCtx.PrintProto(res)
+		},
+	}
+
+	flags.AddQueryFlagsToCmd(cmd)
+	flags.AddPaginationFlagsToCmd(cmd, "tier-history")
+
+	return cmd
+}
+
+// GetCmdExportPerformance implements the export performance command handler
+func GetCmdExportPerformance() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "export-performance [validator-address]",
+		Short: "Export the performance checkpoints of a validator, or of all validators, as CSV or JSON",
+		Long: `Export the performance checkpoints of a validator, or of all validators, as CSV or JSON.
+
+A checkpoint is recorded every time the performance of a validator is updated
+and kept for checkpoint_retention blocks. Checkpoints are fetched page by page
+and written as they arrive, ordered by validator and height: csv writes a
+header row followed by one row per checkpoint, json writes one JSON object per
+line. A zero --from-height or --to-height leaves the range open on that side.`,
+		Example: fmt.Sprintf("%s query %s export-performance --%s 100000 --%s 200000 --%s csv > performance.csv",
+			version.AppName, types.ModuleName, FlagFromHeight, FlagToHeight, FlagFormat),
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			fromHeight, _ := cmd.Flags().GetInt64(FlagFromHeight)
+			toHeight, _ := cmd.Flags().GetInt64(FlagToHeight)
+			if err := types.ValidateHeightRange(fromHeight, toHeight); err != nil {
+				return err
+			}
+
+			format, _ := cmd.Flags().GetString(FlagFormat)
+			if format != ExportFormatCSV && format != ExportFormatJSON {
+				return fmt.Errorf("invalid format %q, expected %s or %s", format, ExportFormatCSV, ExportFormatJSON)
+			}
+
+			pageSize, _ := cmd.Flags().GetUint64(FlagPageSize)
+			if pageSize == 0 {
+				return fmt.Errorf("--%s must be positive", FlagPageSize)
+			}
+
+			validatorAddr := ""
+			if len(args) > 0 {
+				validatorAddr = args[0]
+			}
+
+			out := cmd.OutOrStdout()
+			csvWriter := csv.NewWriter(out)
+			if format == ExportFormatCSV {
+				if err := csvWriter.Write(checkpointCSVHeader); err != nil {
+					return err
+				}
+			}
+
+			queryClient := types.NewQueryClient(clientCtx)
+			pageReq := &query.PageRequest{Limit: pageSize}
+			for {
+				res, err := queryClient.PerformanceHistory(cmd.Context(), &types.QueryPerformanceHistoryRequest{
+					ValidatorAddr: validatorAddr,
+					FromHeight:    fromHeight,
+					ToHeight:      toHeight,
+					Pagination:    pageReq,
+				})
+				if err != nil {
+					return err
+				}
+
+				for i := range res.Checkpoints {
+					if format == ExportFormatCSV {
+						if err := csvWriter.Write(checkpointCSVRecord(res.Checkpoints[i])); err != nil {
+							return err
+						}
+						continue
+					}
+
+					bz, err := clientCtx.Codec.MarshalJSON(&res.Checkpoints[i])
+					if err != nil {
+						return err
+					}
+					if _, err := fmt.Fprintln(out, string(bz)); err != nil {
+						return err
+					}
+				}
+
+				// Flush every page so that the export streams
+				csvWriter.Flush()
+				if err := csvWriter.Error(); err != nil {
+					return err
+				}
+
+				if res.Pagination == nil || len(res.Pagination.NextKey) == 0 {
+					return nil
+				}
+				pageReq = &query.PageRequest{Key: res.Pagination.NextKey, Limit: pageSize}
+			}
+		},
+	}
+
+	cmd.Flags().Int64(FlagFromHeight, 0, "Lowest height to export, 0 exports from the oldest checkpoint")
+	cmd.Flags().Int64(FlagToHeight, 0, "Highest height to export, 0 exports up to the latest checkpoint")
+	cmd.Flags().String(FlagFormat, ExportFormatCSV, "Output format, csv or json")
+	cmd.Flags().Uint64(FlagPageSize, 100, "Number of checkpoints fetched per query")
+	flags.AddQueryFlagsToCmd(cmd)
+
+	return cmd
+}
+
+// checkpointCSVHeader is the header row of the CSV performance export
+var checkpointCSVHeader = []string{
+	"validator_addr",
+	"height",
+	"service_score",
+	"uptime_percent",
+	"response_time",
+	"smoothed_service_score",
+	"smoothed_uptime_percent",
+	"smoothed_response_time",
+}
+
+// checkpointCSVRecord returns the row of a checkpoint in the CSV performance export
+func checkpointCSVRecord(checkpoint types.PerformanceCheckpoint) []string {
+	return []string{
+		checkpoint.ValidatorAddr,
+		strconv.FormatInt(checkpoint.Height, 10),
+		checkpoint.ServiceScore.String(),
+		checkpoint.UptimePercent.String(),
+		checkpoint.ResponseTime.String(),
+		checkpoint.SmoothedServiceScore.String(),
+		checkpoint.SmoothedUptimePercent.String(),
+		checkpoint.SmoothedResponseTime.String(),
+	}
+}
@@ -0,0 +1,205 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/cosmos/cosmos-sdk/client/tx"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/version"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
+	"github.com/serv-chain/serv/x/noderewards/types"
+)
+
+// Flags of the draft reward modifier proposal command
+const (
+	FlagTitle              = "title"
+	FlagSummary            = "summary"
+	FlagDeposit            = "deposit"
+	FlagServiceScoreWeight = "service-score-weight"
+	FlagUptimeWeight       = "uptime-weight"
+	FlagResponseTimeWeight = "response-time-weight"
+	FlagMinModifier        = "min-modifier"
+	FlagMaxModifier        = "max-modifier"
+)
+
+// GetTxCmd returns the transaction commands for the noderewards module
+func GetTxCmd() *cobra.Command {
+	nodeRewardsTxCmd := &cobra.Command{
+		Use:                        types.ModuleName,
+		Short:                      fmt.Sprintf("%s transactions subcommands", types.ModuleName),
+		DisableFlagParsing:         true,
+		SuggestionsMinimumDistance: 2,
+		RunE:                       client.ValidateCmd,
+	}
+
+	nodeRewardsTxCmd.AddCommand(
+		NewUpdateRewardModifierCmd(),
+		NewDraftRewardModifierProposalCmd(),
+	)
+
+	return nodeRewardsTxCmd
+}
+
+// NewUpdateRewardModifierCmd implements the update reward modifier command handler.
+// The message is signed by the gov module account, so it can only be executed
+// as part of an x/gov v1 proposal.
+func NewUpdateRewardModifierCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "update-reward-modifier [modifier-file]",
+		Short: "Update the reward modifier from a JSON file (governance)",
+		Long: `Update the reward modifier from a JSON file (governance).
+
+Example modifier file:
+{
+  "service_score_weight": "0.5",
+  "uptime_weight": "0.3",
+  "response_time_weight": "0.2",
+  "min_modifier": "0.5",
+  "max_modifier": "2.0"
+}
+
+The weights must be non-negative and sum to 1. The modifier of every validator
+is kept between min_modifier and max_modifier, which must be non-negative with
+min_modifier at most max_modifier.
+
+The message is only accepted from the x/gov module account. Use --generate-only
+and include the generated message in a proposal submitted with
+"tx gov submit-proposal", or draft the proposal with
+draft-reward-modifier-proposal.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			bz, err := os.ReadFile(args[0])
+			if err != nil {
+				return fmt.Errorf("failed to read modifier file: %w", err)
+			}
+
+			var modifier types.RewardModifier
+			if err := clientCtx.Codec.UnmarshalJSON(bz, &modifier); err != nil {
+				return fmt.Errorf("invalid modifier file: %w", err)
+			}
+
+			msg := types.NewMsgUpdateRewardModifier(
+				authtypes.NewModuleAddress(govtypes.ModuleName).String(),
+				modifier,
+			)
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), msg)
+		},
+	}
+
+	flags.AddTxFlagsToCmd(cmd)
+
+	return cmd
+}
+
+// rewardModifierProposal is the proposal file read by "tx gov submit-proposal"
+type rewardModifierProposal struct {
+	Messages []json.RawMessage `json:"messages"`
+	Metadata string            `json:"metadata"`
+	Deposit  string            `json:"deposit"`
+	Title    string            `json:"title"`
+	Summary  string            `json:"summary"`
+}
+
+// NewDraftRewardModifierProposalCmd implements the draft reward modifier proposal command handler
+func NewDraftRewardModifierProposalCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "draft-reward-modifier-proposal",
+		Short: "Print a governance proposal template updating the reward modifier",
+		Long: `Print a governance proposal template updating the reward modifier.
+
+The template starts from the current reward modifier, with the fields given as
+flags changed. It is checked like the message itself, so the weights must sum
+to 1 and the minimum modifier must not exceed the maximum. Save it to a file,
+fill in the metadata and submit it with "tx gov submit-proposal".`,
+		Example: fmt.Sprintf("%s tx %s draft-reward-modifier-proposal --%s 0.6 --%s 0.2 --%s 1000000serv > proposal.json",
+			version.AppName, types.ModuleName, FlagServiceScoreWeight, FlagResponseTimeWeight, FlagDeposit),
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			queryClient := types.NewQueryClient(clientCtx)
+			res, err := queryClient.RewardModifier(cmd.Context(), &types.QueryRewardModifierRequest{})
+			if err != nil {
+				return err
+			}
+
+			modifier := *res.Modifier
+			for flag, field := range map[string]*sdk.Dec{
+				FlagServiceScoreWeight: &modifier.ServiceScoreWeight,
+				FlagUptimeWeight:       &modifier.UptimeWeight,
+				FlagResponseTimeWeight: &modifier.ResponseTimeWeight,
+				FlagMinModifier:        &modifier.MinModifier,
+				FlagMaxModifier:        &modifier.MaxModifier,
+			} {
+				s, _ := cmd.Flags().GetString(flag)
+				if s == "" {
+					continue
+				}
+
+				value, err := sdk.NewDecFromStr(s)
+				if err != nil {
+					return fmt.Errorf("invalid --%s: %w", flag, err)
+				}
+				*field = value
+			}
+
+			msg := types.NewMsgUpdateRewardModifier(
+				authtypes.NewModuleAddress(govtypes.ModuleName).String(),
+				modifier,
+			)
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+
+			msgJSON, err := clientCtx.Codec.MarshalInterfaceJSON(msg)
+			if err != nil {
+				return err
+			}
+
+			proposal := rewardModifierProposal{
+				Messages: []json.RawMessage{msgJSON},
+			}
+			proposal.Title, _ = cmd.Flags().GetString(FlagTitle)
+			proposal.Summary, _ = cmd.Flags().GetString(FlagSummary)
+			proposal.Deposit, _ = cmd.Flags().GetString(FlagDeposit)
+
+			bz, err := json.MarshalIndent(proposal, "", "  ")
+			if err != nil {
+				return err
+			}
+
+			return clientCtx.PrintBytes(bz)
+		},
+	}
+
+	cmd.Flags().String(FlagTitle, "Update the node rewards modifier", "Title of the proposal")
+	cmd.Flags().String(FlagSummary, "", "Summary of the proposal")
+	cmd.Flags().String(FlagDeposit, "", "Deposit of the proposal, e.g. 1000000serv")
+	cmd.Flags().String(FlagServiceScoreWeight, "", "Weight of the service score in the modifier")
+	cmd.Flags().String(FlagUptimeWeight, "", "Weight of the uptime in the modifier")
+	cmd.Flags().String(FlagResponseTimeWeight, "", "Weight of the response time in the modifier")
+	cmd.Flags().String(FlagMinModifier, "", "Lowest reward modifier")
+	cmd.Flags().String(FlagMaxModifier, "", "Highest reward modifier")
+	flags.AddQueryFlagsToCmd(cmd)
+
+	return cmd
+}
@@ -8,6 +8,9 @@ import (
 
 // InitGenesis initializes the noderewards module's state from a provided genesis state.
 func InitGenesis(ctx sdk.Context, k keeper.Keeper, genState types.GenesisState) {
+	// Set module parameters
+	k.SetParams(ctx, genState.Params)
+	
 	// Set reward modifier parameters
 	k.SetRewardModifier(ctx, genState.RewardModifier)
 	
@@ -15,19 +18,79 @@ func InitGenesis(ctx sdk.Context, k keeper.Keeper, genState types.GenesisState)
 	for _, performance := range genState.NodePerformances {
 		k.SetNodePerformance(ctx, performance)
 	}
+	
+	// Set smoothed node performance metrics
+	for _, smoothed := range genState.SmoothedPerformances {
+		k.SetSmoothedPerformance(ctx, smoothed)
+	}
+	
+	// Set performance tiers and their history
+	for _, tier := range genState.ValidatorTiers {
+		k.SetValidatorTier(ctx, tier)
+	}
+	for _, tier := range genState.TierHistory {
+		k.SetTierHistory(ctx, tier)
+	}
+	
+	// Set performance checkpoints
+	for _, checkpoint := range genState.PerformanceCheckpoints {
+		k.SetPerformanceCheckpoint(ctx, checkpoint)
+	}
+	
+	// Set modifier normalizations
+	for _, normalization := range genState.ModifierNormalizations {
+		k.SetModifierNormalization(ctx, normalization)
+	}
 }
 
 // ExportGenesis returns the noderewards module's exported genesis.
 func ExportGenesis(ctx sdk.Context, k keeper.Keeper) *types.GenesisState {
 	rewardModifier := k.GetRewardModifier(ctx)
 	
-	// Get all node performances
-	// In a real implementation, we would iterate over all node performances in the store
-	// For now, we'll return an empty list
 	nodePerformances := []types.NodePerformance{}
+	k.IterateNodePerformances(ctx, func(performance types.NodePerformance) bool {
+		nodePerformances = append(nodePerformances, performance)
+		return false
+	})
+	
+	smoothedPerformances := []types.SmoothedPerformance{}
+	k.IterateSmoothedPerformances(ctx, func(smoothed types.SmoothedPerformance) bool {
+		smoothedPerformances = append(smoothedPerformances, smoothed)
+		return false
+	})
+	
+	validatorTiers := []types.ValidatorTier{}
+	k.IterateValidatorTiers(ctx, func(tier types.ValidatorTier) bool {
+		validatorTiers = append(validatorTiers, tier)
+		return false
+	})
+	
+	tierHistory := []types.ValidatorTier{}
+	k.IterateTierHistory(ctx, func(tier types.ValidatorTier) bool {
+		tierHistory = append(tierHistory, tier)
+		return false
+	})
+	
+	checkpoints := []types.PerformanceCheckpoint{}
+	k.IteratePerformanceCheckpoints(ctx, func(checkpoint types.PerformanceCheckpoint) bool {
+		checkpoints = append(checkpoints, checkpoint)
+		return false
+	})
+	
+	normalizations := []types.ModifierNormalization{}
+	k.IterateModifierNormalizations(ctx, func(normalization types.ModifierNormalization) bool {
+		normalizations = append(normalizations, normalization)
+		return false
+	})
 	
 	return &types.GenesisState{
-		RewardModifier:   rewardModifier,
-		NodePerformances: nodePerformances,
+		Params:                 k.GetParams(ctx),
+		RewardModifier:         rewardModifier,
+		NodePerformances:       nodePerformances,
+		SmoothedPerformances:   smoothedPerformances,
+		ValidatorTiers:         validatorTiers,
+		TierHistory:            tierHistory,
+		PerformanceCheckpoints: checkpoints,
+		ModifierNormalizations: normalizations,
 	}
 }
@@ -11,13 +11,23 @@ import (
 
 // NewHandler returns a handler for "noderewards" type messages.
 func NewHandler(k keeper.Keeper) sdk.Handler {
-	// Node rewards module doesn't have any messages to handle directly
-	// It primarily works through hooks and BeginBlocker/EndBlocker
+	// Node rewards module primarily works through hooks and BeginBlocker/EndBlocker,
+	// its only messages are governance parameter and reward modifier updates
+	msgServer := keeper.NewMsgServer(k)
 
 	return func(ctx sdk.Context, msg sdk.Msg) (*sdk.Result, error) {
 		ctx = ctx.WithEventManager(sdk.NewEventManager())
 
-		errMsg := fmt.Sprintf("unrecognized %s message type: %T", types.ModuleName, msg)
-		return nil, sdkerrors.Wrap(sdkerrors.ErrUnknownRequest, errMsg)
+		switch msg := msg.(type) {
+		case *types.MsgUpdateParams:
+			res, err := msgServer.UpdateParams(sdk.WrapSDKContext(ctx), msg)
+			return sdk.WrapServiceResult(ctx, res, err)
+		case *types.MsgUpdateRewardModifier:
+			res, err := msgServer.UpdateRewardModifier(sdk.WrapSDKContext(ctx), msg)
+			return sdk.WrapServiceResult(ctx, res, err)
+		default:
+			errMsg := fmt.Sprintf("unrecognized %s message type: %T", types.ModuleName, msg)
+			return nil, sdkerrors.Wrap(sdkerrors.ErrUnknownRequest, errMsg)
+		}
 	}
 }
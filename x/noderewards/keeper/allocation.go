@@ -0,0 +1,152 @@
+package keeper
+
+import (
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	distrtypes "github.com/cosmos/cosmos-sdk/x/distribution/types"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+	"github.com/serv-chain/serv/x/noderewards/types"
+)
+
+// validatorAllocation is the reward of a validator in a block
+type validatorAllocation struct {
+	validator stakingtypes.Validator
+	power     int64
+	modifier  sdk.Dec
+	reward    sdk.DecCoins
+}
+
+// AllocateTokens allocates the fees collected in the previous block to the
+// validators in its last commit, replacing the fee allocation of x/distribution.
+// As in x/distribution, every validator in the commit is paid whether or not
+// it signed, the community tax goes to the community pool and the rest is
+// shared among the validators by voting power, but the share of each
+// validator is scaled by its reward modifier. With NormalizeModifiers the
+// modifiers are first rescaled so that the modified shares add up to the
+// shares of the active set, otherwise modified shares exceeding the fees are
+// scaled down to fit. Jailed and tombstoned validators get nothing. Whatever
+// the validators leave goes to the community pool. It must
+// run before the x/distribution BeginBlocker, which then finds the fee
+// collector empty.
+func (k Keeper) AllocateTokens(ctx sdk.Context, votes []abci.VoteInfo) {
+	feeCollector := authtypes.NewModuleAddress(authtypes.FeeCollectorName)
+	fees := k.bankKeeper.GetAllBalances(ctx, feeCollector)
+	if fees.IsZero() {
+		return
+	}
+
+	// The distribution module holds the rewards of validators and the community pool
+	if err := k.bankKeeper.SendCoinsFromModuleToModule(ctx, authtypes.FeeCollectorName, distrtypes.ModuleName, fees); err != nil {
+		panic(err)
+	}
+
+	feesDec := sdk.NewDecCoinsFromCoins(fees...)
+	validatorPool := feesDec.MulDecTruncate(sdk.OneDec().Sub(k.distrKeeper.GetCommunityTax(ctx)))
+
+	totalPower := int64(0)
+	for _, vote := range votes {
+		totalPower += vote.Validator.Power
+	}
+
+	var allocations []validatorAllocation
+	activePower := int64(0)
+	weightedModifiers := sdk.ZeroDec()
+	if totalPower > 0 {
+		for _, vote := range votes {
+			validator, found := k.stakingKeeper.GetValidatorByConsAddr(ctx, sdk.ConsAddress(vote.Validator.Address))
+			if !found {
+				continue
+			}
+
+			// Jailed and tombstoned validators earn nothing and do not dilute the active set
+			if k.IsValidatorPenalized(ctx, validator) {
+				continue
+			}
+
+			modifier := k.CalculateRewardModifier(ctx, validator.GetOperator().String())
+			allocations = append(allocations, validatorAllocation{
+				validator: validator,
+				power:     vote.Validator.Power,
+				modifier:  modifier,
+			})
+			activePower += vote.Validator.Power
+			weightedModifiers = weightedModifiers.Add(modifier.MulInt64(vote.Validator.Power))
+		}
+	}
+
+	factor := k.normalizationFactor(ctx, activePower, weightedModifiers)
+
+	modifiedTotal := sdk.NewDecCoins()
+	for i, allocation := range allocations {
+		baseReward := validatorPool.MulDecTruncate(sdk.NewDec(allocation.power).QuoInt64(totalPower))
+		reward := k.applyRewardModifier(ctx, allocation.validator.GetOperator().String(), baseReward, allocation.modifier.Mul(factor))
+		allocations[i].reward = reward
+		modifiedTotal = modifiedTotal.Add(reward...)
+	}
+
+	allocated := sdk.NewDecCoins()
+	for _, allocation := range allocations {
+		reward := fitReward(allocation.reward, modifiedTotal, validatorPool)
+		if reward.IsZero() {
+			continue
+		}
+
+		k.distrKeeper.AllocateTokensToValidator(ctx, allocation.validator, reward)
+		allocated = allocated.Add(reward...)
+	}
+
+	// The community tax and whatever the modifiers left go to the community pool
+	communityPool := feesDec.Sub(allocated)
+	feePool := k.distrKeeper.GetFeePool(ctx)
+	feePool.CommunityPool = feePool.CommunityPool.Add(communityPool...)
+	k.distrKeeper.SetFeePool(ctx, feePool)
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			types.EventTypeRewardsAllocated,
+			sdk.NewAttribute(types.AttributeKeyFees, fees.String()),
+			sdk.NewAttribute(types.AttributeKeyAllocated, allocated.String()),
+			sdk.NewAttribute(types.AttributeKeyCommunityPool, communityPool.String()),
+			sdk.NewAttribute(types.AttributeKeyNormalizationFactor, factor.String()),
+		),
+	)
+}
+
+// normalizationFactor returns the factor that rescales the modifiers of the
+// active set so that their power weighted sum equals its power, and records it
+// for the block. Rescaling every modifier by the same factor keeps the payouts
+// equal to the base pool: the share underperformers forfeit goes to the
+// validators performing above the average. It is one when NormalizeModifiers
+// is disabled or no modifier is positive, in which case nothing is recorded.
+func (k Keeper) normalizationFactor(ctx sdk.Context, activePower int64, weightedModifiers sdk.Dec) sdk.Dec {
+	if !k.GetParams(ctx).NormalizeModifiers || !weightedModifiers.IsPositive() {
+		return sdk.OneDec()
+	}
+
+	factor := sdk.NewDec(activePower).Quo(weightedModifiers)
+	k.SetModifierNormalization(ctx, types.ModifierNormalization{
+		Height: ctx.BlockHeight(),
+		Factor: factor,
+	})
+
+	return factor
+}
+
+// fitReward scales reward down in every denom where the modified rewards of
+// all validators exceed the pool, so that together they fit in it
+func fitReward(reward, modifiedTotal, pool sdk.DecCoins) sdk.DecCoins {
+	fitted := sdk.NewDecCoins()
+	for _, coin := range reward {
+		total := modifiedTotal.AmountOf(coin.Denom)
+		available := pool.AmountOf(coin.Denom)
+		amount := coin.Amount
+		if total.GT(available) {
+			amount = amount.MulTruncate(available).QuoTruncate(total)
+		}
+		fitted = fitted.Add(sdk.NewDecCoinFromDec(coin.Denom, amount))
+	}
+
+	return fitted
+}
@@ -0,0 +1,169 @@
+package keeper
+
+import (
+	"github.com/cosmos/cosmos-sdk/store/prefix"
+	storetypes "github.com/cosmos/cosmos-sdk/store/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/query"
+	"github.com/serv-chain/serv/x/noderewards/types"
+)
+
+// SetPerformanceCheckpoint records the performance of a validator at a height,
+// replacing an earlier checkpoint of the same height
+func (k Keeper) SetPerformanceCheckpoint(ctx sdk.Context, checkpoint types.PerformanceCheckpoint) {
+	store := ctx.KVStore(k.storeKey)
+	bz := k.cdc.MustMarshal(&checkpoint)
+	store.Set(types.GetPerformanceCheckpointKey(checkpoint.ValidatorAddr, checkpoint.Height), bz)
+	store.Set(types.GetCheckpointHeightIndexKey(checkpoint.Height, checkpoint.ValidatorAddr), []byte{})
+}
+
+// IteratePerformanceCheckpoints iterates over the performance checkpoints of
+// all validators, ordered by validator and height
+func (k Keeper) IteratePerformanceCheckpoints(ctx sdk.Context, cb func(checkpoint types.PerformanceCheckpoint) (stop bool)) {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, types.PerformanceCheckpointPrefix)
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		var checkpoint types.PerformanceCheckpoint
+		k.cdc.MustUnmarshal(iterator.Value(), &checkpoint)
+		if cb(checkpoint) {
+			break
+		}
+	}
+}
+
+// performanceCheckpointStore returns the store of the performance checkpoints
+// of a validator keyed by height, or of all validators if validatorAddr is empty
+func (k Keeper) performanceCheckpointStore(ctx sdk.Context, validatorAddr string) prefix.Store {
+	if validatorAddr == "" {
+		return prefix.NewStore(ctx.KVStore(k.storeKey), types.PerformanceCheckpointPrefix)
+	}
+
+	return prefix.NewStore(ctx.KVStore(k.storeKey), types.GetPerformanceCheckpointsPrefix(validatorAddr))
+}
+
+// paginateCheckpointRange pages through the performance checkpoints of a
+// validator in a height range validated by ValidateHeightRange. Only the keys
+// in the range are iterated, rather than the whole retention window. Offsets,
+// page keys and reverse order follow query.Paginate.
+func (k Keeper) paginateCheckpointRange(ctx sdk.Context, validatorAddr string, fromHeight, toHeight int64, pageReq *query.PageRequest) ([]types.PerformanceCheckpoint, *query.PageResponse, error) {
+	if pageReq == nil {
+		pageReq = &query.PageRequest{}
+	}
+
+	offset, limit, countTotal := pageReq.Offset, pageReq.Limit, pageReq.CountTotal
+	if limit == 0 {
+		limit = query.DefaultLimit
+		countTotal = true
+	}
+	if len(pageReq.Key) > 0 {
+		offset = 0
+		countTotal = false
+	}
+
+	// Keys are the big endian heights, the to height is inclusive
+	start := sdk.Uint64ToBigEndian(uint64(fromHeight))
+	var end []byte
+	if toHeight > 0 {
+		end = sdk.Uint64ToBigEndian(uint64(toHeight + 1))
+	}
+
+	store := k.performanceCheckpointStore(ctx, validatorAddr)
+	var iterator sdk.Iterator
+	if pageReq.Reverse {
+		if len(pageReq.Key) > 0 {
+			end = storetypes.InclusiveEndBytes(pageReq.Key)
+		}
+		iterator = store.ReverseIterator(start, end)
+	} else {
+		if len(pageReq.Key) > 0 {
+			start = pageReq.Key
+		}
+		iterator = store.Iterator(start, end)
+	}
+	defer iterator.Close()
+
+	var checkpoints []types.PerformanceCheckpoint
+	var nextKey []byte
+	count := uint64(0)
+	for ; iterator.Valid(); iterator.Next() {
+		count++
+		if count <= offset {
+			continue
+		}
+		if count > offset+limit {
+			if nextKey == nil {
+				nextKey = iterator.Key()
+			}
+			if !countTotal {
+				break
+			}
+			continue
+		}
+
+		var checkpoint types.PerformanceCheckpoint
+		if err := k.cdc.Unmarshal(iterator.Value(), &checkpoint); err != nil {
+			return nil, nil, err
+		}
+		checkpoints = append(checkpoints, checkpoint)
+	}
+
+	pageRes := &query.PageResponse{NextKey: nextKey}
+	if countTotal {
+		pageRes.Total = count
+	}
+
+	return checkpoints, pageRes, nil
+}
+
+// PrunePerformanceCheckpoints removes the performance checkpoints that fall
+// outside CheckpointRetention
+func (k Keeper) PrunePerformanceCheckpoints(ctx sdk.Context) {
+	retention := k.GetParams(ctx).CheckpointRetention
+	if retention == 0 || uint64(ctx.BlockHeight()) <= retention {
+		return
+	}
+
+	// Checkpoints below the cutoff height are pruned
+	cutoff := ctx.BlockHeight() - int64(retention)
+
+	store := ctx.KVStore(k.storeKey)
+	iterator := store.Iterator(types.CheckpointHeightIndexPrefix, types.GetCheckpointHeightIndexPrefix(cutoff))
+	var indexKeys [][]byte
+	for ; iterator.Valid(); iterator.Next() {
+		indexKeys = append(indexKeys, iterator.Key())
+	}
+	iterator.Close()
+
+	for _, indexKey := range indexKeys {
+		height := int64(sdk.BigEndianToUint64(indexKey[len(types.CheckpointHeightIndexPrefix) : len(types.CheckpointHeightIndexPrefix)+8]))
+		validatorAddr := string(indexKey[len(types.CheckpointHeightIndexPrefix)+8:])
+		store.Delete(types.GetPerformanceCheckpointKey(validatorAddr, height))
+		store.Delete(indexKey)
+	}
+}
+
+// PruneModifierNormalizations removes the modifier normalizations that fall
+// outside CheckpointRetention
+func (k Keeper) PruneModifierNormalizations(ctx sdk.Context) {
+	retention := k.GetParams(ctx).CheckpointRetention
+	if retention == 0 || uint64(ctx.BlockHeight()) <= retention {
+		return
+	}
+
+	// Normalizations below the cutoff height are pruned
+	cutoff := ctx.BlockHeight() - int64(retention)
+
+	store := ctx.KVStore(k.storeKey)
+	iterator := store.Iterator(types.ModifierNormalizationPrefix, types.GetModifierNormalizationKey(cutoff))
+	var keys [][]byte
+	for ; iterator.Valid(); iterator.Next() {
+		keys = append(keys, iterator.Key())
+	}
+	iterator.Close()
+
+	for _, key := range keys {
+		store.Delete(key)
+	}
+}
@@ -7,7 +7,9 @@ import (
 	"google.golang.org/grpc/status"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/query"
 	"github.com/serv-chain/serv/x/noderewards/types"
+	proofofservicetypes "github.com/serv-chain/serv/x/proofofservice/types"
 )
 
 // Querier is used for implementing the Query gRPC service
@@ -44,11 +46,45 @@ func (q Querier) NodePerformance(c context.Context, req *types.QueryNodePerforma
 		return nil, status.Error(codes.InvalidArgument, "validator address cannot be empty")
 	}
 
+	operator, err := proofofservicetypes.ParseOperatorAddress(req.ValidatorAddr)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
 	ctx := sdk.UnwrapSDKContext(c)
-	performance := q.Keeper.GetNodePerformance(ctx, req.ValidatorAddr)
+	performance := q.Keeper.GetNodePerformance(ctx, operator.String())
+	smoothed := q.Keeper.GetSmoothedPerformance(ctx, operator.String())
 
 	return &types.QueryNodePerformanceResponse{
 		Performance: &performance,
+		Smoothed:    &smoothed,
+	}, nil
+}
+
+// ModifierNormalization implements the Query/ModifierNormalization gRPC method
+func (q Querier) ModifierNormalization(c context.Context, req *types.QueryModifierNormalizationRequest) (*types.QueryModifierNormalizationResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+
+	if req.Height < 0 {
+		return nil, status.Error(codes.InvalidArgument, "height cannot be negative")
+	}
+
+	ctx := sdk.UnwrapSDKContext(c)
+	if req.Height == 0 {
+		return &types.QueryModifierNormalizationResponse{
+			Normalization: q.Keeper.GetLatestModifierNormalization(ctx),
+		}, nil
+	}
+
+	normalization, found := q.Keeper.GetModifierNormalization(ctx, req.Height)
+	if !found {
+		return nil, status.Errorf(codes.NotFound, "no modifier normalization at height %d", req.Height)
+	}
+
+	return &types.QueryModifierNormalizationResponse{
+		Normalization: normalization,
 	}, nil
 }
 
@@ -62,10 +98,141 @@ func (q Querier) RewardModifierForValidator(c context.Context, req *types.QueryR
 		return nil, status.Error(codes.InvalidArgument, "validator address cannot be empty")
 	}
 
+	operator, err := proofofservicetypes.ParseOperatorAddress(req.ValidatorAddr)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
 	ctx := sdk.UnwrapSDKContext(c)
-	modifier := q.Keeper.CalculateRewardModifier(ctx, req.ValidatorAddr)
+	modifier := q.Keeper.CalculateRewardModifier(ctx, operator.String())
 
 	return &types.QueryRewardModifierForValidatorResponse{
 		Modifier: modifier,
 	}, nil
 }
+
+// ValidatorTier implements the Query/ValidatorTier gRPC method
+func (q Querier) ValidatorTier(c context.Context, req *types.QueryValidatorTierRequest) (*types.QueryValidatorTierResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+
+	if req.ValidatorAddr == "" {
+		return nil, status.Error(codes.InvalidArgument, "validator address cannot be empty")
+	}
+
+	operator, err := proofofservicetypes.ParseOperatorAddress(req.ValidatorAddr)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	ctx := sdk.UnwrapSDKContext(c)
+	tier, found := q.Keeper.GetValidatorTier(ctx, operator.String())
+	if !found {
+		return nil, status.Errorf(codes.NotFound, "validator %s has not been ranked in a performance tier", operator)
+	}
+
+	return &types.QueryValidatorTierResponse{
+		Tier: tier,
+	}, nil
+}
+
+// TierHistory implements the Query/TierHistory gRPC method
+func (q Querier) TierHistory(c context.Context, req *types.QueryTierHistoryRequest) (*types.QueryTierHistoryResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+
+	if req.ValidatorAddr == "" {
+		return nil, status.Error(codes.InvalidArgument, "validator address cannot be empty")
+	}
+
+	operator, err := proofofservicetypes.ParseOperatorAddress(req.ValidatorAddr)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	ctx := sdk.UnwrapSDKContext(c)
+	store := q.Keeper.tierHistoryStore(ctx, operator.String())
+
+	var history []types.ValidatorTier
+	pageRes, err := query.Paginate(store, req.Pagination, func(key []byte, value []byte) error {
+		var tier types.ValidatorTier
+		if err := q.cdc.Unmarshal(value, &tier); err != nil {
+			return err
+		}
+		history = append(history, tier)
+		return nil
+	})
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &types.QueryTierHistoryResponse{
+		History:    history,
+		Pagination: pageRes,
+	}, nil
+}
+
+// PerformanceHistory implements the Query/PerformanceHistory gRPC method
+func (q Querier) PerformanceHistory(c context.Context, req *types.QueryPerformanceHistoryRequest) (*types.QueryPerformanceHistoryResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+
+	if err := types.ValidateHeightRange(req.FromHeight, req.ToHeight); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	validatorAddr := ""
+	if req.ValidatorAddr != "" {
+		operator, err := proofofservicetypes.ParseOperatorAddress(req.ValidatorAddr)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		validatorAddr = operator.String()
+	}
+
+	ctx := sdk.UnwrapSDKContext(c)
+
+	// The checkpoints of a validator are ordered by height, so only its range is iterated
+	if validatorAddr != "" {
+		if req.Pagination != nil && req.Pagination.Offset > 0 && len(req.Pagination.Key) > 0 {
+			return nil, status.Error(codes.InvalidArgument, "either offset or key is expected, got both")
+		}
+
+		checkpoints, pageRes, err := q.Keeper.paginateCheckpointRange(ctx, validatorAddr, req.FromHeight, req.ToHeight, req.Pagination)
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+
+		return &types.QueryPerformanceHistoryResponse{
+			Checkpoints: checkpoints,
+			Pagination:  pageRes,
+		}, nil
+	}
+
+	store := q.Keeper.performanceCheckpointStore(ctx, validatorAddr)
+	var checkpoints []types.PerformanceCheckpoint
+	pageRes, err := query.FilteredPaginate(store, req.Pagination, func(key []byte, value []byte, accumulate bool) (bool, error) {
+		var checkpoint types.PerformanceCheckpoint
+		if err := q.cdc.Unmarshal(value, &checkpoint); err != nil {
+			return false, err
+		}
+		if !checkpoint.InHeightRange(req.FromHeight, req.ToHeight) {
+			return false, nil
+		}
+		if accumulate {
+			checkpoints = append(checkpoints, checkpoint)
+		}
+		return true, nil
+	})
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &types.QueryPerformanceHistoryResponse{
+		Checkpoints: checkpoints,
+		Pagination:  pageRes,
+	}, nil
+}
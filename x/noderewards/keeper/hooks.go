@@ -0,0 +1,176 @@
+package keeper
+
+import (
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+	epochstypes "github.com/serv-chain/serv/x/epochs/types"
+	proofofservicetypes "github.com/serv-chain/serv/x/proofofservice/types"
+)
+
+// updateValidatorPerformance updates the performance metrics of a single
+// bonded validator as soon as an event changes them, between periodic updates
+func (k Keeper) updateValidatorPerformance(ctx sdk.Context, validator stakingtypes.Validator) {
+	if !validator.IsBonded() {
+		return
+	}
+
+	performance, err := k.refreshNodePerformance(ctx, validator)
+	if err != nil {
+		k.Logger(ctx).Error("failed to update node performance", "validator", validator.GetOperator().String(), "err", err)
+		return
+	}
+	k.emitNodePerformanceUpdated(ctx, performance)
+}
+
+var _ proofofservicetypes.ProofOfServiceHooks = ProofOfServiceHooks{}
+
+// ProofOfServiceHooks wrapper struct for the noderewards keeper
+type ProofOfServiceHooks struct {
+	k Keeper
+}
+
+// ProofOfServiceHooks returns the proofofservice hooks through which
+// noderewards updates the performance of validators whose service score changed
+func (k Keeper) ProofOfServiceHooks() ProofOfServiceHooks {
+	return ProofOfServiceHooks{k}
+}
+
+// updateProviderPerformance updates the performance of the validator operated by provider, if any
+func (h ProofOfServiceHooks) updateProviderPerformance(ctx sdk.Context, provider string) {
+	operator, err := proofofservicetypes.ParseOperatorAddress(provider)
+	if err != nil {
+		h.k.Logger(ctx).Error("invalid service provider address", "provider", provider, "err", err)
+		return
+	}
+
+	validator, found := h.k.stakingKeeper.GetValidator(ctx, operator)
+	if !found {
+		return
+	}
+
+	h.k.updateValidatorPerformance(ctx, validator)
+}
+
+// AfterServiceProviderRegistered implements ProofOfServiceHooks
+func (h ProofOfServiceHooks) AfterServiceProviderRegistered(ctx sdk.Context, provider string) {}
+
+// AfterProofSubmitted implements ProofOfServiceHooks
+func (h ProofOfServiceHooks) AfterProofSubmitted(ctx sdk.Context, provider string, proofID string) {}
+
+// AfterProofVerified updates the performance of the provider with its raised service score
+func (h ProofOfServiceHooks) AfterProofVerified(ctx sdk.Context, provider string, proofID string, score sdk.Int) {
+	h.updateProviderPerformance(ctx, provider)
+}
+
+// AfterProofRevoked updates the performance of the provider with its lowered service score
+func (h ProofOfServiceHooks) AfterProofRevoked(ctx sdk.Context, provider string, proofID string, revokedScore, providerScore sdk.Int, verifiedAt time.Time) {
+	h.updateProviderPerformance(ctx, provider)
+}
+
+var _ stakingtypes.StakingHooks = StakingHooks{}
+
+// StakingHooks wrapper struct for the noderewards keeper
+type StakingHooks struct {
+	k Keeper
+}
+
+// StakingHooks returns the staking hooks through which noderewards updates
+// the performance of validators as they join the active set or are slashed
+func (k Keeper) StakingHooks() StakingHooks {
+	return StakingHooks{k}
+}
+
+// updatePerformance updates the performance of the validator with operator address valAddr
+func (h StakingHooks) updatePerformance(ctx sdk.Context, valAddr sdk.ValAddress) {
+	validator, found := h.k.stakingKeeper.GetValidator(ctx, valAddr)
+	if !found {
+		return
+	}
+
+	h.k.updateValidatorPerformance(ctx, validator)
+}
+
+// AfterValidatorCreated implements StakingHooks
+func (h StakingHooks) AfterValidatorCreated(ctx sdk.Context, valAddr sdk.ValAddress) error {
+	return nil
+}
+
+// BeforeValidatorModified implements StakingHooks
+func (h StakingHooks) BeforeValidatorModified(ctx sdk.Context, valAddr sdk.ValAddress) error {
+	return nil
+}
+
+// AfterValidatorRemoved implements StakingHooks
+func (h StakingHooks) AfterValidatorRemoved(ctx sdk.Context, consAddr sdk.ConsAddress, valAddr sdk.ValAddress) error {
+	return nil
+}
+
+// AfterValidatorBonded starts the validator joining the active set from its current performance
+func (h StakingHooks) AfterValidatorBonded(ctx sdk.Context, consAddr sdk.ConsAddress, valAddr sdk.ValAddress) error {
+	h.updatePerformance(ctx, valAddr)
+	return nil
+}
+
+// AfterValidatorBeginUnbonding implements StakingHooks
+func (h StakingHooks) AfterValidatorBeginUnbonding(ctx sdk.Context, consAddr sdk.ConsAddress, valAddr sdk.ValAddress) error {
+	return nil
+}
+
+// BeforeDelegationCreated implements StakingHooks
+func (h StakingHooks) BeforeDelegationCreated(ctx sdk.Context, delAddr sdk.AccAddress, valAddr sdk.ValAddress) error {
+	return nil
+}
+
+// BeforeDelegationSharesModified implements StakingHooks
+func (h StakingHooks) BeforeDelegationSharesModified(ctx sdk.Context, delAddr sdk.AccAddress, valAddr sdk.ValAddress) error {
+	return nil
+}
+
+// BeforeDelegationRemoved implements StakingHooks
+func (h StakingHooks) BeforeDelegationRemoved(ctx sdk.Context, delAddr sdk.AccAddress, valAddr sdk.ValAddress) error {
+	return nil
+}
+
+// AfterDelegationModified implements StakingHooks
+func (h StakingHooks) AfterDelegationModified(ctx sdk.Context, delAddr sdk.AccAddress, valAddr sdk.ValAddress) error {
+	return nil
+}
+
+// BeforeValidatorSlashed updates the performance of the slashed validator,
+// whose uptime dropped if it is slashed for downtime
+func (h StakingHooks) BeforeValidatorSlashed(ctx sdk.Context, valAddr sdk.ValAddress, fraction sdk.Dec) error {
+	h.updatePerformance(ctx, valAddr)
+	return nil
+}
+
+// AfterUnbondingInitiated implements StakingHooks
+func (h StakingHooks) AfterUnbondingInitiated(ctx sdk.Context, id uint64) error {
+	return nil
+}
+
+var _ epochstypes.EpochHooks = EpochHooks{}
+
+// EpochHooks wrapper struct for the noderewards keeper
+type EpochHooks struct {
+	k Keeper
+}
+
+// EpochHooks returns the epoch hooks through which noderewards ranks
+// validators in performance tiers
+func (k Keeper) EpochHooks() EpochHooks {
+	return EpochHooks{k}
+}
+
+// AfterEpochEnd ranks the bonded validators in performance tiers if the ended epoch is the tier epoch
+func (h EpochHooks) AfterEpochEnd(ctx sdk.Context, epochIdentifier string, epochNumber uint64) {
+	if epochIdentifier != h.k.GetParams(ctx).TierEpochIdentifier {
+		return
+	}
+
+	h.k.AssignPerformanceTiers(ctx, epochNumber)
+}
+
+// BeforeEpochStart implements EpochHooks
+func (h EpochHooks) BeforeEpochStart(ctx sdk.Context, epochIdentifier string, epochNumber uint64) {}
@@ -8,7 +8,9 @@ import (
 	"github.com/cosmos/cosmos-sdk/codec"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	paramtypes "github.com/cosmos/cosmos-sdk/x/params/types"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
 	"github.com/serv-chain/serv/x/noderewards/types"
+	proofofservicetypes "github.com/serv-chain/serv/x/proofofservice/types"
 )
 
 // Keeper of the noderewards store
@@ -17,10 +19,16 @@ type Keeper struct {
 	cdc        codec.BinaryCodec
 	paramstore paramtypes.Subspace
 
-	stakingKeeper    types.StakingKeeper
-	distrKeeper      types.DistrKeeper
-	posKeeper        types.ProofOfServiceKeeper
-	hooks            types.NodeRewardsHooks
+	bankKeeper     types.BankKeeper
+	stakingKeeper  types.StakingKeeper
+	slashingKeeper types.SlashingKeeper
+	distrKeeper    types.DistrKeeper
+	posKeeper      types.ProofOfServiceKeeper
+	hooks          types.NodeRewardsHooks
+
+	// the address capable of executing a MsgUpdateParams message,
+	// typically the x/gov module account
+	authority string
 }
 
 // NewKeeper creates a new noderewards Keeper instance
@@ -28,25 +36,40 @@ func NewKeeper(
 	cdc codec.BinaryCodec,
 	storeKey sdk.StoreKey,
 	ps paramtypes.Subspace,
+	bankKeeper types.BankKeeper,
 	stakingKeeper types.StakingKeeper,
+	slashingKeeper types.SlashingKeeper,
 	distrKeeper types.DistrKeeper,
 	posKeeper types.ProofOfServiceKeeper,
+	authority string,
 ) *Keeper {
+	if _, err := sdk.AccAddressFromBech32(authority); err != nil {
+		panic(fmt.Errorf("invalid noderewards authority address: %w", err))
+	}
+
 	// set KeyTable if it has not already been set
 	if !ps.HasKeyTable() {
 		ps = ps.WithKeyTable(types.ParamKeyTable())
 	}
 
 	return &Keeper{
-		storeKey:      storeKey,
-		cdc:           cdc,
-		paramstore:    ps,
-		stakingKeeper: stakingKeeper,
-		distrKeeper:   distrKeeper,
-		posKeeper:     posKeeper,
+		storeKey:       storeKey,
+		cdc:            cdc,
+		paramstore:     ps,
+		bankKeeper:     bankKeeper,
+		stakingKeeper:  stakingKeeper,
+		slashingKeeper: slashingKeeper,
+		distrKeeper:    distrKeeper,
+		posKeeper:      posKeeper,
+		authority:      authority,
 	}
 }
 
+// GetAuthority returns the module's authority
+func (k Keeper) GetAuthority() string {
+	return k.authority
+}
+
 // Logger returns a module-specific logger.
 func (k Keeper) Logger(ctx sdk.Context) log.Logger {
 	return ctx.Logger().With("module", fmt.Sprintf("x/%s", types.ModuleName))
@@ -61,6 +84,26 @@ func (k *Keeper) SetHooks(h types.NodeRewardsHooks) *Keeper {
 	return k
 }
 
+// GetParams returns the current module parameters
+func (k Keeper) GetParams(ctx sdk.Context) types.Params {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(types.ParamsKey)
+	if bz == nil {
+		return types.DefaultParams()
+	}
+
+	var params types.Params
+	k.cdc.MustUnmarshal(bz, &params)
+	return params
+}
+
+// SetParams sets the current module parameters
+func (k Keeper) SetParams(ctx sdk.Context, params types.Params) {
+	store := ctx.KVStore(k.storeKey)
+	bz := k.cdc.MustMarshal(&params)
+	store.Set(types.ParamsKey, bz)
+}
+
 // GetRewardModifier returns the current reward modifier parameters
 func (k Keeper) GetRewardModifier(ctx sdk.Context) types.RewardModifier {
 	store := ctx.KVStore(k.storeKey)
@@ -81,6 +124,60 @@ func (k Keeper) SetRewardModifier(ctx sdk.Context, modifier types.RewardModifier
 	store.Set(types.RewardModifierKey, bz)
 }
 
+// GetModifierNormalization returns the normalization of the reward modifiers
+// at a height, if the modifiers were normalized in that block and the record
+// has not been pruned
+func (k Keeper) GetModifierNormalization(ctx sdk.Context, height int64) (types.ModifierNormalization, bool) {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(types.GetModifierNormalizationKey(height))
+	if bz == nil {
+		return types.ModifierNormalization{}, false
+	}
+
+	var normalization types.ModifierNormalization
+	k.cdc.MustUnmarshal(bz, &normalization)
+	return normalization, true
+}
+
+// GetLatestModifierNormalization returns the latest recorded normalization of
+// the reward modifiers, or a factor of one if none is recorded
+func (k Keeper) GetLatestModifierNormalization(ctx sdk.Context) types.ModifierNormalization {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStoreReversePrefixIterator(store, types.ModifierNormalizationPrefix)
+	defer iterator.Close()
+
+	if !iterator.Valid() {
+		return types.DefaultModifierNormalization()
+	}
+
+	var normalization types.ModifierNormalization
+	k.cdc.MustUnmarshal(iterator.Value(), &normalization)
+	return normalization
+}
+
+// SetModifierNormalization records the normalization of the reward modifiers at its height
+func (k Keeper) SetModifierNormalization(ctx sdk.Context, normalization types.ModifierNormalization) {
+	store := ctx.KVStore(k.storeKey)
+	bz := k.cdc.MustMarshal(&normalization)
+	store.Set(types.GetModifierNormalizationKey(normalization.Height), bz)
+}
+
+// IterateModifierNormalizations iterates over the recorded normalizations of
+// the reward modifiers in height order
+func (k Keeper) IterateModifierNormalizations(ctx sdk.Context, cb func(normalization types.ModifierNormalization) (stop bool)) {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, types.ModifierNormalizationPrefix)
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		var normalization types.ModifierNormalization
+		k.cdc.MustUnmarshal(iterator.Value(), &normalization)
+		if cb(normalization) {
+			break
+		}
+	}
+}
+
 // GetNodePerformance returns the performance metrics for a validator node
 func (k Keeper) GetNodePerformance(ctx sdk.Context, validatorAddr string) types.NodePerformance {
 	store := ctx.KVStore(k.storeKey)
@@ -104,25 +201,99 @@ func (k Keeper) SetNodePerformance(ctx sdk.Context, performance types.NodePerfor
 	store.Set(key, bz)
 }
 
-// UpdateNodePerformance updates the performance metrics for a validator node
-func (k Keeper) UpdateNodePerformance(ctx sdk.Context, validatorAddr string) {
+// IterateNodePerformances iterates over the performance metrics of all validator nodes
+func (k Keeper) IterateNodePerformances(ctx sdk.Context, cb func(performance types.NodePerformance) (stop bool)) {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, types.NodePerformancePrefix)
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		var performance types.NodePerformance
+		k.cdc.MustUnmarshal(iterator.Value(), &performance)
+		if cb(performance) {
+			break
+		}
+	}
+}
+
+// GetValidator returns the validator with the given account or operator address
+func (k Keeper) GetValidator(ctx sdk.Context, addr string) (stakingtypes.Validator, error) {
+	operator, err := proofofservicetypes.ParseOperatorAddress(addr)
+	if err != nil {
+		return stakingtypes.Validator{}, err
+	}
+
+	validator, found := k.stakingKeeper.GetValidator(ctx, operator)
+	if !found {
+		return stakingtypes.Validator{}, fmt.Errorf("address is not a validator: %s", addr)
+	}
+
+	return validator, nil
+}
+
+// UpdateNodePerformance updates the performance metrics for the validator
+// node with the given account or operator address
+func (k Keeper) UpdateNodePerformance(ctx sdk.Context, validatorAddr string) error {
+	validator, err := k.GetValidator(ctx, validatorAddr)
+	if err != nil {
+		return err
+	}
+
+	performance, err := k.refreshNodePerformance(ctx, validator)
+	if err != nil {
+		return err
+	}
+
+	k.emitNodePerformanceUpdated(ctx, performance)
+	return nil
+}
+
+// UpdateBondedNodePerformances updates the performance metrics of all bonded
+// validators. Unbonded validators earn no rewards, so their metrics are left
+// as they are, and a single event covers the whole batch.
+func (k Keeper) UpdateBondedNodePerformances(ctx sdk.Context) {
+	validators := k.stakingKeeper.GetBondedValidatorsByPower(ctx)
+	for _, validator := range validators {
+		if _, err := k.refreshNodePerformance(ctx, validator); err != nil {
+			k.Logger(ctx).Error("failed to update node performance", "validator", validator.GetOperator().String(), "err", err)
+		}
+	}
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			types.EventTypeNodePerformancesUpdated,
+			sdk.NewAttribute(types.AttributeKeyValidators, fmt.Sprintf("%d", len(validators))),
+			sdk.NewAttribute(types.AttributeKeyHeight, fmt.Sprintf("%d", ctx.BlockHeight())),
+		),
+	)
+}
+
+// IsNodePerformanceUpdateHeight returns whether the performance metrics of
+// the bonded validators are due for their periodic update at the current height
+func (k Keeper) IsNodePerformanceUpdateHeight(ctx sdk.Context) bool {
+	interval := k.GetParams(ctx).UpdateInterval
+	return interval > 0 && uint64(ctx.BlockHeight())%interval == 0
+}
+
+// refreshNodePerformance reads the current performance metrics of a validator
+// node, then stores, smooths and checkpoints them. Performance is stored by operator
+// address, while the validator provides service from its operator account.
+func (k Keeper) refreshNodePerformance(ctx sdk.Context, validator stakingtypes.Validator) (types.NodePerformance, error) {
+	identity, err := proofofservicetypes.NewValidatorIdentity(validator)
+	if err != nil {
+		return types.NodePerformance{}, err
+	}
+	validatorAddr := identity.Operator.String()
+
 	// Get current performance
 	performance := k.GetNodePerformance(ctx, validatorAddr)
 	
 	// Update service score from proof of service module
-	performance.ServiceScore = k.posKeeper.GetServiceScore(ctx, validatorAddr)
-	
-	// Update uptime from slashing module (via staking keeper)
-	validator, found := k.stakingKeeper.GetValidator(ctx, sdk.MustAccAddressFromBech32(validatorAddr))
-	if found {
-		// Calculate uptime based on signing info
-		signInfo, found := k.stakingKeeper.GetValidatorSigningInfo(ctx, validator.GetConsAddr())
-		if found {
-			missedBlocks := signInfo.MissedBlocksCounter
-			windowSize := k.stakingKeeper.SignedBlocksWindow(ctx)
-			uptime := sdk.OneDec().Sub(sdk.NewDec(missedBlocks).QuoInt64(windowSize))
-			performance.UptimePercent = uptime
-		}
+	performance.ServiceScore = k.posKeeper.GetServiceScore(ctx, identity.Account.String())
+	
+	// Update uptime from the missed blocks the slashing module tracks
+	if uptime, found := k.validatorUptime(ctx, identity.ConsAddr); found {
+		performance.UptimePercent = uptime
 	}
 	
 	// Update response time (this would typically come from monitoring data)
@@ -134,28 +305,36 @@ func (k Keeper) UpdateNodePerformance(ctx sdk.Context, validatorAddr string) {
 	
 	// Save updated performance
 	k.SetNodePerformance(ctx, performance)
+	smoothed := k.smoothNodePerformance(ctx, performance)
+	k.SetPerformanceCheckpoint(ctx, types.NewPerformanceCheckpoint(performance, smoothed))
 	
-	// Emit event
+	// Call hooks if set
+	if k.hooks != nil {
+		k.hooks.AfterNodePerformanceUpdated(ctx, validatorAddr)
+	}
+	
+	return performance, nil
+}
+
+// emitNodePerformanceUpdated emits the updated performance metrics of a single validator node
+func (k Keeper) emitNodePerformanceUpdated(ctx sdk.Context, performance types.NodePerformance) {
 	ctx.EventManager().EmitEvent(
 		sdk.NewEvent(
 			types.EventTypeNodePerformanceUpdated,
-			sdk.NewAttribute(types.AttributeKeyValidator, validatorAddr),
+			sdk.NewAttribute(types.AttributeKeyValidator, performance.ValidatorAddr),
 			sdk.NewAttribute(types.AttributeKeyServiceScore, performance.ServiceScore.String()),
 			sdk.NewAttribute(types.AttributeKeyUptime, performance.UptimePercent.String()),
 			sdk.NewAttribute(types.AttributeKeyResponseTime, performance.ResponseTime.String()),
 		),
 	)
-	
-	// Call hooks if set
-	if k.hooks != nil {
-		k.hooks.AfterNodePerformanceUpdated(ctx, validatorAddr)
-	}
 }
 
-// CalculateRewardModifier calculates the reward modifier for a validator
-func (k Keeper) CalculateRewardModifier(ctx sdk.Context, validatorAddr string) sdk.Dec {
-	performance := k.GetNodePerformance(ctx, validatorAddr)
+// PerformanceScore returns the weighted score of the smoothed performance of
+// a validator, from 0 for the worst to 1 for the best performance
+func (k Keeper) PerformanceScore(ctx sdk.Context, validatorAddr string) sdk.Dec {
+	performance := k.GetSmoothedPerformance(ctx, validatorAddr)
 	modifier := k.GetRewardModifier(ctx)
+	params := k.GetParams(ctx)
 	
 	// Get total service score for normalization
 	totalServiceScore := k.posKeeper.GetTotalServiceScore(ctx)
@@ -164,18 +343,34 @@ func (k Keeper) CalculateRewardModifier(ctx sdk.Context, validatorAddr string) s
 	}
 	
 	// Normalize service score (0-1)
-	normalizedServiceScore := sdk.NewDecFromInt(performance.ServiceScore).QuoInt(totalServiceScore)
+	normalizedServiceScore := performance.ServiceScore.QuoInt(totalServiceScore)
 	
-	// Response time score (lower is better, 1000ms is considered worst case)
-	responseTimeScore := sdk.OneDec().Sub(sdk.NewDecFromInt(performance.ResponseTime).QuoInt64(1000))
+	// Response time score (lower is better, MaxResponseTime is considered worst case)
+	responseTimeScore := sdk.OneDec().Sub(performance.ResponseTime.QuoInt(params.MaxResponseTime))
 	if responseTimeScore.IsNegative() {
 		responseTimeScore = sdk.ZeroDec()
 	}
 	
 	// Calculate weighted score
-	weightedScore := normalizedServiceScore.Mul(modifier.ServiceScoreWeight).
+	return normalizedServiceScore.Mul(modifier.ServiceScoreWeight).
 		Add(performance.UptimePercent.Mul(modifier.UptimeWeight)).
 		Add(responseTimeScore.Mul(modifier.ResponseTimeWeight))
+}
+
+// CalculateRewardModifier calculates the reward modifier for a validator from
+// its smoothed performance, so that a single bad window does not swing it.
+// Jailed and tombstoned validators and validators on probation earn nothing.
+func (k Keeper) CalculateRewardModifier(ctx sdk.Context, validatorAddr string) sdk.Dec {
+	if validator, err := k.GetValidator(ctx, validatorAddr); err == nil && k.IsValidatorPenalized(ctx, validator) {
+		return sdk.ZeroDec()
+	}
+
+	if k.IsOnProbation(ctx, validatorAddr) {
+		return sdk.ZeroDec()
+	}
+
+	modifier := k.GetRewardModifier(ctx)
+	weightedScore := k.PerformanceScore(ctx, validatorAddr)
 	
 	// Scale to min-max range
 	// 0 score = min modifier, 1 score = max modifier
@@ -195,9 +390,13 @@ func (k Keeper) CalculateRewardModifier(ctx sdk.Context, validatorAddr string) s
 }
 
 // ModifyValidatorReward modifies the reward for a validator based on performance
-func (k Keeper) ModifyValidatorReward(ctx sdk.Context, validatorAddr string, baseReward sdk.Dec) sdk.Dec {
-	modifier := k.CalculateRewardModifier(ctx, validatorAddr)
-	modifiedReward := baseReward.Mul(modifier)
+func (k Keeper) ModifyValidatorReward(ctx sdk.Context, validatorAddr string, baseReward sdk.DecCoins) sdk.DecCoins {
+	return k.applyRewardModifier(ctx, validatorAddr, baseReward, k.CalculateRewardModifier(ctx, validatorAddr))
+}
+
+// applyRewardModifier scales the reward of a validator by modifier
+func (k Keeper) applyRewardModifier(ctx sdk.Context, validatorAddr string, baseReward sdk.DecCoins, modifier sdk.Dec) sdk.DecCoins {
+	modifiedReward := baseReward.MulDecTruncate(modifier)
 	
 	// Emit event
 	ctx.EventManager().EmitEvent(
@@ -213,10 +412,3 @@ func (k Keeper) ModifyValidatorReward(ctx sdk.Context, validatorAddr string, bas
 	return modifiedReward
 }
 
-// UpdateAllNodePerformances updates performance metrics for all validators
-func (k Keeper) UpdateAllNodePerformances(ctx sdk.Context) {
-	validators := k.stakingKeeper.GetAllValidators(ctx)
-	for _, validator := range validators {
-		k.UpdateNodePerformance(ctx, validator.GetOperator().String())
-	}
-}
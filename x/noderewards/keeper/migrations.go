@@ -0,0 +1,36 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/serv-chain/serv/x/noderewards/types"
+)
+
+// Migrator is a struct for handling in-place store migrations.
+type Migrator struct {
+	keeper Keeper
+}
+
+// NewMigrator returns a new Migrator.
+func NewMigrator(keeper Keeper) Migrator {
+	return Migrator{keeper: keeper}
+}
+
+// Migrate1to2 migrates the store from consensus version 1 to 2. Modifier
+// normalizations used to overwrite a single record every block, they are now
+// kept by height. The last recorded normalization moves to its height.
+func (m Migrator) Migrate1to2(ctx sdk.Context) error {
+	store := ctx.KVStore(m.keeper.storeKey)
+	bz := store.Get(types.ModifierNormalizationKey)
+	if bz == nil {
+		return nil
+	}
+
+	var normalization types.ModifierNormalization
+	if err := m.keeper.cdc.Unmarshal(bz, &normalization); err != nil {
+		return err
+	}
+
+	store.Delete(types.ModifierNormalizationKey)
+	m.keeper.SetModifierNormalization(ctx, normalization)
+	return nil
+}
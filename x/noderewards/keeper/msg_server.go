@@ -0,0 +1,80 @@
+package keeper
+
+import (
+	"context"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
+	"github.com/serv-chain/serv/x/noderewards/types"
+)
+
+type msgServer struct {
+	Keeper
+}
+
+// NewMsgServer returns an implementation of the MsgServer interface
+// for the noderewards module.
+func NewMsgServer(keeper Keeper) types.MsgServer {
+	return &msgServer{Keeper: keeper}
+}
+
+// UpdateParams implements the MsgServer.UpdateParams method.
+func (m msgServer) UpdateParams(goCtx context.Context, msg *types.MsgUpdateParams) (*types.MsgUpdateParamsResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	if m.authority != msg.Authority {
+		return nil, sdkerrors.Wrapf(govtypes.ErrInvalidSigner, "invalid authority; expected %s, got %s", m.authority, msg.Authority)
+	}
+
+	// Validate parameters
+	if err := msg.Params.Validate(); err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, err.Error())
+	}
+
+	// Update parameters
+	m.Keeper.SetParams(ctx, msg.Params)
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.ModuleName),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Authority),
+		),
+	})
+
+	return &types.MsgUpdateParamsResponse{}, nil
+}
+
+// UpdateRewardModifier implements the MsgServer.UpdateRewardModifier method.
+func (m msgServer) UpdateRewardModifier(goCtx context.Context, msg *types.MsgUpdateRewardModifier) (*types.MsgUpdateRewardModifierResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	if m.authority != msg.Authority {
+		return nil, sdkerrors.Wrapf(govtypes.ErrInvalidSigner, "invalid authority; expected %s, got %s", m.authority, msg.Authority)
+	}
+
+	if err := msg.RewardModifier.Validate(); err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, err.Error())
+	}
+
+	m.Keeper.SetRewardModifier(ctx, msg.RewardModifier)
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			types.EventTypeRewardModifierUpdated,
+			sdk.NewAttribute(types.AttributeKeyServiceScoreWeight, msg.RewardModifier.ServiceScoreWeight.String()),
+			sdk.NewAttribute(types.AttributeKeyUptimeWeight, msg.RewardModifier.UptimeWeight.String()),
+			sdk.NewAttribute(types.AttributeKeyResponseTimeWeight, msg.RewardModifier.ResponseTimeWeight.String()),
+			sdk.NewAttribute(types.AttributeKeyMinModifier, msg.RewardModifier.MinModifier.String()),
+			sdk.NewAttribute(types.AttributeKeyMaxModifier, msg.RewardModifier.MaxModifier.String()),
+		),
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.ModuleName),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Authority),
+		),
+	})
+
+	return &types.MsgUpdateRewardModifierResponse{}, nil
+}
@@ -0,0 +1,53 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/serv-chain/serv/x/noderewards/types"
+)
+
+// GetSmoothedPerformance returns the smoothed performance metrics for a
+// validator node. Before the first sample they equal its latest snapshot.
+func (k Keeper) GetSmoothedPerformance(ctx sdk.Context, validatorAddr string) types.SmoothedPerformance {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(types.GetSmoothedPerformanceKey(validatorAddr))
+	if bz == nil {
+		return types.NewSmoothedPerformance(k.GetNodePerformance(ctx, validatorAddr))
+	}
+
+	var smoothed types.SmoothedPerformance
+	k.cdc.MustUnmarshal(bz, &smoothed)
+	return smoothed
+}
+
+// SetSmoothedPerformance sets the smoothed performance metrics for a validator node
+func (k Keeper) SetSmoothedPerformance(ctx sdk.Context, smoothed types.SmoothedPerformance) {
+	store := ctx.KVStore(k.storeKey)
+	bz := k.cdc.MustMarshal(&smoothed)
+	store.Set(types.GetSmoothedPerformanceKey(smoothed.ValidatorAddr), bz)
+}
+
+// IterateSmoothedPerformances iterates over the smoothed performance metrics of all validator nodes
+func (k Keeper) IterateSmoothedPerformances(ctx sdk.Context, cb func(smoothed types.SmoothedPerformance) (stop bool)) {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, types.SmoothedPerformancePrefix)
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		var smoothed types.SmoothedPerformance
+		k.cdc.MustUnmarshal(iterator.Value(), &smoothed)
+		if cb(smoothed) {
+			break
+		}
+	}
+}
+
+// smoothNodePerformance adds a performance snapshot to the moving averages of
+// its node and returns them. The snapshot is weighted by the blocks elapsed
+// since the previous one, see Params.SampleSmoothingFactor.
+func (k Keeper) smoothNodePerformance(ctx sdk.Context, performance types.NodePerformance) types.SmoothedPerformance {
+	smoothed := k.GetSmoothedPerformance(ctx, performance.ValidatorAddr)
+	alpha := k.GetParams(ctx).SampleSmoothingFactor(performance.LastUpdateHeight - smoothed.LastUpdateHeight)
+	smoothed = smoothed.Add(performance, alpha)
+	k.SetSmoothedPerformance(ctx, smoothed)
+	return smoothed
+}
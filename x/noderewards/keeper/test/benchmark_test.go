@@ -0,0 +1,83 @@
+package test
+
+import (
+	"fmt"
+	"testing"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/serv-chain/serv/x/noderewards"
+	"github.com/serv-chain/serv/x/noderewards/keeper"
+	"github.com/serv-chain/serv/x/noderewards/types"
+)
+
+// benchmarkBlocks is the number of blocks every benchmark iteration covers
+const benchmarkBlocks = 100
+
+// setupBenchmark returns a keeper with the given number of bonded validators
+// and half as many unbonded ones, updating performances every 10 blocks
+func setupBenchmark(b *testing.B, bonded int) (*keeper.Keeper, sdk.Context, *MockStakingKeeper) {
+	k, ctx, _, staking, slashing, _, pos := Setup(b)
+
+	params := types.DefaultParams()
+	params.UpdateInterval = 10
+	k.SetParams(ctx, params)
+
+	for i := 0; i < bonded+bonded/2; i++ {
+		operator := sdk.ValAddress(fmt.Sprintf("validator_%010d", i))
+		var consAddr sdk.ConsAddress
+		if i < bonded {
+			consAddr = staking.AddValidator(operator)
+		} else {
+			consAddr = staking.AddUnbondedValidator(operator)
+		}
+		slashing.SetMissedBlocks(consAddr, int64(i%10))
+		pos.ServiceScores[sdk.AccAddress(operator).String()] = sdk.NewInt(int64(i))
+	}
+
+	// Only measure the gas of the updates
+	return k, ctx.WithGasMeter(sdk.NewInfiniteGasMeter()), staking
+}
+
+// reportGas reports the gas consumed per block
+func reportGas(b *testing.B, ctx sdk.Context) {
+	b.ReportMetric(float64(ctx.GasMeter().GasConsumed())/float64(b.N*benchmarkBlocks), "gas/block")
+}
+
+// BenchmarkNodePerformanceUpdates compares updating every validator every
+// block, as BeginBlocker used to, with updating the bonded validators every
+// UpdateInterval blocks
+func BenchmarkNodePerformanceUpdates(b *testing.B) {
+	for _, bonded := range []int{150, 500} {
+		b.Run(fmt.Sprintf("every_block_all_validators/%d", bonded), func(b *testing.B) {
+			k, ctx, staking := setupBenchmark(b, bonded)
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				for height := int64(1); height <= benchmarkBlocks; height++ {
+					blockCtx := ctx.WithBlockHeight(height).WithEventManager(sdk.NewEventManager())
+					for _, validator := range staking.Validators {
+						if err := k.UpdateNodePerformance(blockCtx, validator.GetOperator().String()); err != nil {
+							b.Fatal(err)
+						}
+					}
+				}
+			}
+			reportGas(b, ctx)
+		})
+
+		b.Run(fmt.Sprintf("interval_bonded_validators/%d", bonded), func(b *testing.B) {
+			k, ctx, _ := setupBenchmark(b, bonded)
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				for height := int64(1); height <= benchmarkBlocks; height++ {
+					blockCtx := ctx.WithBlockHeight(height).WithEventManager(sdk.NewEventManager())
+					noderewards.BeginBlocker(blockCtx, abci.RequestBeginBlock{}, *k)
+				}
+			}
+			reportGas(b, ctx)
+		})
+	}
+}
@@ -0,0 +1,137 @@
+package test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/libs/log"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	tmdb "github.com/tendermint/tm-db"
+
+	"github.com/cosmos/cosmos-sdk/store"
+	storetypes "github.com/cosmos/cosmos-sdk/store/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	distrkeeper "github.com/cosmos/cosmos-sdk/x/distribution/keeper"
+	distrtypes "github.com/cosmos/cosmos-sdk/x/distribution/types"
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
+	"github.com/serv-chain/serv/x/noderewards/keeper"
+	"github.com/serv-chain/serv/x/noderewards/types"
+)
+
+var _ types.DistrKeeper = distrkeeper.Keeper{}
+
+// SetupDistribution initializes a test keeper that allocates fees through a
+// real x/distribution keeper, which keeps the rewards, commission and
+// community pool accounts that payouts are withdrawn from
+func SetupDistribution(t *testing.T) (*keeper.Keeper, sdk.Context, *MockBankKeeper, *MockStakingKeeper, distrkeeper.Keeper, *MockPosKeeper) {
+	bankKeeper := NewMockBankKeeper()
+	stakingKeeper := NewMockStakingKeeper()
+	slashingKeeper := NewMockSlashingKeeper()
+	posKeeper := NewMockPosKeeper()
+
+	encodingConfig := MakeTestEncodingConfig()
+	storeKey := sdk.NewKVStoreKey(types.StoreKey)
+	memStoreKey := sdk.NewKVStoreKey(types.MemStoreKey)
+	distrStoreKey := sdk.NewKVStoreKey(distrtypes.StoreKey)
+
+	db := tmdb.NewMemDB()
+	stateStore := store.NewCommitMultiStore(db)
+	for _, key := range []storetypes.StoreKey{storeKey, memStoreKey, distrStoreKey} {
+		stateStore.MountStoreWithDB(key, storetypes.StoreTypeIAVL, db)
+	}
+	require.NoError(t, stateStore.LoadLatestVersion())
+
+	authority := authtypes.NewModuleAddress(govtypes.ModuleName).String()
+
+	// Bank and staking are not needed to allocate tokens to validators
+	distrKeeper := distrkeeper.NewKeeper(
+		encodingConfig.Marshaler,
+		distrStoreKey,
+		MockAccountKeeper{},
+		nil,
+		nil,
+		authtypes.FeeCollectorName,
+		authority,
+	)
+
+	paramsKeeper := initParamsKeeper(
+		encodingConfig.Marshaler,
+		encodingConfig.Amino,
+		storeKey,
+		memStoreKey,
+	)
+	k := keeper.NewKeeper(
+		encodingConfig.Marshaler,
+		storeKey,
+		paramsKeeper.Subspace(types.ModuleName),
+		bankKeeper,
+		stakingKeeper,
+		slashingKeeper,
+		distrKeeper,
+		posKeeper,
+		authority,
+	)
+
+	ctx := sdk.NewContext(stateStore, tmproto.Header{Height: 1, Time: time.Now().UTC()}, false, log.NewNopLogger())
+	require.NoError(t, distrKeeper.SetParams(ctx, distrtypes.DefaultParams()))
+	distrKeeper.SetFeePool(ctx, distrtypes.InitialFeePool())
+
+	return k, ctx, bankKeeper, stakingKeeper, distrKeeper, posKeeper
+}
+
+// TestAllocateTokensDistribution tests that modified shares of the block fees
+// end up in the validator rewards x/distribution pays delegators out of
+func TestAllocateTokensDistribution(t *testing.T) {
+	k, ctx, bank, staking, distr, pos := SetupDistribution(t)
+
+	strong := sdk.ValAddress("strong_validator____")
+	weak := sdk.ValAddress("weak_validator______")
+	strongCons := staking.AddValidator(strong)
+	weakCons := staking.AddValidator(weak)
+
+	// The commission of the strong validator is taken out of its modified share
+	staking.Validators[0].Commission.Rate = sdk.NewDecWithPrec(1, 1)
+
+	pos.TotalServiceScore = sdk.NewInt(100)
+	k.SetNodePerformance(ctx, types.NodePerformance{
+		ValidatorAddr: strong.String(),
+		ServiceScore:  sdk.NewInt(100),
+		UptimePercent: sdk.OneDec(),
+		ResponseTime:  sdk.NewInt(100),
+	})
+	k.SetNodePerformance(ctx, types.NodePerformance{
+		ValidatorAddr: weak.String(),
+		ServiceScore:  sdk.ZeroInt(),
+		UptimePercent: sdk.NewDecWithPrec(5, 1),
+		ResponseTime:  sdk.NewInt(900),
+	})
+
+	fees := sdk.NewCoins(sdk.NewInt64Coin("userv", 1000))
+	bank.SetModuleBalance(authtypes.FeeCollectorName, fees)
+
+	votes := []abci.VoteInfo{
+		{Validator: abci.Validator{Address: strongCons, Power: 10}, SignedLastBlock: true},
+		{Validator: abci.Validator{Address: weakCons, Power: 10}, SignedLastBlock: true},
+	}
+	k.AllocateTokens(ctx, votes)
+	require.Equal(t, fees, bank.ModuleBalance(distrtypes.ModuleName))
+
+	// The higher performer is owed more for the same voting power
+	strongOutstanding := distr.GetValidatorOutstandingRewards(ctx, strong).Rewards.AmountOf("userv")
+	weakOutstanding := distr.GetValidatorOutstandingRewards(ctx, weak).Rewards.AmountOf("userv")
+	require.True(t, strongOutstanding.GT(weakOutstanding), "strong %s, weak %s", strongOutstanding, weakOutstanding)
+
+	// Its delegators share what is left after its commission
+	strongCommission := distr.GetValidatorAccumulatedCommission(ctx, strong).Commission.AmountOf("userv")
+	require.Equal(t, strongOutstanding.Mul(sdk.NewDecWithPrec(1, 1)), strongCommission)
+	require.Equal(t, strongOutstanding.Sub(strongCommission), distr.GetValidatorCurrentRewards(ctx, strong).Rewards.AmountOf("userv"))
+	require.Equal(t, weakOutstanding, distr.GetValidatorCurrentRewards(ctx, weak).Rewards.AmountOf("userv"))
+
+	// The community tax and what the modifiers left is in the community pool
+	communityPool := distr.GetFeePool(ctx).CommunityPool.AmountOf("userv")
+	require.True(t, communityPool.GTE(sdk.NewDec(20)))
+	require.Equal(t, sdk.NewDec(1000), strongOutstanding.Add(weakOutstanding).Add(communityPool))
+}
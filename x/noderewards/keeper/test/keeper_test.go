@@ -0,0 +1,871 @@
+package test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/query"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	distrtypes "github.com/cosmos/cosmos-sdk/x/distribution/types"
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+	"github.com/serv-chain/serv/x/noderewards"
+	"github.com/serv-chain/serv/x/noderewards/keeper"
+	"github.com/serv-chain/serv/x/noderewards/types"
+)
+
+// Setup initializes a test keeper with mock dependencies
+func Setup(t testing.TB) (*keeper.Keeper, sdk.Context, *MockBankKeeper, *MockStakingKeeper, *MockSlashingKeeper, *MockDistrKeeper, *MockPosKeeper) {
+	// Initialize keepers
+	bankKeeper := NewMockBankKeeper()
+	stakingKeeper := NewMockStakingKeeper()
+	slashingKeeper := NewMockSlashingKeeper()
+	distrKeeper := NewMockDistrKeeper()
+	posKeeper := NewMockPosKeeper()
+
+	// Initialize codec
+	encodingConfig := MakeTestEncodingConfig()
+	storeKey := sdk.NewKVStoreKey(types.StoreKey)
+	memStoreKey := sdk.NewKVStoreKey(types.MemStoreKey)
+
+	// Initialize params keeper and subspace
+	paramsKeeper := initParamsKeeper(
+		encodingConfig.Marshaler,
+		encodingConfig.Amino,
+		storeKey,
+		memStoreKey,
+	)
+	subspace := paramsKeeper.Subspace(types.ModuleName)
+
+	// Create test keeper
+	k := keeper.NewKeeper(
+		encodingConfig.Marshaler,
+		storeKey,
+		subspace,
+		bankKeeper,
+		stakingKeeper,
+		slashingKeeper,
+		distrKeeper,
+		posKeeper,
+		authtypes.NewModuleAddress(govtypes.ModuleName).String(),
+	)
+
+	// Create test context
+	ctx := sdk.NewContext(
+		initKVStore(t, storeKey),
+		tmproto.Header{Height: 1, Time: time.Now().UTC()},
+		false,
+		nil,
+	)
+
+	return k, ctx, bankKeeper, stakingKeeper, slashingKeeper, distrKeeper, posKeeper
+}
+
+// TestUpdateParams tests that only the authority can update params and only to valid ones
+func TestUpdateParams(t *testing.T) {
+	k, ctx, _, _, _, _, _ := Setup(t)
+	msgServer := keeper.NewMsgServer(*k)
+	k.SetParams(ctx, types.DefaultParams())
+
+	params := types.DefaultParams()
+	params.MaxResponseTime = sdk.NewInt(500)
+
+	// Any other signer is rejected
+	other := authtypes.NewModuleAddress("other").String()
+	_, err := msgServer.UpdateParams(sdk.WrapSDKContext(ctx), types.NewMsgUpdateParams(other, params))
+	require.ErrorIs(t, err, govtypes.ErrInvalidSigner)
+	require.Equal(t, types.DefaultParams(), k.GetParams(ctx))
+
+	// Invalid params are rejected even from the authority
+	invalid := params
+	invalid.MaxResponseTime = sdk.ZeroInt()
+	_, err = msgServer.UpdateParams(sdk.WrapSDKContext(ctx), types.NewMsgUpdateParams(k.GetAuthority(), invalid))
+	require.Error(t, err)
+	require.Equal(t, types.DefaultParams(), k.GetParams(ctx))
+
+	// The gov module account can update the params
+	_, err = msgServer.UpdateParams(sdk.WrapSDKContext(ctx), types.NewMsgUpdateParams(k.GetAuthority(), params))
+	require.NoError(t, err)
+	require.Equal(t, params, k.GetParams(ctx))
+}
+
+// TestAllocateTokens tests that block fees are shared among validators by voting power scaled by performance
+func TestAllocateTokens(t *testing.T) {
+	k, ctx, bank, staking, _, distr, pos := Setup(t)
+
+	strong := sdk.ValAddress("strong_validator____")
+	weak := sdk.ValAddress("weak_validator______")
+	absent := sdk.ValAddress("absent_validator____")
+	strongCons := staking.AddValidator(strong)
+	weakCons := staking.AddValidator(weak)
+	staking.AddValidator(absent)
+
+	// The strong validator provides all service, is always up and responds quickly
+	pos.TotalServiceScore = sdk.NewInt(100)
+	k.SetNodePerformance(ctx, types.NodePerformance{
+		ValidatorAddr: strong.String(),
+		ServiceScore:  sdk.NewInt(100),
+		UptimePercent: sdk.OneDec(),
+		ResponseTime:  sdk.NewInt(100),
+	})
+	k.SetNodePerformance(ctx, types.NodePerformance{
+		ValidatorAddr: weak.String(),
+		ServiceScore:  sdk.ZeroInt(),
+		UptimePercent: sdk.NewDecWithPrec(5, 1),
+		ResponseTime:  sdk.NewInt(900),
+	})
+
+	fees := sdk.NewCoins(sdk.NewInt64Coin("userv", 1000))
+	bank.SetModuleBalance(authtypes.FeeCollectorName, fees)
+	distr.CommunityTax = sdk.NewDecWithPrec(2, 2)
+
+	// Both validators are in the commit with equal power, the absent one is not
+	votes := []abci.VoteInfo{
+		{Validator: abci.Validator{Address: strongCons, Power: 10}, SignedLastBlock: true},
+		{Validator: abci.Validator{Address: weakCons, Power: 10}, SignedLastBlock: true},
+	}
+	k.AllocateTokens(ctx, votes)
+
+	// The fees moved to the distribution module
+	require.True(t, bank.ModuleBalance(authtypes.FeeCollectorName).IsZero())
+	require.Equal(t, fees, bank.ModuleBalance(distrtypes.ModuleName))
+
+	// The higher performer receives more for the same voting power
+	strongReward := distr.Allocations[strong.String()].AmountOf("userv")
+	weakReward := distr.Allocations[weak.String()].AmountOf("userv")
+	require.True(t, strongReward.GT(weakReward), "strong %s, weak %s", strongReward, weakReward)
+	require.True(t, distr.Allocations[absent.String()].IsZero())
+
+	// Modifiers 1.97 and 0.755 overdraw the 980 left after the community tax,
+	// so both rewards are scaled down to fit it
+	validatorPool := sdk.NewDec(980)
+	require.True(t, strongReward.Add(weakReward).LTE(validatorPool))
+	require.True(t, strongReward.Add(weakReward).GT(validatorPool.Sub(sdk.OneDec())))
+	ratio := sdk.NewDecWithPrec(197, 2).Quo(sdk.NewDecWithPrec(755, 3))
+	require.True(t, strongReward.Quo(weakReward).Sub(ratio).Abs().LT(sdk.NewDecWithPrec(1, 6)))
+
+	// Nothing is lost, the community pool receives the rest
+	communityPool := distr.FeePool.CommunityPool.AmountOf("userv")
+	require.Equal(t, sdk.NewDec(1000), strongReward.Add(weakReward).Add(communityPool))
+}
+
+// TestAllocateTokensNormalized tests that normalized modifiers pay out exactly the base pool
+func TestAllocateTokensNormalized(t *testing.T) {
+	k, ctx, bank, staking, _, distr, pos := Setup(t)
+
+	params := types.DefaultParams()
+	params.NormalizeModifiers = true
+	k.SetParams(ctx, params)
+
+	strong := sdk.ValAddress("strong_validator____")
+	weak := sdk.ValAddress("weak_validator______")
+	strongCons := staking.AddValidator(strong)
+	weakCons := staking.AddValidator(weak)
+
+	pos.TotalServiceScore = sdk.NewInt(100)
+	k.SetNodePerformance(ctx, types.NodePerformance{
+		ValidatorAddr: strong.String(),
+		ServiceScore:  sdk.NewInt(100),
+		UptimePercent: sdk.OneDec(),
+		ResponseTime:  sdk.NewInt(100),
+	})
+	k.SetNodePerformance(ctx, types.NodePerformance{
+		ValidatorAddr: weak.String(),
+		ServiceScore:  sdk.ZeroInt(),
+		UptimePercent: sdk.NewDecWithPrec(5, 1),
+		ResponseTime:  sdk.NewInt(900),
+	})
+
+	bank.SetModuleBalance(authtypes.FeeCollectorName, sdk.NewCoins(sdk.NewInt64Coin("userv", 1000)))
+	distr.CommunityTax = sdk.NewDecWithPrec(2, 2)
+
+	// Nothing is recorded before the first normalized block
+	_, found := k.GetModifierNormalization(ctx, ctx.BlockHeight())
+	require.False(t, found)
+	require.Equal(t, types.DefaultModifierNormalization(), k.GetLatestModifierNormalization(ctx))
+
+	k.AllocateTokens(ctx, []abci.VoteInfo{
+		{Validator: abci.Validator{Address: strongCons, Power: 10}, SignedLastBlock: true},
+		{Validator: abci.Validator{Address: weakCons, Power: 10}, SignedLastBlock: true},
+	})
+
+	// Modifiers 1.97 and 0.755 average 1.3625, so they are rescaled by 1 / 1.3625
+	factor := sdk.NewDec(20).Quo(sdk.NewDecWithPrec(2725, 2))
+	normalization, found := k.GetModifierNormalization(ctx, ctx.BlockHeight())
+	require.True(t, found)
+	require.Equal(t, ctx.BlockHeight(), normalization.Height)
+	require.Equal(t, factor, normalization.Factor)
+
+	// The payouts equal the base pool, the weak validator's forfeit goes to the strong one
+	base := sdk.NewDec(490)
+	strongReward := distr.Allocations[strong.String()].AmountOf("userv")
+	weakReward := distr.Allocations[weak.String()].AmountOf("userv")
+	require.True(t, strongReward.GT(base))
+	require.True(t, weakReward.LT(base))
+	require.True(t, strongReward.Add(weakReward).Sub(sdk.NewDec(980)).Abs().LT(sdk.NewDecWithPrec(1, 15)))
+
+	// Only the community tax and rounding dust go to the community pool
+	communityPool := distr.FeePool.CommunityPool.AmountOf("userv")
+	require.Equal(t, sdk.NewDec(1000), strongReward.Add(weakReward).Add(communityPool))
+}
+
+// TestModifierNormalizationHistory tests that modifier normalizations are kept by height and pruned with checkpoints
+func TestModifierNormalizationHistory(t *testing.T) {
+	k, ctx, _, _, _, _, _ := Setup(t)
+
+	params := types.DefaultParams()
+	params.CheckpointRetention = 10
+	k.SetParams(ctx, params)
+
+	// Every block keeps its own factor instead of overwriting the previous one
+	for height := int64(1); height <= 30; height++ {
+		k.SetModifierNormalization(ctx, types.ModifierNormalization{
+			Height: height,
+			Factor: sdk.NewDec(height),
+		})
+	}
+	normalization, found := k.GetModifierNormalization(ctx, 7)
+	require.True(t, found)
+	require.Equal(t, sdk.NewDec(7), normalization.Factor)
+	require.Equal(t, sdk.NewDec(30), k.GetLatestModifierNormalization(ctx).Factor)
+
+	querier := keeper.NewQueryServer(*k)
+	res, err := querier.ModifierNormalization(sdk.WrapSDKContext(ctx), &types.QueryModifierNormalizationRequest{Height: 7})
+	require.NoError(t, err)
+	require.Equal(t, int64(7), res.Normalization.Height)
+	res, err = querier.ModifierNormalization(sdk.WrapSDKContext(ctx), &types.QueryModifierNormalizationRequest{})
+	require.NoError(t, err)
+	require.Equal(t, int64(30), res.Normalization.Height)
+
+	// Normalizations older than the retention window are pruned
+	k.PruneModifierNormalizations(ctx.WithBlockHeight(30))
+	_, found = k.GetModifierNormalization(ctx, 19)
+	require.False(t, found)
+	_, found = k.GetModifierNormalization(ctx, 20)
+	require.True(t, found)
+	_, err = querier.ModifierNormalization(sdk.WrapSDKContext(ctx), &types.QueryModifierNormalizationRequest{Height: 7})
+	require.Error(t, err)
+
+	// The remaining ones round trip through genesis
+	genesis := noderewards.ExportGenesis(ctx, *k)
+	require.Len(t, genesis.ModifierNormalizations, 11)
+	require.NoError(t, genesis.Validate())
+	genesis.ModifierNormalizations = append(genesis.ModifierNormalizations, genesis.ModifierNormalizations[0])
+	require.Error(t, genesis.Validate())
+}
+
+// TestAllocateTokensLowPerformers tests that rewards modified below the base leave the rest to the community pool
+func TestAllocateTokensLowPerformers(t *testing.T) {
+	k, ctx, bank, staking, _, distr, _ := Setup(t)
+
+	val := sdk.ValAddress("validator___________")
+	cons := staking.AddValidator(val)
+
+	// No service, no uptime and the slowest responses earn the minimum modifier of 0.5
+	k.SetNodePerformance(ctx, types.NodePerformance{
+		ValidatorAddr: val.String(),
+		ServiceScore:  sdk.ZeroInt(),
+		UptimePercent: sdk.ZeroDec(),
+		ResponseTime:  sdk.NewInt(1000),
+	})
+
+	bank.SetModuleBalance(authtypes.FeeCollectorName, sdk.NewCoins(sdk.NewInt64Coin("userv", 1000)))
+	k.AllocateTokens(ctx, []abci.VoteInfo{
+		{Validator: abci.Validator{Address: cons, Power: 10}, SignedLastBlock: true},
+	})
+
+	require.Equal(t, sdk.NewDec(500), distr.Allocations[val.String()].AmountOf("userv"))
+	require.Equal(t, sdk.NewDec(500), distr.FeePool.CommunityPool.AmountOf("userv"))
+
+	// Without fees nothing is allocated
+	k.AllocateTokens(ctx, []abci.VoteInfo{
+		{Validator: abci.Validator{Address: cons, Power: 10}, SignedLastBlock: true},
+	})
+	require.Equal(t, sdk.NewDec(500), distr.Allocations[val.String()].AmountOf("userv"))
+}
+
+// TestSmoothedPerformance tests that the reward modifier follows the moving average of performance
+func TestSmoothedPerformance(t *testing.T) {
+	k, ctx, _, _, _, _, pos := Setup(t)
+
+	// A window of 3 blocks weights every new sample by a half
+	params := types.DefaultParams()
+	params.SmoothingWindow = 3
+	k.SetParams(ctx, params)
+	alpha := params.SmoothingFactor()
+	require.Equal(t, sdk.NewDecWithPrec(5, 1), alpha)
+
+	addr := sdk.ValAddress("validator___________").String()
+	pos.TotalServiceScore = sdk.NewInt(100)
+	good := types.NodePerformance{
+		ValidatorAddr:    addr,
+		ServiceScore:     sdk.NewInt(100),
+		UptimePercent:    sdk.OneDec(),
+		ResponseTime:     sdk.NewInt(100),
+		LastUpdateHeight: 1,
+	}
+	bad := types.NodePerformance{
+		ValidatorAddr:    addr,
+		ServiceScore:     sdk.NewInt(100),
+		UptimePercent:    sdk.ZeroDec(),
+		ResponseTime:     sdk.NewInt(900),
+		LastUpdateHeight: 2,
+	}
+
+	// Before the first sample the smoothed metrics equal the snapshot
+	k.SetNodePerformance(ctx, good)
+	smoothed := k.GetSmoothedPerformance(ctx, addr)
+	require.Equal(t, types.NewSmoothedPerformance(good), smoothed)
+	require.Equal(t, uint64(0), smoothed.Samples)
+
+	// The first sample starts the averages, the next ones move them by alpha
+	smoothed = smoothed.Add(good, alpha)
+	require.Equal(t, uint64(1), smoothed.Samples)
+	require.Equal(t, sdk.OneDec(), smoothed.UptimePercent)
+	smoothed = smoothed.Add(bad, alpha)
+	require.Equal(t, uint64(2), smoothed.Samples)
+	require.Equal(t, sdk.NewDec(100), smoothed.ServiceScore)
+	require.Equal(t, sdk.NewDecWithPrec(5, 1), smoothed.UptimePercent)
+	require.Equal(t, sdk.NewDec(500), smoothed.ResponseTime)
+	require.Equal(t, int64(2), smoothed.LastUpdateHeight)
+	k.SetSmoothedPerformance(ctx, smoothed)
+	k.SetNodePerformance(ctx, bad)
+
+	// One bad window only halves the uptime and response time scores:
+	// 0.5 + 0.3 * 0.5 + 0.2 * 0.5 = 0.75 instead of 0.52 for the snapshot
+	require.Equal(t, sdk.NewDecWithPrec(1625, 3), k.CalculateRewardModifier(ctx, addr))
+
+	// Without smoothing a sample replaces the averages
+	params.SmoothingWindow = 0
+	require.Equal(t, sdk.OneDec(), params.SmoothingFactor())
+	smoothed = smoothed.Add(bad, params.SmoothingFactor())
+	require.Equal(t, sdk.ZeroDec(), smoothed.UptimePercent)
+	require.Equal(t, sdk.NewDec(900), smoothed.ResponseTime)
+
+	var exported []types.SmoothedPerformance
+	k.IterateSmoothedPerformances(ctx, func(smoothed types.SmoothedPerformance) bool {
+		exported = append(exported, smoothed)
+		return false
+	})
+	require.Len(t, exported, 1)
+	require.Equal(t, addr, exported[0].ValidatorAddr)
+}
+
+// TestUpdateBondedNodePerformances tests that bonded validators are updated periodically and on events
+func TestUpdateBondedNodePerformances(t *testing.T) {
+	k, ctx, _, staking, slashing, _, pos := Setup(t)
+
+	params := types.DefaultParams()
+	params.UpdateInterval = 10
+	k.SetParams(ctx, params)
+
+	valA := sdk.ValAddress("validator_a_________")
+	valB := sdk.ValAddress("validator_b_________")
+	valC := sdk.ValAddress("validator_c_________")
+	consA := staking.AddValidator(valA)
+	consB := staking.AddValidator(valB)
+	staking.AddUnbondedValidator(valC)
+	slashing.SetMissedBlocks(consA, 10)
+	pos.ServiceScores[sdk.AccAddress(valA).String()] = sdk.NewInt(50)
+
+	// Nothing is updated between intervals
+	ctx = ctx.WithBlockHeight(5)
+	require.False(t, k.IsNodePerformanceUpdateHeight(ctx))
+	noderewards.BeginBlocker(ctx, abci.RequestBeginBlock{}, *k)
+	require.Equal(t, int64(0), k.GetNodePerformance(ctx, valA.String()).LastUpdateHeight)
+
+	// At the interval the bonded validators are updated with a single event
+	ctx = ctx.WithBlockHeight(10).WithEventManager(sdk.NewEventManager())
+	require.True(t, k.IsNodePerformanceUpdateHeight(ctx))
+	noderewards.BeginBlocker(ctx, abci.RequestBeginBlock{}, *k)
+
+	performance := k.GetNodePerformance(ctx, valA.String())
+	require.Equal(t, int64(10), performance.LastUpdateHeight)
+	require.Equal(t, sdk.NewInt(50), performance.ServiceScore)
+	require.Equal(t, sdk.NewDecWithPrec(9, 1), performance.UptimePercent)
+	require.Equal(t, int64(10), k.GetNodePerformance(ctx, valB.String()).LastUpdateHeight)
+	require.Equal(t, int64(0), k.GetNodePerformance(ctx, valC.String()).LastUpdateHeight)
+	require.Len(t, ctx.EventManager().Events(), 1)
+	require.Equal(t, types.EventTypeNodePerformancesUpdated, ctx.EventManager().Events()[0].Type)
+
+	// A verified proof updates its provider right away, unless it is unbonded
+	ctx = ctx.WithBlockHeight(13)
+	pos.ServiceScores[sdk.AccAddress(valA).String()] = sdk.NewInt(80)
+	k.ProofOfServiceHooks().AfterProofVerified(ctx, sdk.AccAddress(valA).String(), "proof-1", sdk.NewInt(30))
+	performance = k.GetNodePerformance(ctx, valA.String())
+	require.Equal(t, int64(13), performance.LastUpdateHeight)
+	require.Equal(t, sdk.NewInt(80), performance.ServiceScore)
+
+	// The event sample only covers 3 of the 10 blocks of the interval
+	alpha := params.SmoothingFactor().MulInt64(3).QuoInt64(10)
+	require.Equal(t, alpha, params.SampleSmoothingFactor(3))
+	smoothed := k.GetSmoothedPerformance(ctx, valA.String())
+	require.Equal(t, uint64(2), smoothed.Samples)
+	require.Equal(t, sdk.NewDec(50).Add(sdk.NewDec(30).Mul(alpha)), smoothed.ServiceScore)
+
+	// Further events in the same block update the snapshot but not the averages
+	pos.ServiceScores[sdk.AccAddress(valA).String()] = sdk.NewInt(100)
+	k.ProofOfServiceHooks().AfterProofVerified(ctx, sdk.AccAddress(valA).String(), "proof-3", sdk.NewInt(20))
+	require.Equal(t, sdk.NewInt(100), k.GetNodePerformance(ctx, valA.String()).ServiceScore)
+	require.Equal(t, smoothed.ServiceScore, k.GetSmoothedPerformance(ctx, valA.String()).ServiceScore)
+
+	// Samples a whole interval or more apart, or without smoothing, keep their full weight
+	require.Equal(t, params.SmoothingFactor(), params.SampleSmoothingFactor(10))
+	require.Equal(t, params.SmoothingFactor(), params.SampleSmoothingFactor(25))
+	noSmoothing := params
+	noSmoothing.SmoothingWindow = 0
+	require.Equal(t, sdk.OneDec(), noSmoothing.SampleSmoothingFactor(3))
+	k.ProofOfServiceHooks().AfterProofVerified(ctx, sdk.AccAddress(valC).String(), "proof-2", sdk.NewInt(30))
+	require.Equal(t, int64(0), k.GetNodePerformance(ctx, valC.String()).LastUpdateHeight)
+
+	// A slash updates the slashed validator
+	ctx = ctx.WithBlockHeight(14)
+	slashing.SetMissedBlocks(consB, 50)
+	require.NoError(t, k.StakingHooks().BeforeValidatorSlashed(ctx, valB, sdk.NewDecWithPrec(1, 2)))
+	performance = k.GetNodePerformance(ctx, valB.String())
+	require.Equal(t, int64(14), performance.LastUpdateHeight)
+	require.Equal(t, sdk.NewDecWithPrec(5, 1), performance.UptimePercent)
+}
+
+// TestValidatorAddresses tests that validators are accepted by account or operator address
+func TestValidatorAddresses(t *testing.T) {
+	k, ctx, _, staking, slashing, _, pos := Setup(t)
+
+	operator := sdk.ValAddress("validator___________")
+	account := sdk.AccAddress(operator)
+	consAddr := staking.AddValidator(operator)
+	slashing.SetMissedBlocks(consAddr, 20)
+
+	// The validator provides service from its operator account
+	pos.ServiceScores[account.String()] = sdk.NewInt(40)
+
+	// Either form updates the performance stored by operator address
+	for height, addr := range []string{account.String(), operator.String()} {
+		ctx = ctx.WithBlockHeight(int64(height + 1))
+		require.NoError(t, k.UpdateNodePerformance(ctx, addr))
+
+		performance := k.GetNodePerformance(ctx, operator.String())
+		require.Equal(t, operator.String(), performance.ValidatorAddr)
+		require.Equal(t, int64(height+1), performance.LastUpdateHeight)
+		require.Equal(t, sdk.NewInt(40), performance.ServiceScore)
+		require.Equal(t, sdk.NewDecWithPrec(8, 1), performance.UptimePercent)
+	}
+
+	// Consensus addresses and unknown validators are rejected
+	require.Error(t, k.UpdateNodePerformance(ctx, consAddr.String()))
+	require.Error(t, k.UpdateNodePerformance(ctx, sdk.ValAddress("unknown_validator___").String()))
+
+	// Queries accept either form too
+	querier := keeper.NewQueryServer(*k)
+	for _, addr := range []string{account.String(), operator.String()} {
+		res, err := querier.NodePerformance(sdk.WrapSDKContext(ctx), &types.QueryNodePerformanceRequest{ValidatorAddr: addr})
+		require.NoError(t, err)
+		require.Equal(t, operator.String(), res.Performance.ValidatorAddr)
+		require.Equal(t, sdk.NewInt(40), res.Performance.ServiceScore)
+	}
+	_, err := querier.NodePerformance(sdk.WrapSDKContext(ctx), &types.QueryNodePerformanceRequest{ValidatorAddr: consAddr.String()})
+	require.Error(t, err)
+
+	// Validators whose consensus key cannot be decoded are not updated and earn nothing
+	broken := sdk.ValAddress("broken_validator____")
+	staking.Validators = append(staking.Validators, stakingtypes.Validator{
+		OperatorAddress: broken.String(),
+		ConsensusPubkey: &codectypes.Any{},
+		Status:          stakingtypes.Bonded,
+	})
+	require.Error(t, k.UpdateNodePerformance(ctx, broken.String()))
+	require.True(t, k.CalculateRewardModifier(ctx, broken.String()).IsZero())
+}
+
+// TestUptimeWindows tests that uptime is read from the missed blocks bitmap over the configured window
+func TestUptimeWindows(t *testing.T) {
+	k, ctx, _, staking, slashing, _, _ := Setup(t)
+
+	operator := sdk.ValAddress("validator___________")
+	consAddr := staking.AddValidator(operator)
+
+	// Two and a half windows in, the last block signed is at index 49
+	missed := []int64{45, 46, 47, 48, 49}
+	for i := int64(0); i < 20; i++ {
+		missed = append(missed, i)
+	}
+	slashing.SetSigningInfo(consAddr, 250, missed...)
+
+	for _, tc := range []struct {
+		window uint64
+		uptime sdk.Dec
+	}{
+		{0, sdk.NewDecWithPrec(75, 2)},   // 25 of the 100 blocks of the whole window
+		{10, sdk.NewDecWithPrec(5, 1)},   // 45 to 49 of the last 10 blocks
+		{40, sdk.NewDecWithPrec(625, 3)}, // 45 to 49 and 10 to 19 of the last 40 blocks
+		{500, sdk.NewDecWithPrec(75, 2)}, // capped by the signed blocks window
+	} {
+		params := types.DefaultParams()
+		params.UptimeWindow = tc.window
+		k.SetParams(ctx, params)
+
+		require.NoError(t, k.UpdateNodePerformance(ctx, operator.String()))
+		require.Equal(t, tc.uptime, k.GetNodePerformance(ctx, operator.String()).UptimePercent, "uptime window %d", tc.window)
+	}
+
+	// A new validator is measured over the blocks it has signed so far
+	newOperator := sdk.ValAddress("new_validator_______")
+	newConsAddr := staking.AddValidator(newOperator)
+	slashing.SetSigningInfo(newConsAddr, 4, 2)
+	require.NoError(t, k.UpdateNodePerformance(ctx, newOperator.String()))
+	require.Equal(t, sdk.NewDecWithPrec(75, 2), k.GetNodePerformance(ctx, newOperator.String()).UptimePercent)
+}
+
+// TestPenalizedValidators tests that jailed and tombstoned validators earn nothing
+func TestPenalizedValidators(t *testing.T) {
+	k, ctx, bank, staking, slashing, distr, _ := Setup(t)
+
+	params := types.DefaultParams()
+	params.NormalizeModifiers = true
+	k.SetParams(ctx, params)
+
+	active := sdk.ValAddress("active_validator____")
+	jailed := sdk.ValAddress("jailed_validator____")
+	tombstoned := sdk.ValAddress("tombstoned_validator")
+	activeCons := staking.AddValidator(active)
+	jailedCons := staking.AddValidator(jailed)
+	tombstonedCons := staking.AddValidator(tombstoned)
+	staking.Jail(jailed)
+	slashing.Tombstoned[tombstonedCons.String()] = true
+
+	require.True(t, k.CalculateRewardModifier(ctx, active.String()).IsPositive())
+	require.True(t, k.CalculateRewardModifier(ctx, jailed.String()).IsZero())
+	require.True(t, k.CalculateRewardModifier(ctx, tombstoned.String()).IsZero())
+
+	bank.SetModuleBalance(authtypes.FeeCollectorName, sdk.NewCoins(sdk.NewInt64Coin("userv", 1000)))
+	k.AllocateTokens(ctx, []abci.VoteInfo{
+		{Validator: abci.Validator{Address: activeCons, Power: 10}, SignedLastBlock: true},
+		{Validator: abci.Validator{Address: jailedCons, Power: 10}, SignedLastBlock: true},
+		{Validator: abci.Validator{Address: tombstonedCons, Power: 20}, SignedLastBlock: true},
+	})
+
+	// Normalization leaves the active validator its base share, the rest goes to the community pool
+	activeReward := distr.Allocations[active.String()].AmountOf("userv")
+	require.True(t, activeReward.Sub(sdk.NewDec(250)).Abs().LT(sdk.NewDecWithPrec(1, 15)))
+	require.True(t, distr.Allocations[jailed.String()].IsZero())
+	require.True(t, distr.Allocations[tombstoned.String()].IsZero())
+	require.Equal(t, sdk.NewDec(1000), activeReward.Add(distr.FeePool.CommunityPool.AmountOf("userv")))
+}
+
+// TestUpdateRewardModifier tests that only the gov authority updates the reward modifier, and only to a valid one
+func TestUpdateRewardModifier(t *testing.T) {
+	k, ctx, _, _, _, _, _ := Setup(t)
+	msgServer := keeper.NewMsgServer(*k)
+	authority := authtypes.NewModuleAddress(govtypes.ModuleName).String()
+
+	modifier := types.RewardModifier{
+		ServiceScoreWeight: sdk.NewDecWithPrec(6, 1),
+		UptimeWeight:       sdk.NewDecWithPrec(3, 1),
+		ResponseTimeWeight: sdk.NewDecWithPrec(1, 1),
+		MinModifier:        sdk.NewDecWithPrec(8, 1),
+		MaxModifier:        sdk.NewDecWithPrec(15, 1),
+	}
+
+	// Only the gov authority may update it
+	other := sdk.AccAddress("not_the_authority___").String()
+	_, err := msgServer.UpdateRewardModifier(sdk.WrapSDKContext(ctx), types.NewMsgUpdateRewardModifier(other, modifier))
+	require.Error(t, err)
+	require.Equal(t, types.DefaultRewardModifier(), k.GetRewardModifier(ctx))
+
+	_, err = msgServer.UpdateRewardModifier(sdk.WrapSDKContext(ctx), types.NewMsgUpdateRewardModifier(authority, modifier))
+	require.NoError(t, err)
+	require.Equal(t, modifier, k.GetRewardModifier(ctx))
+
+	// Invalid modifiers are rejected like in genesis
+	unbalanced := modifier
+	unbalanced.UptimeWeight = sdk.NewDecWithPrec(4, 1)
+	inverted := modifier
+	inverted.MinModifier = sdk.NewDec(2)
+	negative := modifier
+	negative.ServiceScoreWeight = sdk.NewDecWithPrec(-1, 1)
+	negative.UptimeWeight = sdk.OneDec()
+	for _, invalid := range []types.RewardModifier{unbalanced, inverted, negative} {
+		msg := types.NewMsgUpdateRewardModifier(authority, invalid)
+		require.Error(t, msg.ValidateBasic())
+
+		genesis := types.DefaultGenesis()
+		genesis.RewardModifier = invalid
+		require.Error(t, genesis.Validate())
+
+		_, err = msgServer.UpdateRewardModifier(sdk.WrapSDKContext(ctx), msg)
+		require.Error(t, err)
+		require.Equal(t, modifier, k.GetRewardModifier(ctx))
+	}
+}
+
+// TestPerformanceTiers tests that validators are ranked in tiers every tier epoch and put on probation in the lowest one
+func TestPerformanceTiers(t *testing.T) {
+	k, ctx, _, staking, slashing, _, pos := Setup(t)
+
+	params := types.DefaultParams()
+	params.ProbationEpochs = 2
+	params.JailOnProbation = true
+	k.SetParams(ctx, params)
+
+	good := sdk.ValAddress("good_validator______")
+	poor := sdk.ValAddress("poor_validator______")
+	staking.AddValidator(good)
+	poorCons := staking.AddValidator(poor)
+
+	pos.TotalServiceScore = sdk.NewInt(100)
+	k.SetSmoothedPerformance(ctx, types.SmoothedPerformance{
+		ValidatorAddr: good.String(),
+		ServiceScore:  sdk.NewDec(100),
+		UptimePercent: sdk.OneDec(),
+		ResponseTime:  sdk.ZeroDec(),
+	})
+	poorPerformance := types.SmoothedPerformance{
+		ValidatorAddr: poor.String(),
+		ServiceScore:  sdk.ZeroDec(),
+		UptimePercent: sdk.ZeroDec(),
+		ResponseTime:  sdk.NewDec(1000),
+	}
+	k.SetSmoothedPerformance(ctx, poorPerformance)
+
+	// Other epochs do not rank validators
+	k.EpochHooks().AfterEpochEnd(ctx, "week", 1)
+	_, found := k.GetValidatorTier(ctx, good.String())
+	require.False(t, found)
+
+	// The first epoch in the lowest tier is no probation yet
+	k.EpochHooks().AfterEpochEnd(ctx, params.TierEpochIdentifier, 1)
+	tier, found := k.GetValidatorTier(ctx, good.String())
+	require.True(t, found)
+	require.Equal(t, "gold", tier.Tier)
+	require.Equal(t, sdk.OneDec(), tier.Score)
+	tier, _ = k.GetValidatorTier(ctx, poor.String())
+	require.Equal(t, "bronze", tier.Tier)
+	require.Equal(t, uint64(1), tier.LowestTierEpochs)
+	require.False(t, tier.Probation)
+	require.True(t, k.CalculateRewardModifier(ctx, poor.String()).IsPositive())
+
+	// The second one is, which zeroes the modifier and jails the validator
+	ctx = ctx.WithEventManager(sdk.NewEventManager())
+	k.EpochHooks().AfterEpochEnd(ctx, params.TierEpochIdentifier, 2)
+	tier, _ = k.GetValidatorTier(ctx, poor.String())
+	require.Equal(t, uint64(2), tier.LowestTierEpochs)
+	require.True(t, tier.Probation)
+	require.True(t, k.CalculateRewardModifier(ctx, poor.String()).IsZero())
+	require.Equal(t, []sdk.ConsAddress{poorCons}, slashing.Jailed)
+	require.Equal(t, types.EventTypeValidatorProbation, ctx.EventManager().Events()[0].Type)
+
+	// Probation ends once the validator leaves the lowest tier
+	poorPerformance.ServiceScore = sdk.NewDec(60)
+	poorPerformance.UptimePercent = sdk.OneDec()
+	k.SetSmoothedPerformance(ctx, poorPerformance)
+	k.EpochHooks().AfterEpochEnd(ctx, params.TierEpochIdentifier, 3)
+	tier, _ = k.GetValidatorTier(ctx, poor.String())
+	require.Equal(t, "silver", tier.Tier)
+	require.Equal(t, uint64(0), tier.LowestTierEpochs)
+	require.False(t, tier.Probation)
+	require.True(t, k.CalculateRewardModifier(ctx, poor.String()).IsPositive())
+	require.Len(t, slashing.Jailed, 1)
+
+	// The history is queryable page by page
+	querier := keeper.NewQueryServer(*k)
+	res, err := querier.TierHistory(sdk.WrapSDKContext(ctx), &types.QueryTierHistoryRequest{
+		ValidatorAddr: poor.String(),
+		Pagination:    &query.PageRequest{Limit: 2},
+	})
+	require.NoError(t, err)
+	require.Len(t, res.History, 2)
+	require.Equal(t, uint64(1), res.History[0].EpochNumber)
+	require.True(t, res.History[1].Probation)
+
+	res, err = querier.TierHistory(sdk.WrapSDKContext(ctx), &types.QueryTierHistoryRequest{
+		ValidatorAddr: poor.String(),
+		Pagination:    &query.PageRequest{Key: res.Pagination.NextKey},
+	})
+	require.NoError(t, err)
+	require.Len(t, res.History, 1)
+	require.Equal(t, "silver", res.History[0].Tier)
+
+	_, err = querier.ValidatorTier(sdk.WrapSDKContext(ctx), &types.QueryValidatorTierRequest{
+		ValidatorAddr: sdk.ValAddress("unknown_validator___").String(),
+	})
+	require.Error(t, err)
+
+	// Tiers and their history survive a genesis export
+	genesis := noderewards.ExportGenesis(ctx, *k)
+	require.NoError(t, genesis.Validate())
+	require.Len(t, genesis.ValidatorTiers, 2)
+	require.Len(t, genesis.TierHistory, 6)
+
+	// Tiers must rank every score
+	params.Tiers = []types.PerformanceTier{{Name: "gold", MinScore: sdk.NewDecWithPrec(8, 1)}}
+	require.Error(t, params.Validate())
+	params.Tiers = []types.PerformanceTier{{Name: "bronze", MinScore: sdk.ZeroDec()}, {Name: "gold", MinScore: sdk.NewDecWithPrec(8, 1)}}
+	require.Error(t, params.Validate())
+}
+
+// TestProbationJailing tests that validators on probation are jailed for the jail duration and again after unjailing
+func TestProbationJailing(t *testing.T) {
+	k, ctx, _, staking, slashing, _, pos := Setup(t)
+
+	params := types.DefaultParams()
+	params.ProbationEpochs = 2
+	params.JailOnProbation = true
+	params.ProbationJailDuration = time.Hour
+	k.SetParams(ctx, params)
+
+	poor := sdk.ValAddress("poor_validator______")
+	poorCons := staking.AddValidator(poor)
+	pos.TotalServiceScore = sdk.NewInt(100)
+	k.SetSmoothedPerformance(ctx, types.SmoothedPerformance{
+		ValidatorAddr: poor.String(),
+		ServiceScore:  sdk.ZeroDec(),
+		UptimePercent: sdk.ZeroDec(),
+		ResponseTime:  sdk.NewDec(1000),
+	})
+
+	// Probation jails the validator until the jail duration has passed
+	k.EpochHooks().AfterEpochEnd(ctx, params.TierEpochIdentifier, 1)
+	k.EpochHooks().AfterEpochEnd(ctx, params.TierEpochIdentifier, 2)
+	require.Equal(t, []sdk.ConsAddress{poorCons}, slashing.Jailed)
+	require.Equal(t, ctx.BlockTime().Add(time.Hour), slashing.JailedUntil[poorCons.String()])
+
+	// A jailed validator is not bonded, so it is not ranked
+	staking.Validators[0].Status = stakingtypes.Unbonding
+	k.EpochHooks().AfterEpochEnd(ctx, params.TierEpochIdentifier, 3)
+	tier, _ := k.GetValidatorTier(ctx, poor.String())
+	require.Equal(t, uint64(2), tier.EpochNumber)
+	require.Equal(t, uint64(2), tier.LowestTierEpochs)
+
+	// Once unjailed it stays on probation, and another ProbationEpochs in the lowest tier jail it again
+	staking.Validators[0].Status = stakingtypes.Bonded
+	later := ctx.WithBlockTime(ctx.BlockTime().Add(2 * time.Hour))
+	k.EpochHooks().AfterEpochEnd(later, params.TierEpochIdentifier, 4)
+	tier, _ = k.GetValidatorTier(ctx, poor.String())
+	require.Equal(t, uint64(3), tier.LowestTierEpochs)
+	require.True(t, tier.Probation)
+	require.Len(t, slashing.Jailed, 1)
+
+	k.EpochHooks().AfterEpochEnd(later, params.TierEpochIdentifier, 5)
+	tier, _ = k.GetValidatorTier(ctx, poor.String())
+	require.Equal(t, uint64(4), tier.LowestTierEpochs)
+	require.True(t, tier.Probation)
+	require.Len(t, slashing.Jailed, 2)
+	require.Equal(t, later.BlockTime().Add(time.Hour), slashing.JailedUntil[poorCons.String()])
+
+	// The jail duration cannot be negative
+	params.ProbationJailDuration = -time.Hour
+	require.Error(t, params.Validate())
+}
+
+// TestPerformanceHistory tests that performance updates are checkpointed, pruned and queryable by height range
+func TestPerformanceHistory(t *testing.T) {
+	k, ctx, _, staking, slashing, _, _ := Setup(t)
+
+	params := types.DefaultParams()
+	params.UpdateInterval = 10
+	params.CheckpointRetention = 25
+	k.SetParams(ctx, params)
+
+	valA := sdk.ValAddress("validator_a_________")
+	valB := sdk.ValAddress("validator_b_________")
+	consA := staking.AddValidator(valA)
+	staking.AddValidator(valB)
+	slashing.SetMissedBlocks(consA, 10)
+
+	// Every periodic update is checkpointed, checkpoints older than the retention are pruned
+	for height := int64(10); height <= 40; height += 10 {
+		noderewards.BeginBlocker(ctx.WithBlockHeight(height), abci.RequestBeginBlock{}, *k)
+	}
+
+	// So is an update between intervals
+	ctx = ctx.WithBlockHeight(43)
+	require.NoError(t, k.UpdateNodePerformance(ctx, valA.String()))
+
+	querier := keeper.NewQueryServer(*k)
+	res, err := querier.PerformanceHistory(sdk.WrapSDKContext(ctx), &types.QueryPerformanceHistoryRequest{
+		ValidatorAddr: valA.String(),
+		Pagination:    &query.PageRequest{Limit: 2},
+	})
+	require.NoError(t, err)
+	require.Len(t, res.Checkpoints, 2)
+	require.Equal(t, int64(20), res.Checkpoints[0].Height)
+	require.Equal(t, valA.String(), res.Checkpoints[0].ValidatorAddr)
+	require.Equal(t, sdk.NewDecWithPrec(9, 1), res.Checkpoints[0].UptimePercent)
+	require.Equal(t, int64(30), res.Checkpoints[1].Height)
+
+	res, err = querier.PerformanceHistory(sdk.WrapSDKContext(ctx), &types.QueryPerformanceHistoryRequest{
+		ValidatorAddr: valA.String(),
+		Pagination:    &query.PageRequest{Key: res.Pagination.NextKey},
+	})
+	require.NoError(t, err)
+	require.Len(t, res.Checkpoints, 2)
+	require.Equal(t, int64(40), res.Checkpoints[0].Height)
+	require.Equal(t, int64(43), res.Checkpoints[1].Height)
+
+	// The range of a validator is paged through in either direction
+	res, err = querier.PerformanceHistory(sdk.WrapSDKContext(ctx), &types.QueryPerformanceHistoryRequest{
+		ValidatorAddr: valA.String(),
+		FromHeight:    25,
+		ToHeight:      43,
+		Pagination:    &query.PageRequest{Limit: 2, Reverse: true, CountTotal: true},
+	})
+	require.NoError(t, err)
+	require.Len(t, res.Checkpoints, 2)
+	require.Equal(t, int64(43), res.Checkpoints[0].Height)
+	require.Equal(t, int64(40), res.Checkpoints[1].Height)
+	require.Equal(t, uint64(3), res.Pagination.Total)
+
+	res, err = querier.PerformanceHistory(sdk.WrapSDKContext(ctx), &types.QueryPerformanceHistoryRequest{
+		ValidatorAddr: valA.String(),
+		FromHeight:    25,
+		ToHeight:      43,
+		Pagination:    &query.PageRequest{Key: res.Pagination.NextKey, Reverse: true},
+	})
+	require.NoError(t, err)
+	require.Len(t, res.Checkpoints, 1)
+	require.Equal(t, int64(30), res.Checkpoints[0].Height)
+	require.Nil(t, res.Pagination.NextKey)
+
+	res, err = querier.PerformanceHistory(sdk.WrapSDKContext(ctx), &types.QueryPerformanceHistoryRequest{
+		ValidatorAddr: valA.String(),
+		FromHeight:    25,
+		Pagination:    &query.PageRequest{Offset: 1, Limit: 1},
+	})
+	require.NoError(t, err)
+	require.Len(t, res.Checkpoints, 1)
+	require.Equal(t, int64(40), res.Checkpoints[0].Height)
+
+	_, err = querier.PerformanceHistory(sdk.WrapSDKContext(ctx), &types.QueryPerformanceHistoryRequest{
+		ValidatorAddr: valA.String(),
+		Pagination:    &query.PageRequest{Offset: 1, Key: res.Pagination.NextKey},
+	})
+	require.Error(t, err)
+
+	// Height ranges are inclusive and an empty address selects every validator
+	res, err = querier.PerformanceHistory(sdk.WrapSDKContext(ctx), &types.QueryPerformanceHistoryRequest{
+		FromHeight: 25,
+		ToHeight:   40,
+	})
+	require.NoError(t, err)
+	require.Len(t, res.Checkpoints, 4)
+	for _, checkpoint := range res.Checkpoints {
+		require.True(t, checkpoint.Height == 30 || checkpoint.Height == 40)
+	}
+
+	_, err = querier.PerformanceHistory(sdk.WrapSDKContext(ctx), &types.QueryPerformanceHistoryRequest{
+		FromHeight: 40,
+		ToHeight:   30,
+	})
+	require.Error(t, err)
+
+	// Checkpoints survive a genesis export
+	genesis := noderewards.ExportGenesis(ctx, *k)
+	require.NoError(t, genesis.Validate())
+	require.Len(t, genesis.PerformanceCheckpoints, 7)
+}
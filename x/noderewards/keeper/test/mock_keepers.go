@@ -0,0 +1,333 @@
+package test
+
+import (
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/crypto/keys/ed25519"
+	"github.com/cosmos/cosmos-sdk/store"
+	storetypes "github.com/cosmos/cosmos-sdk/store/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	distrtypes "github.com/cosmos/cosmos-sdk/x/distribution/types"
+	paramskeeper "github.com/cosmos/cosmos-sdk/x/params/keeper"
+	slashingtypes "github.com/cosmos/cosmos-sdk/x/slashing/types"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+	"github.com/serv-chain/serv/x/noderewards/types"
+	tmdb "github.com/tendermint/tm-db"
+	"testing"
+	"time"
+)
+
+// MockBankKeeper is a mock of the bank keeper for testing
+type MockBankKeeper struct {
+	Balances map[string]sdk.Coins
+}
+
+// NewMockBankKeeper returns a new mock bank keeper
+func NewMockBankKeeper() *MockBankKeeper {
+	return &MockBankKeeper{
+		Balances: make(map[string]sdk.Coins),
+	}
+}
+
+// GetAllBalances implements the BankKeeper interface
+func (k *MockBankKeeper) GetAllBalances(ctx sdk.Context, addr sdk.AccAddress) sdk.Coins {
+	return k.Balances[addr.String()]
+}
+
+// SendCoinsFromModuleToModule implements the BankKeeper interface
+func (k *MockBankKeeper) SendCoinsFromModuleToModule(ctx sdk.Context, senderModule, recipientModule string, amt sdk.Coins) error {
+	sender := authtypes.NewModuleAddress(senderModule).String()
+	recipient := authtypes.NewModuleAddress(recipientModule).String()
+	k.Balances[sender] = k.Balances[sender].Sub(amt...)
+	k.Balances[recipient] = k.Balances[recipient].Add(amt...)
+	return nil
+}
+
+// SetModuleBalance sets the balance of a module account
+func (k *MockBankKeeper) SetModuleBalance(moduleName string, coins sdk.Coins) {
+	k.Balances[authtypes.NewModuleAddress(moduleName).String()] = coins
+}
+
+// ModuleBalance returns the balance of a module account
+func (k *MockBankKeeper) ModuleBalance(moduleName string) sdk.Coins {
+	return k.Balances[authtypes.NewModuleAddress(moduleName).String()]
+}
+
+// MockStakingKeeper is a mock of the staking keeper for testing
+type MockStakingKeeper struct {
+	Validators []stakingtypes.Validator
+}
+
+// NewMockStakingKeeper returns a new mock staking keeper
+func NewMockStakingKeeper() *MockStakingKeeper {
+	return &MockStakingKeeper{}
+}
+
+// addValidator adds a validator with a consensus key derived from its
+// operator address and returns its consensus address
+func (k *MockStakingKeeper) addValidator(operator sdk.ValAddress, status stakingtypes.BondStatus) sdk.ConsAddress {
+	pubKey := ed25519.GenPrivKeyFromSecret(operator).PubKey()
+	validator, err := stakingtypes.NewValidator(operator, pubKey, stakingtypes.Description{})
+	if err != nil {
+		panic(err)
+	}
+	validator.Status = status
+	k.Validators = append(k.Validators, validator)
+	return sdk.ConsAddress(pubKey.Address())
+}
+
+// AddValidator adds a bonded validator and returns its consensus address
+func (k *MockStakingKeeper) AddValidator(operator sdk.ValAddress) sdk.ConsAddress {
+	return k.addValidator(operator, stakingtypes.Bonded)
+}
+
+// AddUnbondedValidator adds an unbonded validator and returns its consensus address
+func (k *MockStakingKeeper) AddUnbondedValidator(operator sdk.ValAddress) sdk.ConsAddress {
+	return k.addValidator(operator, stakingtypes.Unbonded)
+}
+
+// Jail jails the validator with the given operator address
+func (k *MockStakingKeeper) Jail(operator sdk.ValAddress) {
+	for i, validator := range k.Validators {
+		if validator.GetOperator().Equals(operator) {
+			k.Validators[i].Jailed = true
+		}
+	}
+}
+
+// GetBondedValidatorsByPower implements the StakingKeeper interface
+func (k *MockStakingKeeper) GetBondedValidatorsByPower(ctx sdk.Context) []stakingtypes.Validator {
+	validators := make([]stakingtypes.Validator, 0, len(k.Validators))
+	for _, validator := range k.Validators {
+		if validator.IsBonded() {
+			validators = append(validators, validator)
+		}
+	}
+	return validators
+}
+
+// GetValidator implements the StakingKeeper interface
+func (k *MockStakingKeeper) GetValidator(ctx sdk.Context, addr sdk.ValAddress) (stakingtypes.Validator, bool) {
+	for _, validator := range k.Validators {
+		if validator.GetOperator().Equals(addr) {
+			return validator, true
+		}
+	}
+	return stakingtypes.Validator{}, false
+}
+
+// GetValidatorByConsAddr implements the StakingKeeper interface
+func (k *MockStakingKeeper) GetValidatorByConsAddr(ctx sdk.Context, consAddr sdk.ConsAddress) (stakingtypes.Validator, bool) {
+	for _, validator := range k.Validators {
+		if validatorConsAddr, err := validator.GetConsAddr(); err == nil && validatorConsAddr.Equals(consAddr) {
+			return validator, true
+		}
+	}
+	return stakingtypes.Validator{}, false
+}
+
+// MockSlashingKeeper is a mock of the slashing keeper for testing
+type MockSlashingKeeper struct {
+	SigningInfos map[string]slashingtypes.ValidatorSigningInfo
+	MissedBlocks map[string]map[int64]bool
+	Tombstoned   map[string]bool
+	Jailed       []sdk.ConsAddress
+	JailedUntil  map[string]time.Time
+	Window       int64
+}
+
+// NewMockSlashingKeeper returns a new mock slashing keeper with a signed blocks window of 100
+func NewMockSlashingKeeper() *MockSlashingKeeper {
+	return &MockSlashingKeeper{
+		SigningInfos: make(map[string]slashingtypes.ValidatorSigningInfo),
+		MissedBlocks: make(map[string]map[int64]bool),
+		Tombstoned:   make(map[string]bool),
+		JailedUntil:  make(map[string]time.Time),
+		Window:       100,
+	}
+}
+
+// SetSigningInfo sets the signing info of a validator that has signed
+// indexOffset blocks and missed those at the given bitmap indices
+func (k *MockSlashingKeeper) SetSigningInfo(consAddr sdk.ConsAddress, indexOffset int64, missed ...int64) {
+	bitmap := make(map[int64]bool)
+	for _, index := range missed {
+		bitmap[index] = true
+	}
+	k.MissedBlocks[consAddr.String()] = bitmap
+	k.SigningInfos[consAddr.String()] = slashingtypes.ValidatorSigningInfo{
+		Address:             consAddr.String(),
+		IndexOffset:         indexOffset,
+		MissedBlocksCounter: int64(len(bitmap)),
+	}
+}
+
+// SetMissedBlocks sets the signing info of a validator that has signed a
+// whole window and missed its first count blocks
+func (k *MockSlashingKeeper) SetMissedBlocks(consAddr sdk.ConsAddress, count int64) {
+	missed := make([]int64, count)
+	for i := range missed {
+		missed[i] = int64(i)
+	}
+	k.SetSigningInfo(consAddr, k.Window, missed...)
+}
+
+// GetValidatorSigningInfo implements the SlashingKeeper interface
+func (k *MockSlashingKeeper) GetValidatorSigningInfo(ctx sdk.Context, consAddr sdk.ConsAddress) (slashingtypes.ValidatorSigningInfo, bool) {
+	info, found := k.SigningInfos[consAddr.String()]
+	return info, found
+}
+
+// GetValidatorMissedBlockBitArray implements the SlashingKeeper interface
+func (k *MockSlashingKeeper) GetValidatorMissedBlockBitArray(ctx sdk.Context, consAddr sdk.ConsAddress, index int64) bool {
+	return k.MissedBlocks[consAddr.String()][index]
+}
+
+// SignedBlocksWindow implements the SlashingKeeper interface
+func (k *MockSlashingKeeper) SignedBlocksWindow(ctx sdk.Context) int64 {
+	return k.Window
+}
+
+// IsTombstoned implements the SlashingKeeper interface
+func (k *MockSlashingKeeper) IsTombstoned(ctx sdk.Context, consAddr sdk.ConsAddress) bool {
+	return k.Tombstoned[consAddr.String()]
+}
+
+// Jail implements the SlashingKeeper interface
+func (k *MockSlashingKeeper) Jail(ctx sdk.Context, consAddr sdk.ConsAddress) {
+	k.Jailed = append(k.Jailed, consAddr)
+}
+
+// JailUntil implements the SlashingKeeper interface
+func (k *MockSlashingKeeper) JailUntil(ctx sdk.Context, consAddr sdk.ConsAddress, jailTime time.Time) {
+	k.JailedUntil[consAddr.String()] = jailTime
+}
+
+// MockAccountKeeper is a mock of the account keeper the distribution keeper
+// is built with, which only needs to know the module addresses
+type MockAccountKeeper struct{}
+
+// GetAccount implements the distribution AccountKeeper interface
+func (k MockAccountKeeper) GetAccount(ctx sdk.Context, addr sdk.AccAddress) authtypes.AccountI {
+	return nil
+}
+
+// GetModuleAddress implements the distribution AccountKeeper interface
+func (k MockAccountKeeper) GetModuleAddress(name string) sdk.AccAddress {
+	return authtypes.NewModuleAddress(name)
+}
+
+// GetModuleAccount implements the distribution AccountKeeper interface
+func (k MockAccountKeeper) GetModuleAccount(ctx sdk.Context, name string) authtypes.ModuleAccountI {
+	return authtypes.NewEmptyModuleAccount(name)
+}
+
+// SetModuleAccount implements the distribution AccountKeeper interface
+func (k MockAccountKeeper) SetModuleAccount(ctx sdk.Context, account authtypes.ModuleAccountI) {}
+
+// MockDistrKeeper is a mock of the distribution keeper for testing
+type MockDistrKeeper struct {
+	Allocations  map[string]sdk.DecCoins
+	CommunityTax sdk.Dec
+	FeePool      distrtypes.FeePool
+}
+
+// NewMockDistrKeeper returns a new mock distribution keeper
+func NewMockDistrKeeper() *MockDistrKeeper {
+	return &MockDistrKeeper{
+		Allocations:  make(map[string]sdk.DecCoins),
+		CommunityTax: sdk.ZeroDec(),
+		FeePool:      distrtypes.InitialFeePool(),
+	}
+}
+
+// AllocateTokensToValidator implements the DistrKeeper interface
+func (k *MockDistrKeeper) AllocateTokensToValidator(ctx sdk.Context, val stakingtypes.ValidatorI, tokens sdk.DecCoins) {
+	k.Allocations[val.GetOperator().String()] = k.Allocations[val.GetOperator().String()].Add(tokens...)
+}
+
+// GetCommunityTax implements the DistrKeeper interface
+func (k *MockDistrKeeper) GetCommunityTax(ctx sdk.Context) sdk.Dec {
+	return k.CommunityTax
+}
+
+// GetFeePool implements the DistrKeeper interface
+func (k *MockDistrKeeper) GetFeePool(ctx sdk.Context) distrtypes.FeePool {
+	return k.FeePool
+}
+
+// SetFeePool implements the DistrKeeper interface
+func (k *MockDistrKeeper) SetFeePool(ctx sdk.Context, feePool distrtypes.FeePool) {
+	k.FeePool = feePool
+}
+
+// MockPosKeeper is a mock of the proof of service keeper for testing
+type MockPosKeeper struct {
+	ServiceScores     map[string]sdk.Int
+	TotalServiceScore sdk.Int
+}
+
+// NewMockPosKeeper returns a new mock proof of service keeper
+func NewMockPosKeeper() *MockPosKeeper {
+	return &MockPosKeeper{
+		ServiceScores:     make(map[string]sdk.Int),
+		TotalServiceScore: sdk.ZeroInt(),
+	}
+}
+
+// GetServiceScore implements the ProofOfServiceKeeper interface
+func (k *MockPosKeeper) GetServiceScore(ctx sdk.Context, addr string) sdk.Int {
+	score, found := k.ServiceScores[addr]
+	if !found {
+		return sdk.ZeroInt()
+	}
+	return score
+}
+
+// GetTotalServiceScore implements the ProofOfServiceKeeper interface
+func (k *MockPosKeeper) GetTotalServiceScore(ctx sdk.Context) sdk.Int {
+	return k.TotalServiceScore
+}
+
+// MakeTestEncodingConfig creates a test encoding config
+func MakeTestEncodingConfig() TestEncodingConfig {
+	cdc := codec.NewLegacyAmino()
+	interfaceRegistry := codec.NewInterfaceRegistry()
+	marshaler := codec.NewProtoCodec(interfaceRegistry)
+
+	return TestEncodingConfig{
+		Marshaler:         marshaler,
+		Amino:             cdc,
+		InterfaceRegistry: interfaceRegistry,
+	}
+}
+
+// TestEncodingConfig specifies the concrete encoding types to use for a given app.
+// This is provided for compatibility between protobuf and amino implementations.
+type TestEncodingConfig struct {
+	Marshaler         codec.Codec
+	Amino             *codec.LegacyAmino
+	InterfaceRegistry codec.InterfaceRegistry
+}
+
+func initParamsKeeper(
+	cdc codec.BinaryCodec,
+	legacyAmino *codec.LegacyAmino,
+	key storetypes.StoreKey,
+	tkey storetypes.StoreKey,
+) paramskeeper.Keeper {
+	paramsKeeper := paramskeeper.NewKeeper(cdc, legacyAmino, key, tkey)
+
+	return paramsKeeper
+}
+
+func initKVStore(t testing.TB, storeKey storetypes.StoreKey) storetypes.KVStore {
+	db := tmdb.NewMemDB()
+	stateStore := store.NewCommitMultiStore(db)
+	stateStore.MountStoreWithDB(storeKey, storetypes.StoreTypeIAVL, db)
+	err := stateStore.LoadLatestVersion()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return stateStore.GetKVStore(storeKey)
+}
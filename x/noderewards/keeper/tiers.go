@@ -0,0 +1,181 @@
+package keeper
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/cosmos/cosmos-sdk/store/prefix"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+	"github.com/serv-chain/serv/x/noderewards/types"
+)
+
+// GetValidatorTier returns the performance tier a validator was last ranked in
+func (k Keeper) GetValidatorTier(ctx sdk.Context, validatorAddr string) (types.ValidatorTier, bool) {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(types.GetValidatorTierKey(validatorAddr))
+	if bz == nil {
+		return types.ValidatorTier{}, false
+	}
+
+	var tier types.ValidatorTier
+	k.cdc.MustUnmarshal(bz, &tier)
+	return tier, true
+}
+
+// SetValidatorTier sets the performance tier a validator was last ranked in
+func (k Keeper) SetValidatorTier(ctx sdk.Context, tier types.ValidatorTier) {
+	store := ctx.KVStore(k.storeKey)
+	bz := k.cdc.MustMarshal(&tier)
+	store.Set(types.GetValidatorTierKey(tier.ValidatorAddr), bz)
+}
+
+// IterateValidatorTiers iterates over the performance tiers of all ranked validators
+func (k Keeper) IterateValidatorTiers(ctx sdk.Context, cb func(tier types.ValidatorTier) (stop bool)) {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, types.ValidatorTierPrefix)
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		var tier types.ValidatorTier
+		k.cdc.MustUnmarshal(iterator.Value(), &tier)
+		if cb(tier) {
+			break
+		}
+	}
+}
+
+// IsOnProbation returns whether a validator is on probation
+func (k Keeper) IsOnProbation(ctx sdk.Context, validatorAddr string) bool {
+	tier, found := k.GetValidatorTier(ctx, validatorAddr)
+	return found && tier.Probation
+}
+
+// SetTierHistory records the performance tier a validator was ranked in at the end of an epoch
+func (k Keeper) SetTierHistory(ctx sdk.Context, tier types.ValidatorTier) {
+	store := ctx.KVStore(k.storeKey)
+	bz := k.cdc.MustMarshal(&tier)
+	store.Set(types.GetTierHistoryKey(tier.ValidatorAddr, tier.EpochNumber), bz)
+}
+
+// IterateTierHistory iterates over the recorded performance tiers of all
+// validators, ordered by validator and epoch
+func (k Keeper) IterateTierHistory(ctx sdk.Context, cb func(tier types.ValidatorTier) (stop bool)) {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, types.TierHistoryPrefix)
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		var tier types.ValidatorTier
+		k.cdc.MustUnmarshal(iterator.Value(), &tier)
+		if cb(tier) {
+			break
+		}
+	}
+}
+
+// tierHistoryStore returns the store of the performance tiers of a validator, keyed by epoch
+func (k Keeper) tierHistoryStore(ctx sdk.Context, validatorAddr string) prefix.Store {
+	return prefix.NewStore(ctx.KVStore(k.storeKey), types.GetTierHistoryPrefix(validatorAddr))
+}
+
+// AssignPerformanceTiers ranks the bonded validators in the performance tier
+// their score reaches at the end of an epoch and records it in their tier
+// history. A validator ranked in the lowest tier for ProbationEpochs
+// consecutive epochs is put on probation, which zeroes its reward modifier
+// and, with JailOnProbation, jails it for ProbationJailDuration. Probation
+// ends as soon as the validator is ranked above the lowest tier.
+//
+// Jailed validators are not bonded, so they are not ranked and their count
+// of epochs in the lowest tier stands still. Once unjailed they are ranked
+// again, and every further ProbationEpochs in the lowest tier jail them again.
+func (k Keeper) AssignPerformanceTiers(ctx sdk.Context, epochNumber uint64) {
+	params := k.GetParams(ctx)
+	lowest := params.LowestTier()
+
+	validators := k.stakingKeeper.GetBondedValidatorsByPower(ctx)
+	for _, validator := range validators {
+		operator := validator.GetOperator().String()
+		score := k.PerformanceScore(ctx, operator)
+		assigned := params.TierForScore(score)
+
+		tier, _ := k.GetValidatorTier(ctx, operator)
+		wasOnProbation := tier.Probation
+
+		tier.ValidatorAddr = operator
+		tier.EpochNumber = epochNumber
+		tier.Tier = assigned.Name
+		tier.Score = score
+		if assigned.Name == lowest.Name {
+			tier.LowestTierEpochs++
+		} else {
+			tier.LowestTierEpochs = 0
+			tier.Probation = false
+		}
+
+		if params.ProbationEpochs > 0 && tier.LowestTierEpochs >= params.ProbationEpochs {
+			switch {
+			case !tier.Probation:
+				tier.Probation = true
+				k.startProbation(ctx, validator, tier, params)
+			case params.JailOnProbation && tier.LowestTierEpochs%params.ProbationEpochs == 0:
+				k.startProbation(ctx, validator, tier, params)
+			}
+		}
+		if wasOnProbation && !tier.Probation {
+			ctx.EventManager().EmitEvent(
+				sdk.NewEvent(
+					types.EventTypeValidatorProbationEnded,
+					sdk.NewAttribute(types.AttributeKeyValidator, operator),
+					sdk.NewAttribute(types.AttributeKeyEpoch, fmt.Sprintf("%d", epochNumber)),
+					sdk.NewAttribute(types.AttributeKeyTier, tier.Tier),
+				),
+			)
+		}
+
+		k.SetValidatorTier(ctx, tier)
+		k.SetTierHistory(ctx, tier)
+	}
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			types.EventTypePerformanceTiersAssigned,
+			sdk.NewAttribute(types.AttributeKeyEpoch, fmt.Sprintf("%d", epochNumber)),
+			sdk.NewAttribute(types.AttributeKeyValidators, fmt.Sprintf("%d", len(validators))),
+		),
+	)
+}
+
+// startProbation puts a validator on probation, or keeps it there, and with
+// JailOnProbation jails it until ProbationJailDuration has passed
+func (k Keeper) startProbation(ctx sdk.Context, validator stakingtypes.Validator, tier types.ValidatorTier, params types.Params) {
+	jail := params.JailOnProbation
+	if jail {
+		consAddr, err := validator.GetConsAddr()
+		if err != nil {
+			k.Logger(ctx).Error("cannot jail validator with invalid consensus key", "validator", tier.ValidatorAddr, "err", err)
+			jail = false
+		} else {
+			k.slashingKeeper.Jail(ctx, consAddr)
+			k.slashingKeeper.JailUntil(ctx, consAddr, ctx.BlockTime().Add(params.ProbationJailDuration))
+		}
+	}
+
+	k.Logger(ctx).Info("Validator put on probation",
+		"validator", tier.ValidatorAddr,
+		"epoch", tier.EpochNumber,
+		"lowest_tier_epochs", tier.LowestTierEpochs,
+		"jailed", jail)
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			types.EventTypeValidatorProbation,
+			sdk.NewAttribute(types.AttributeKeyValidator, tier.ValidatorAddr),
+			sdk.NewAttribute(types.AttributeKeyEpoch, fmt.Sprintf("%d", tier.EpochNumber)),
+			sdk.NewAttribute(types.AttributeKeyTier, tier.Tier),
+			sdk.NewAttribute(types.AttributeKeyScore, tier.Score.String()),
+			sdk.NewAttribute(types.AttributeKeyLowestTierEpochs, fmt.Sprintf("%d", tier.LowestTierEpochs)),
+			sdk.NewAttribute(types.AttributeKeyJailed, strconv.FormatBool(jail)),
+		),
+	)
+}
@@ -0,0 +1,62 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+)
+
+// validatorUptime returns the share of the recent blocks a validator signed,
+// as tracked by the missed blocks bitmap of x/slashing. Blocks before the
+// validator started signing are not counted, so a new validator is not
+// penalized for the blocks of the window it did not exist for. With an
+// UptimeWindow shorter than the signed blocks window only the most recent
+// blocks are read from the bitmap. It returns false for a validator the
+// slashing module keeps no signing info for.
+func (k Keeper) validatorUptime(ctx sdk.Context, consAddr sdk.ConsAddress) (sdk.Dec, bool) {
+	info, found := k.slashingKeeper.GetValidatorSigningInfo(ctx, consAddr)
+	if !found {
+		return sdk.Dec{}, false
+	}
+
+	window := k.slashingKeeper.SignedBlocksWindow(ctx)
+	tracked := info.IndexOffset
+	if tracked > window {
+		tracked = window
+	}
+	if tracked <= 0 {
+		return sdk.OneDec(), true
+	}
+
+	blocks := int64(k.GetParams(ctx).UptimeWindow)
+	if blocks == 0 || blocks >= tracked {
+		// The slashing module keeps the count of the whole window
+		return sdk.OneDec().Sub(sdk.NewDec(info.MissedBlocksCounter).QuoInt64(tracked)), true
+	}
+
+	// The last block signed is at IndexOffset - 1, walk back from it
+	missed := int64(0)
+	for i := int64(1); i <= blocks; i++ {
+		if k.slashingKeeper.GetValidatorMissedBlockBitArray(ctx, consAddr, (info.IndexOffset-i)%window) {
+			missed++
+		}
+	}
+
+	return sdk.OneDec().Sub(sdk.NewDec(missed).QuoInt64(blocks)), true
+}
+
+// IsValidatorPenalized returns whether a validator is jailed or tombstoned,
+// which forfeits its performance based rewards. A validator whose consensus
+// key cannot be decoded cannot be checked for tombstoning and is penalized.
+func (k Keeper) IsValidatorPenalized(ctx sdk.Context, validator stakingtypes.Validator) bool {
+	if validator.IsJailed() {
+		return true
+	}
+
+	consAddr, err := validator.GetConsAddr()
+	if err != nil {
+		k.Logger(ctx).Error("invalid validator consensus key", "validator", validator.GetOperator().String(), "err", err)
+		return true
+	}
+
+	return k.slashingKeeper.IsTombstoned(ctx, consAddr)
+}
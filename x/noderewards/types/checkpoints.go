@@ -0,0 +1,85 @@
+package types
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// PerformanceCheckpoint is a snapshot of the raw and smoothed performance
+// metrics of a validator node at the height they were updated. Checkpoints
+// make up the performance time series of validators and are kept for
+// CheckpointRetention blocks.
+type PerformanceCheckpoint struct {
+	ValidatorAddr         string  `json:"validator_addr"`
+	Height                int64   `json:"height"`
+	ServiceScore          sdk.Int `json:"service_score"`
+	UptimePercent         sdk.Dec `json:"uptime_percent"`
+	ResponseTime          sdk.Int `json:"response_time"` // In milliseconds
+	SmoothedServiceScore  sdk.Dec `json:"smoothed_service_score"`
+	SmoothedUptimePercent sdk.Dec `json:"smoothed_uptime_percent"`
+	SmoothedResponseTime  sdk.Dec `json:"smoothed_response_time"` // In milliseconds
+}
+
+// NewPerformanceCheckpoint returns the checkpoint of a performance update
+func NewPerformanceCheckpoint(performance NodePerformance, smoothed SmoothedPerformance) PerformanceCheckpoint {
+	return PerformanceCheckpoint{
+		ValidatorAddr:         performance.ValidatorAddr,
+		Height:                performance.LastUpdateHeight,
+		ServiceScore:          performance.ServiceScore,
+		UptimePercent:         performance.UptimePercent,
+		ResponseTime:          performance.ResponseTime,
+		SmoothedServiceScore:  smoothed.ServiceScore,
+		SmoothedUptimePercent: smoothed.UptimePercent,
+		SmoothedResponseTime:  smoothed.ResponseTime,
+	}
+}
+
+// Validate performs basic validation of a performance checkpoint
+func (c PerformanceCheckpoint) Validate() error {
+	if c.ValidatorAddr == "" {
+		return fmt.Errorf("performance checkpoint validator address cannot be empty")
+	}
+	if c.Height < 0 {
+		return fmt.Errorf("performance checkpoint height cannot be negative: %d", c.Height)
+	}
+	if c.ServiceScore.IsNil() || c.ServiceScore.IsNegative() {
+		return fmt.Errorf("checkpoint service score cannot be negative: %s", c.ServiceScore)
+	}
+	if c.UptimePercent.IsNil() || c.UptimePercent.IsNegative() || c.UptimePercent.GT(sdk.OneDec()) {
+		return fmt.Errorf("checkpoint uptime percent must be between 0 and 1: %s", c.UptimePercent)
+	}
+	if c.ResponseTime.IsNil() || c.ResponseTime.IsNegative() {
+		return fmt.Errorf("checkpoint response time cannot be negative: %s", c.ResponseTime)
+	}
+	if c.SmoothedServiceScore.IsNil() || c.SmoothedServiceScore.IsNegative() {
+		return fmt.Errorf("checkpoint smoothed service score cannot be negative: %s", c.SmoothedServiceScore)
+	}
+	if c.SmoothedUptimePercent.IsNil() || c.SmoothedUptimePercent.IsNegative() || c.SmoothedUptimePercent.GT(sdk.OneDec()) {
+		return fmt.Errorf("checkpoint smoothed uptime percent must be between 0 and 1: %s", c.SmoothedUptimePercent)
+	}
+	if c.SmoothedResponseTime.IsNil() || c.SmoothedResponseTime.IsNegative() {
+		return fmt.Errorf("checkpoint smoothed response time cannot be negative: %s", c.SmoothedResponseTime)
+	}
+
+	return nil
+}
+
+// ValidateHeightRange checks that a height range is not reversed. A zero
+// bound leaves the range open on that side.
+func ValidateHeightRange(fromHeight, toHeight int64) error {
+	if fromHeight < 0 || toHeight < 0 {
+		return fmt.Errorf("heights cannot be negative: %d to %d", fromHeight, toHeight)
+	}
+	if toHeight > 0 && fromHeight > toHeight {
+		return fmt.Errorf("from height %d is after to height %d", fromHeight, toHeight)
+	}
+
+	return nil
+}
+
+// InHeightRange returns whether the checkpoint falls in a height range
+// validated by ValidateHeightRange
+func (c PerformanceCheckpoint) InHeightRange(fromHeight, toHeight int64) bool {
+	return c.Height >= fromHeight && (toHeight == 0 || c.Height <= toHeight)
+}
@@ -1,20 +1,43 @@
 package types
 
 import (
+	"time"
+
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	distrtypes "github.com/cosmos/cosmos-sdk/x/distribution/types"
+	slashingtypes "github.com/cosmos/cosmos-sdk/x/slashing/types"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
 )
 
+// BankKeeper defines the expected bank keeper
+type BankKeeper interface {
+	GetAllBalances(ctx sdk.Context, addr sdk.AccAddress) sdk.Coins
+	SendCoinsFromModuleToModule(ctx sdk.Context, senderModule, recipientModule string, amt sdk.Coins) error
+}
+
 // StakingKeeper defines the expected staking keeper
 type StakingKeeper interface {
-	GetAllValidators(ctx sdk.Context) []StakingValidator
-	GetValidator(ctx sdk.Context, addr sdk.AccAddress) (validator StakingValidator, found bool)
-	GetValidatorSigningInfo(ctx sdk.Context, consAddr sdk.ConsAddress) (SigningInfo, bool)
+	GetBondedValidatorsByPower(ctx sdk.Context) []stakingtypes.Validator
+	GetValidator(ctx sdk.Context, addr sdk.ValAddress) (validator stakingtypes.Validator, found bool)
+	GetValidatorByConsAddr(ctx sdk.Context, consAddr sdk.ConsAddress) (validator stakingtypes.Validator, found bool)
+}
+
+// SlashingKeeper defines the expected slashing keeper
+type SlashingKeeper interface {
+	GetValidatorSigningInfo(ctx sdk.Context, address sdk.ConsAddress) (info slashingtypes.ValidatorSigningInfo, found bool)
+	GetValidatorMissedBlockBitArray(ctx sdk.Context, address sdk.ConsAddress, index int64) bool
 	SignedBlocksWindow(ctx sdk.Context) int64
+	IsTombstoned(ctx sdk.Context, consAddr sdk.ConsAddress) bool
+	Jail(ctx sdk.Context, consAddr sdk.ConsAddress)
+	JailUntil(ctx sdk.Context, consAddr sdk.ConsAddress, jailTime time.Time)
 }
 
 // DistrKeeper defines the expected distribution keeper
 type DistrKeeper interface {
-	AllocateTokensToValidator(ctx sdk.Context, val sdk.ValAddress, tokens sdk.DecCoins)
+	AllocateTokensToValidator(ctx sdk.Context, val stakingtypes.ValidatorI, tokens sdk.DecCoins)
+	GetCommunityTax(ctx sdk.Context) sdk.Dec
+	GetFeePool(ctx sdk.Context) distrtypes.FeePool
+	SetFeePool(ctx sdk.Context, feePool distrtypes.FeePool)
 }
 
 // ProofOfServiceKeeper defines the expected proof of service keeper
@@ -23,17 +46,6 @@ type ProofOfServiceKeeper interface {
 	GetTotalServiceScore(ctx sdk.Context) sdk.Int
 }
 
-// StakingValidator defines the expected validator interface
-type StakingValidator interface {
-	GetOperator() sdk.ValAddress
-	GetConsAddr() sdk.ConsAddress
-}
-
-// SigningInfo defines the expected signing info interface
-type SigningInfo interface {
-	GetMissedBlocksCounter() int64
-}
-
 // NodeRewardsHooks event hooks for node rewards module
 type NodeRewardsHooks interface {
 	AfterNodePerformanceUpdated(ctx sdk.Context, validatorAddr string)
@@ -9,49 +9,37 @@ import (
 // DefaultGenesis returns the default genesis state for the noderewards module.
 func DefaultGenesis() *GenesisState {
 	return &GenesisState{
-		RewardModifier:   DefaultRewardModifier(),
-		NodePerformances: []NodePerformance{},
+		Params:                 DefaultParams(),
+		RewardModifier:         DefaultRewardModifier(),
+		NodePerformances:       []NodePerformance{},
+		SmoothedPerformances:   []SmoothedPerformance{},
+		ValidatorTiers:         []ValidatorTier{},
+		TierHistory:            []ValidatorTier{},
+		PerformanceCheckpoints: []PerformanceCheckpoint{},
+		ModifierNormalizations: []ModifierNormalization{},
 	}
 }
 
 // GenesisState defines the noderewards module's genesis state.
 type GenesisState struct {
-	RewardModifier   RewardModifier    `json:"reward_modifier"`
-	NodePerformances []NodePerformance `json:"node_performances"`
+	Params                 Params                  `json:"params"`
+	RewardModifier         RewardModifier          `json:"reward_modifier"`
+	NodePerformances       []NodePerformance       `json:"node_performances"`
+	SmoothedPerformances   []SmoothedPerformance   `json:"smoothed_performances"`
+	ValidatorTiers         []ValidatorTier         `json:"validator_tiers"`
+	TierHistory            []ValidatorTier         `json:"tier_history"`
+	PerformanceCheckpoints []PerformanceCheckpoint `json:"performance_checkpoints"`
+	ModifierNormalizations []ModifierNormalization `json:"modifier_normalizations"`
 }
 
 // Validate performs basic genesis state validation.
 func (gs GenesisState) Validate() error {
-	// Validate reward modifier parameters
-	if gs.RewardModifier.ServiceScoreWeight.IsNegative() {
-		return fmt.Errorf("service score weight cannot be negative: %s", gs.RewardModifier.ServiceScoreWeight)
+	if err := gs.Params.Validate(); err != nil {
+		return err
 	}
 	
-	if gs.RewardModifier.UptimeWeight.IsNegative() {
-		return fmt.Errorf("uptime weight cannot be negative: %s", gs.RewardModifier.UptimeWeight)
-	}
-	
-	if gs.RewardModifier.ResponseTimeWeight.IsNegative() {
-		return fmt.Errorf("response time weight cannot be negative: %s", gs.RewardModifier.ResponseTimeWeight)
-	}
-	
-	// Ensure weights sum to 1
-	sumWeights := gs.RewardModifier.ServiceScoreWeight.Add(gs.RewardModifier.UptimeWeight).Add(gs.RewardModifier.ResponseTimeWeight)
-	if !sumWeights.Equal(sdk.OneDec()) {
-		return fmt.Errorf("weights must sum to 1, got: %s", sumWeights)
-	}
-	
-	if gs.RewardModifier.MinModifier.IsNegative() {
-		return fmt.Errorf("minimum modifier cannot be negative: %s", gs.RewardModifier.MinModifier)
-	}
-	
-	if gs.RewardModifier.MaxModifier.IsNegative() {
-		return fmt.Errorf("maximum modifier cannot be negative: %s", gs.RewardModifier.MaxModifier)
-	}
-	
-	if gs.RewardModifier.MinModifier.GT(gs.RewardModifier.MaxModifier) {
-		return fmt.Errorf("minimum modifier cannot be greater than maximum modifier: %s > %s", 
-			gs.RewardModifier.MinModifier, gs.RewardModifier.MaxModifier)
+	if err := gs.RewardModifier.Validate(); err != nil {
+		return err
 	}
 	
 	// Validate node performances
@@ -75,5 +63,79 @@ func (gs GenesisState) Validate() error {
 		}
 	}
 	
+	// Validate smoothed performances
+	smoothedAddresses := make(map[string]bool)
+	for _, smoothed := range gs.SmoothedPerformances {
+		if smoothedAddresses[smoothed.ValidatorAddr] {
+			return fmt.Errorf("duplicate smoothed performance for validator address: %s", smoothed.ValidatorAddr)
+		}
+		smoothedAddresses[smoothed.ValidatorAddr] = true
+		
+		if smoothed.ServiceScore.IsNil() || smoothed.ServiceScore.IsNegative() {
+			return fmt.Errorf("smoothed service score cannot be negative: %s", smoothed.ServiceScore)
+		}
+		
+		if smoothed.UptimePercent.IsNil() || smoothed.UptimePercent.IsNegative() || smoothed.UptimePercent.GT(sdk.OneDec()) {
+			return fmt.Errorf("smoothed uptime percent must be between 0 and 1: %s", smoothed.UptimePercent)
+		}
+		
+		if smoothed.ResponseTime.IsNil() || smoothed.ResponseTime.IsNegative() {
+			return fmt.Errorf("smoothed response time cannot be negative: %s", smoothed.ResponseTime)
+		}
+	}
+	
+	// Validate performance tiers
+	tierAddresses := make(map[string]bool)
+	for _, tier := range gs.ValidatorTiers {
+		if tierAddresses[tier.ValidatorAddr] {
+			return fmt.Errorf("duplicate performance tier for validator address: %s", tier.ValidatorAddr)
+		}
+		tierAddresses[tier.ValidatorAddr] = true
+		
+		if err := tier.Validate(); err != nil {
+			return err
+		}
+	}
+	
+	historyEntries := make(map[string]bool)
+	for _, tier := range gs.TierHistory {
+		entry := fmt.Sprintf("%s/%d", tier.ValidatorAddr, tier.EpochNumber)
+		if historyEntries[entry] {
+			return fmt.Errorf("duplicate performance tier history for validator address %s in epoch %d", tier.ValidatorAddr, tier.EpochNumber)
+		}
+		historyEntries[entry] = true
+		
+		if err := tier.Validate(); err != nil {
+			return err
+		}
+	}
+	
+	// Validate performance checkpoints
+	checkpointEntries := make(map[string]bool)
+	for _, checkpoint := range gs.PerformanceCheckpoints {
+		entry := fmt.Sprintf("%s/%d", checkpoint.ValidatorAddr, checkpoint.Height)
+		if checkpointEntries[entry] {
+			return fmt.Errorf("duplicate performance checkpoint for validator address %s at height %d", checkpoint.ValidatorAddr, checkpoint.Height)
+		}
+		checkpointEntries[entry] = true
+		
+		if err := checkpoint.Validate(); err != nil {
+			return err
+		}
+	}
+	
+	// Validate modifier normalizations
+	normalizationHeights := make(map[int64]bool)
+	for _, normalization := range gs.ModifierNormalizations {
+		if normalizationHeights[normalization.Height] {
+			return fmt.Errorf("duplicate modifier normalization at height %d", normalization.Height)
+		}
+		normalizationHeights[normalization.Height] = true
+		
+		if err := normalization.Validate(); err != nil {
+			return err
+		}
+	}
+	
 	return nil
 }
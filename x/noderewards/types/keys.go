@@ -1,5 +1,10 @@
 package types
 
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/address"
+)
+
 const (
 	// ModuleName defines the module name
 	ModuleName = "noderewards"
@@ -23,9 +28,79 @@ var (
 
 	// RewardModifierKey is the key for storing reward modifier parameters
 	RewardModifierKey = []byte{0x02}
+
+	// ParamsKey is the key for storing module parameters
+	ParamsKey = []byte{0x03}
+
+	// ModifierNormalizationKey is the key the latest modifier normalization was
+	// stored under in consensus version 1, see ModifierNormalizationPrefix
+	ModifierNormalizationKey = []byte{0x04}
+
+	// SmoothedPerformancePrefix is the prefix for storing smoothed node performance metrics
+	SmoothedPerformancePrefix = []byte{0x05}
+
+	// ValidatorTierPrefix is the prefix for storing the current performance tier of validators
+	ValidatorTierPrefix = []byte{0x06}
+
+	// TierHistoryPrefix is the prefix for storing the performance tiers of validators per epoch
+	TierHistoryPrefix = []byte{0x07}
+
+	// PerformanceCheckpointPrefix is the prefix for storing performance checkpoints per validator and height
+	PerformanceCheckpointPrefix = []byte{0x08}
+
+	// CheckpointHeightIndexPrefix is the prefix for indexing performance checkpoints by height, used for pruning
+	CheckpointHeightIndexPrefix = []byte{0x09}
+
+	// ModifierNormalizationPrefix is the prefix for storing modifier normalizations by height
+	ModifierNormalizationPrefix = []byte{0x0A}
 )
 
 // GetNodePerformanceKey returns the key for storing node performance metrics
 func GetNodePerformanceKey(validatorAddr string) []byte {
 	return append(NodePerformancePrefix, []byte(validatorAddr)...)
 }
+
+// GetSmoothedPerformanceKey returns the key for storing smoothed node performance metrics
+func GetSmoothedPerformanceKey(validatorAddr string) []byte {
+	return append(SmoothedPerformancePrefix, []byte(validatorAddr)...)
+}
+
+// GetValidatorTierKey returns the key for storing the current performance tier of a validator
+func GetValidatorTierKey(validatorAddr string) []byte {
+	return append(ValidatorTierPrefix, []byte(validatorAddr)...)
+}
+
+// GetTierHistoryPrefix returns the prefix of the performance tiers of a validator, ordered by epoch
+func GetTierHistoryPrefix(validatorAddr string) []byte {
+	return append(TierHistoryPrefix, address.MustLengthPrefix([]byte(validatorAddr))...)
+}
+
+// GetTierHistoryKey returns the key for storing the performance tier of a validator in an epoch
+func GetTierHistoryKey(validatorAddr string, epoch uint64) []byte {
+	return append(GetTierHistoryPrefix(validatorAddr), sdk.Uint64ToBigEndian(epoch)...)
+}
+
+// GetPerformanceCheckpointsPrefix returns the prefix of the performance checkpoints of a validator, ordered by height
+func GetPerformanceCheckpointsPrefix(validatorAddr string) []byte {
+	return append(PerformanceCheckpointPrefix, address.MustLengthPrefix([]byte(validatorAddr))...)
+}
+
+// GetPerformanceCheckpointKey returns the key for storing the performance checkpoint of a validator at a height
+func GetPerformanceCheckpointKey(validatorAddr string, height int64) []byte {
+	return append(GetPerformanceCheckpointsPrefix(validatorAddr), sdk.Uint64ToBigEndian(uint64(height))...)
+}
+
+// GetCheckpointHeightIndexPrefix returns the prefix of the index keys of the checkpoints at a height
+func GetCheckpointHeightIndexPrefix(height int64) []byte {
+	return append(CheckpointHeightIndexPrefix, sdk.Uint64ToBigEndian(uint64(height))...)
+}
+
+// GetCheckpointHeightIndexKey returns the index key of the performance checkpoint of a validator at a height
+func GetCheckpointHeightIndexKey(height int64, validatorAddr string) []byte {
+	return append(GetCheckpointHeightIndexPrefix(height), []byte(validatorAddr)...)
+}
+
+// GetModifierNormalizationKey returns the key for storing the modifier normalization of a height
+func GetModifierNormalizationKey(height int64) []byte {
+	return append(ModifierNormalizationPrefix, sdk.Uint64ToBigEndian(uint64(height))...)
+}
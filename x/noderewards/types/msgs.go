@@ -0,0 +1,115 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+const (
+	TypeMsgUpdateParams         = "update_params"
+	TypeMsgUpdateRewardModifier = "update_reward_modifier"
+)
+
+var (
+	_ sdk.Msg = &MsgUpdateParams{}
+	_ sdk.Msg = &MsgUpdateRewardModifier{}
+)
+
+// MsgUpdateParams defines a governance message for updating the module parameters
+type MsgUpdateParams struct {
+	Authority string `json:"authority"` // Address of the gov module account
+	Params    Params `json:"params"`
+}
+
+// NewMsgUpdateParams creates a new MsgUpdateParams instance
+func NewMsgUpdateParams(authority string, params Params) *MsgUpdateParams {
+	return &MsgUpdateParams{
+		Authority: authority,
+		Params:    params,
+	}
+}
+
+// Route implements sdk.Msg
+func (msg MsgUpdateParams) Route() string {
+	return RouterKey
+}
+
+// Type implements sdk.Msg
+func (msg MsgUpdateParams) Type() string {
+	return TypeMsgUpdateParams
+}
+
+// ValidateBasic implements sdk.Msg
+func (msg MsgUpdateParams) ValidateBasic() error {
+	if _, err := sdk.AccAddressFromBech32(msg.Authority); err != nil {
+		return sdkerrors.Wrapf(sdkerrors.ErrInvalidAddress, "invalid authority address: %s", err)
+	}
+
+	if err := msg.Params.Validate(); err != nil {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, err.Error())
+	}
+
+	return nil
+}
+
+// GetSignBytes implements sdk.Msg
+func (msg MsgUpdateParams) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(&msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// GetSigners implements sdk.Msg
+func (msg MsgUpdateParams) GetSigners() []sdk.AccAddress {
+	addr, _ := sdk.AccAddressFromBech32(msg.Authority)
+	return []sdk.AccAddress{addr}
+}
+
+// MsgUpdateRewardModifier defines a governance message for updating the
+// weights and bounds of the reward modifier
+type MsgUpdateRewardModifier struct {
+	Authority      string         `json:"authority"` // Address of the gov module account
+	RewardModifier RewardModifier `json:"reward_modifier"`
+}
+
+// NewMsgUpdateRewardModifier creates a new MsgUpdateRewardModifier instance
+func NewMsgUpdateRewardModifier(authority string, modifier RewardModifier) *MsgUpdateRewardModifier {
+	return &MsgUpdateRewardModifier{
+		Authority:      authority,
+		RewardModifier: modifier,
+	}
+}
+
+// Route implements sdk.Msg
+func (msg MsgUpdateRewardModifier) Route() string {
+	return RouterKey
+}
+
+// Type implements sdk.Msg
+func (msg MsgUpdateRewardModifier) Type() string {
+	return TypeMsgUpdateRewardModifier
+}
+
+// ValidateBasic implements sdk.Msg
+func (msg MsgUpdateRewardModifier) ValidateBasic() error {
+	if _, err := sdk.AccAddressFromBech32(msg.Authority); err != nil {
+		return sdkerrors.Wrapf(sdkerrors.ErrInvalidAddress, "invalid authority address: %s", err)
+	}
+
+	if err := msg.RewardModifier.Validate(); err != nil {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, err.Error())
+	}
+
+	return nil
+}
+
+// GetSignBytes implements sdk.Msg
+func (msg MsgUpdateRewardModifier) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(&msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// GetSigners implements sdk.Msg
+func (msg MsgUpdateRewardModifier) GetSigners() []sdk.AccAddress {
+	addr, _ := sdk.AccAddressFromBech32(msg.Authority)
+	return []sdk.AccAddress{addr}
+}
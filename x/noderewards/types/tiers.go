@@ -0,0 +1,100 @@
+package types
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// PerformanceTier is a band of performance scores validators are ranked in.
+// A validator is ranked in the first tier whose MinScore its score reaches.
+type PerformanceTier struct {
+	Name     string  `json:"name"`
+	MinScore sdk.Dec `json:"min_score"`
+}
+
+// ValidatorTier is the tier a validator was ranked in at the end of a tier
+// epoch, with the number of consecutive epochs it has spent in the lowest
+// tier. A validator on probation earns no rewards until it leaves the lowest
+// tier.
+type ValidatorTier struct {
+	ValidatorAddr    string  `json:"validator_addr"`
+	EpochNumber      uint64  `json:"epoch_number"`
+	Tier             string  `json:"tier"`
+	Score            sdk.Dec `json:"score"`
+	LowestTierEpochs uint64  `json:"lowest_tier_epochs"`
+	Probation        bool    `json:"probation"`
+}
+
+// DefaultPerformanceTiers returns the default performance tiers, best first
+func DefaultPerformanceTiers() []PerformanceTier {
+	return []PerformanceTier{
+		{Name: "gold", MinScore: sdk.NewDecWithPrec(8, 1)},   // 0.8
+		{Name: "silver", MinScore: sdk.NewDecWithPrec(5, 1)}, // 0.5
+		{Name: "bronze", MinScore: sdk.ZeroDec()},
+	}
+}
+
+// ValidatePerformanceTiers checks that tiers are ordered best first by
+// strictly decreasing scores in [0, 1] and that the lowest tier starts at 0,
+// so that every score is ranked in a tier
+func ValidatePerformanceTiers(tiers []PerformanceTier) error {
+	if len(tiers) == 0 {
+		return fmt.Errorf("at least one performance tier is required")
+	}
+
+	names := make(map[string]bool)
+	for i, tier := range tiers {
+		if tier.Name == "" {
+			return fmt.Errorf("performance tier name cannot be empty")
+		}
+		if names[tier.Name] {
+			return fmt.Errorf("duplicate performance tier: %s", tier.Name)
+		}
+		names[tier.Name] = true
+
+		if tier.MinScore.IsNil() || tier.MinScore.IsNegative() || tier.MinScore.GT(sdk.OneDec()) {
+			return fmt.Errorf("minimum score of performance tier %s must be between 0 and 1: %s", tier.Name, tier.MinScore)
+		}
+		if i > 0 && !tier.MinScore.LT(tiers[i-1].MinScore) {
+			return fmt.Errorf("performance tiers must be ordered by decreasing minimum score: %s after %s", tier.Name, tiers[i-1].Name)
+		}
+	}
+
+	if lowest := tiers[len(tiers)-1]; !lowest.MinScore.IsZero() {
+		return fmt.Errorf("lowest performance tier %s must have a minimum score of 0: %s", lowest.Name, lowest.MinScore)
+	}
+
+	return nil
+}
+
+// Validate performs basic validation of a validator performance tier
+func (t ValidatorTier) Validate() error {
+	if t.ValidatorAddr == "" {
+		return fmt.Errorf("performance tier validator address cannot be empty")
+	}
+	if t.Tier == "" {
+		return fmt.Errorf("performance tier of validator %s cannot be empty", t.ValidatorAddr)
+	}
+	if t.Score.IsNil() || t.Score.IsNegative() {
+		return fmt.Errorf("performance score of validator %s cannot be negative: %s", t.ValidatorAddr, t.Score)
+	}
+
+	return nil
+}
+
+// TierForScore returns the tier a score is ranked in
+func (p Params) TierForScore(score sdk.Dec) PerformanceTier {
+	for _, tier := range p.Tiers {
+		if score.GTE(tier.MinScore) {
+			return tier
+		}
+	}
+
+	return p.LowestTier()
+}
+
+// LowestTier returns the tier validators on the way to probation are ranked in
+func (p Params) LowestTier() PerformanceTier {
+	return p.Tiers[len(p.Tiers)-1]
+}
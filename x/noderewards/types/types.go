@@ -1,7 +1,11 @@
 package types
 
 import (
+	"fmt"
+	"time"
+
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	epochstypes "github.com/serv-chain/serv/x/epochs/types"
 )
 
 // NodePerformance represents performance metrics for a validator node
@@ -22,6 +26,162 @@ type RewardModifier struct {
 	MaxModifier        sdk.Dec `json:"max_modifier"` // Maximum reward modifier (e.g., 2.0 = 200% of base rewards)
 }
 
+// Params represents the noderewards module parameters
+type Params struct {
+	MaxResponseTime    sdk.Int `json:"max_response_time"`   // Response time (ms) at or above which the response time score is zero
+	NormalizeModifiers bool    `json:"normalize_modifiers"` // Rescale modifiers across the active set so payouts equal the base pool
+	SmoothingWindow    uint64  `json:"smoothing_window"`    // Update intervals the moving average spans, 0 or 1 disables smoothing
+	UpdateInterval     uint64  `json:"update_interval"`     // Blocks between periodic updates of the bonded validators' performance, 0 disables them
+	UptimeWindow       uint64  `json:"uptime_window"`       // Most recent blocks uptime is measured over, 0 uses the whole slashing signed blocks window
+
+	TierEpochIdentifier string            `json:"tier_epoch_identifier"` // x/epochs epoch at whose end validators are ranked in tiers
+	Tiers               []PerformanceTier `json:"tiers"`                 // Performance tiers, best first
+	ProbationEpochs     uint64            `json:"probation_epochs"`      // Consecutive epochs in the lowest tier before probation, 0 disables probation
+	JailOnProbation     bool              `json:"jail_on_probation"`     // Jail validators through x/slashing when they are put on probation
+
+	ProbationJailDuration time.Duration `json:"probation_jail_duration"` // Time validators jailed on probation stay jailed before they can unjail
+
+	CheckpointRetention uint64 `json:"checkpoint_retention"` // Blocks performance checkpoints and modifier normalizations are kept for, 0 keeps them forever
+}
+
+// SmoothedPerformance holds the exponential moving averages of the
+// performance metrics of a validator node, updated with every snapshot.
+// Together with the number of samples they are all the state smoothing needs.
+type SmoothedPerformance struct {
+	ValidatorAddr    string  `json:"validator_addr"`
+	ServiceScore     sdk.Dec `json:"service_score"`
+	UptimePercent    sdk.Dec `json:"uptime_percent"`
+	ResponseTime     sdk.Dec `json:"response_time"` // In milliseconds
+	Samples          uint64  `json:"samples"`
+	LastUpdateHeight int64   `json:"last_update_height"`
+}
+
+// ModifierNormalization records the factor the reward modifiers of the active
+// set were rescaled by so that the validator payouts of a block equal the base
+// pool. Normalizations are kept by height for CheckpointRetention blocks.
+type ModifierNormalization struct {
+	Height int64   `json:"height"`
+	Factor sdk.Dec `json:"factor"`
+}
+
+// DefaultParams returns default noderewards module parameters
+func DefaultParams() Params {
+	return Params{
+		MaxResponseTime:    sdk.NewInt(1000), // 1000ms
+		NormalizeModifiers: false,
+		SmoothingWindow:    100, // 100 intervals
+		UpdateInterval:     100, // 100 blocks
+		UptimeWindow:       0,   // whole signed blocks window
+
+		TierEpochIdentifier: epochstypes.DayEpochIdentifier,
+		Tiers:               DefaultPerformanceTiers(),
+		ProbationEpochs:     3, // 3 epochs
+		JailOnProbation:     false,
+
+		ProbationJailDuration: 24 * time.Hour, // 1 day
+
+		CheckpointRetention: 100000, // 100000 blocks
+	}
+}
+
+// SmoothingFactor returns the weight of a new sample in the moving average,
+// 2 / (window + 1) as for an N-period exponential moving average
+func (p Params) SmoothingFactor() sdk.Dec {
+	if p.SmoothingWindow <= 1 {
+		return sdk.OneDec()
+	}
+
+	return sdk.NewDec(2).Quo(sdk.NewDecFromInt(sdk.NewIntFromUint64(p.SmoothingWindow + 1)))
+}
+
+// SampleSmoothingFactor returns the weight of a sample taken elapsed blocks
+// after the previous one. A sample is weighted by the share of UpdateInterval
+// it covers, so that updates on events between the periodic ones do not move
+// the averages faster than one sample per interval.
+func (p Params) SampleSmoothingFactor(elapsed int64) sdk.Dec {
+	alpha := p.SmoothingFactor()
+	if alpha.Equal(sdk.OneDec()) || p.UpdateInterval == 0 || elapsed >= int64(p.UpdateInterval) {
+		return alpha
+	}
+	if elapsed <= 0 {
+		return sdk.ZeroDec()
+	}
+
+	return alpha.MulInt64(elapsed).QuoInt64(int64(p.UpdateInterval))
+}
+
+// NewSmoothedPerformance returns the moving averages of a node that start from a snapshot
+func NewSmoothedPerformance(performance NodePerformance) SmoothedPerformance {
+	return SmoothedPerformance{
+		ValidatorAddr:    performance.ValidatorAddr,
+		ServiceScore:     sdk.NewDecFromInt(performance.ServiceScore),
+		UptimePercent:    performance.UptimePercent,
+		ResponseTime:     sdk.NewDecFromInt(performance.ResponseTime),
+		Samples:          0,
+		LastUpdateHeight: performance.LastUpdateHeight,
+	}
+}
+
+// Add updates the moving averages with a snapshot, weighting it by alpha
+func (s SmoothedPerformance) Add(performance NodePerformance, alpha sdk.Dec) SmoothedPerformance {
+	if s.Samples == 0 {
+		s = NewSmoothedPerformance(performance)
+	} else {
+		s.ServiceScore = ema(s.ServiceScore, sdk.NewDecFromInt(performance.ServiceScore), alpha)
+		s.UptimePercent = ema(s.UptimePercent, performance.UptimePercent, alpha)
+		s.ResponseTime = ema(s.ResponseTime, sdk.NewDecFromInt(performance.ResponseTime), alpha)
+		s.LastUpdateHeight = performance.LastUpdateHeight
+	}
+
+	s.Samples++
+	return s
+}
+
+// ema moves average towards sample by alpha
+func ema(average, sample, alpha sdk.Dec) sdk.Dec {
+	return average.Add(sample.Sub(average).Mul(alpha))
+}
+
+// Validate validates the modifier normalization
+func (n ModifierNormalization) Validate() error {
+	if n.Height <= 0 {
+		return fmt.Errorf("modifier normalization height must be positive: %d", n.Height)
+	}
+	if n.Factor.IsNil() || !n.Factor.IsPositive() {
+		return fmt.Errorf("modifier normalization factor must be positive: %s", n.Factor)
+	}
+	return nil
+}
+
+// DefaultModifierNormalization returns the normalization of a block whose modifiers were not rescaled
+func DefaultModifierNormalization() ModifierNormalization {
+	return ModifierNormalization{
+		Height: 0,
+		Factor: sdk.OneDec(),
+	}
+}
+
+// Validate performs basic validation of the module parameters
+func (p Params) Validate() error {
+	if p.MaxResponseTime.IsNil() || !p.MaxResponseTime.IsPositive() {
+		return fmt.Errorf("max response time must be positive: %s", p.MaxResponseTime)
+	}
+
+	if err := epochstypes.ValidateEpochIdentifierString(p.TierEpochIdentifier); err != nil {
+		return err
+	}
+
+	if err := ValidatePerformanceTiers(p.Tiers); err != nil {
+		return err
+	}
+
+	if p.ProbationJailDuration < 0 {
+		return fmt.Errorf("probation jail duration cannot be negative: %s", p.ProbationJailDuration)
+	}
+
+	return nil
+}
+
 // DefaultRewardModifier returns default parameters for reward modification
 func DefaultRewardModifier() RewardModifier {
 	return RewardModifier{
@@ -33,6 +193,40 @@ func DefaultRewardModifier() RewardModifier {
 	}
 }
 
+// Validate checks that the weights are non-negative and sum to 1 and that the
+// modifier bounds are non-negative with the minimum at most the maximum
+func (m RewardModifier) Validate() error {
+	for _, field := range []struct {
+		name  string
+		value sdk.Dec
+	}{
+		{"service score weight", m.ServiceScoreWeight},
+		{"uptime weight", m.UptimeWeight},
+		{"response time weight", m.ResponseTimeWeight},
+		{"minimum modifier", m.MinModifier},
+		{"maximum modifier", m.MaxModifier},
+	} {
+		if field.value.IsNil() {
+			return fmt.Errorf("%s cannot be empty", field.name)
+		}
+		if field.value.IsNegative() {
+			return fmt.Errorf("%s cannot be negative: %s", field.name, field.value)
+		}
+	}
+
+	// Ensure weights sum to 1
+	sumWeights := m.ServiceScoreWeight.Add(m.UptimeWeight).Add(m.ResponseTimeWeight)
+	if !sumWeights.Equal(sdk.OneDec()) {
+		return fmt.Errorf("weights must sum to 1, got: %s", sumWeights)
+	}
+
+	if m.MinModifier.GT(m.MaxModifier) {
+		return fmt.Errorf("minimum modifier cannot be greater than maximum modifier: %s > %s", m.MinModifier, m.MaxModifier)
+	}
+
+	return nil
+}
+
 // DefaultNodePerformance returns default performance metrics for a validator node
 func DefaultNodePerformance(validatorAddr string) NodePerformance {
 	return NodePerformance{
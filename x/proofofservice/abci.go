@@ -2,7 +2,6 @@ package proofofservice
 
 import (
 	"github.com/serv-chain/serv/x/proofofservice/keeper"
-	"github.com/serv-chain/serv/x/proofofservice/types"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 )
 
@@ -13,18 +12,7 @@ func BeginBlocker(ctx sdk.Context, k keeper.Keeper) {
 
 // EndBlocker is called at the end of every block
 func EndBlocker(ctx sdk.Context, k keeper.Keeper) []sdk.ValidatorUpdate {
-	params := k.GetServiceParams(ctx)
-	
-	// Decay service scores periodically
-	// For example, every 100 blocks (configurable)
-	if uint64(ctx.BlockHeight())%params.ProofValidityPeriod == 0 {
-		k.DecayServiceScores(ctx)
-		
-		// Log score decay
-		ctx.Logger().Info("Service scores decayed", 
-			"decay_rate", params.ScoreDecayRate,
-			"total_service_score", k.GetTotalServiceScore(ctx))
-	}
+	// Service scores decay from the x/epochs AfterEpochEnd hook, see keeper.Hooks
 	
 	// Clean up expired proofs
 	// In a real implementation, we would iterate through proofs and remove those that are expired
@@ -157,7 +157,7 @@ func GetCmdQueryProof() *cobra.Command {
 func GetCmdQueryServiceScore() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "score [address]",
-		Short: "Query the service score for an address",
+		Short: "Query the service score for an account or validator operator address",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			clientCtx, err := client.GetClientQueryContext(cmd)
@@ -44,8 +44,13 @@ func (q Querier) ServiceProvider(c context.Context, req *types.QueryServiceProvi
 		return nil, status.Error(codes.InvalidArgument, "address cannot be empty")
 	}
 
+	addr, err := types.ParseAccountAddress(req.Address)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
 	ctx := sdk.UnwrapSDKContext(c)
-	provider, found := q.Keeper.GetServiceProvider(ctx, req.Address)
+	provider, found := q.Keeper.GetServiceProvider(ctx, addr.String())
 	if !found {
 		return nil, status.Error(codes.NotFound, "service provider not found")
 	}
@@ -87,8 +92,13 @@ func (q Querier) Proof(c context.Context, req *types.QueryProofRequest) (*types.
 		return nil, status.Error(codes.InvalidArgument, "proof ID cannot be empty")
 	}
 
+	provider, err := types.ParseAccountAddress(req.Provider)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
 	ctx := sdk.UnwrapSDKContext(c)
-	proof, found := q.Keeper.GetProof(ctx, req.Provider, req.ProofId)
+	proof, found := q.Keeper.GetProof(ctx, provider.String(), req.ProofId)
 	if !found {
 		return nil, status.Error(codes.NotFound, "proof not found")
 	}
@@ -108,8 +118,13 @@ func (q Querier) ServiceScore(c context.Context, req *types.QueryServiceScoreReq
 		return nil, status.Error(codes.InvalidArgument, "address cannot be empty")
 	}
 
+	addr, err := types.ParseAccountAddress(req.Address)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
 	ctx := sdk.UnwrapSDKContext(c)
-	score := q.Keeper.GetServiceScore(ctx, req.Address)
+	score := q.Keeper.GetServiceScore(ctx, addr.String())
 
 	return &types.QueryServiceScoreResponse{
 		Score: score,
@@ -0,0 +1,36 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	epochstypes "github.com/serv-chain/serv/x/epochs/types"
+)
+
+var _ epochstypes.EpochHooks = Hooks{}
+
+// Hooks wrapper struct for the proofofservice keeper
+type Hooks struct {
+	k Keeper
+}
+
+// Hooks returns the epoch hooks through which proofofservice decays service scores
+func (k Keeper) Hooks() Hooks {
+	return Hooks{k}
+}
+
+// AfterEpochEnd decays all service scores if the ended epoch is the decay epoch
+func (h Hooks) AfterEpochEnd(ctx sdk.Context, epochIdentifier string, epochNumber uint64) {
+	params := h.k.GetServiceParams(ctx)
+	if epochIdentifier != params.DecayEpochIdentifier {
+		return
+	}
+
+	h.k.DecayServiceScores(ctx)
+
+	h.k.Logger(ctx).Info("Service scores decayed",
+		"epoch", epochNumber,
+		"decay_rate", params.ScoreDecayRate,
+		"total_service_score", h.k.GetTotalServiceScore(ctx))
+}
+
+// BeforeEpochStart implements EpochHooks
+func (h Hooks) BeforeEpochStart(ctx sdk.Context, epochIdentifier string, epochNumber uint64) {}
@@ -0,0 +1,24 @@
+package keeper
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/serv-chain/serv/x/proofofservice/types"
+)
+
+// GetValidatorIdentity returns the identity of the validator with the given
+// account or operator address
+func (k Keeper) GetValidatorIdentity(ctx sdk.Context, addr string) (types.ValidatorIdentity, error) {
+	operator, err := types.ParseOperatorAddress(addr)
+	if err != nil {
+		return types.ValidatorIdentity{}, err
+	}
+
+	validator, found := k.stakingKeeper.GetValidator(ctx, operator)
+	if !found {
+		return types.ValidatorIdentity{}, fmt.Errorf("address is not a validator")
+	}
+
+	return types.NewValidatorIdentity(validator)
+}
@@ -20,6 +20,10 @@ type Keeper struct {
 
 	stakingKeeper types.StakingKeeper
 	hooks         types.ProofOfServiceHooks
+
+	// the address capable of executing a MsgUpdateParams message,
+	// typically the x/gov module account
+	authority string
 }
 
 // NewKeeper creates a new proofofservice Keeper instance
@@ -28,7 +32,12 @@ func NewKeeper(
 	storeKey sdk.StoreKey,
 	ps paramtypes.Subspace,
 	stakingKeeper types.StakingKeeper,
+	authority string,
 ) *Keeper {
+	if _, err := sdk.AccAddressFromBech32(authority); err != nil {
+		panic(fmt.Errorf("invalid proofofservice authority address: %w", err))
+	}
+
 	// set KeyTable if it has not already been set
 	if !ps.HasKeyTable() {
 		ps = ps.WithKeyTable(types.ParamKeyTable())
@@ -39,9 +48,15 @@ func NewKeeper(
 		cdc:           cdc,
 		paramstore:    ps,
 		stakingKeeper: stakingKeeper,
+		authority:     authority,
 	}
 }
 
+// GetAuthority returns the module's authority
+func (k Keeper) GetAuthority() string {
+	return k.authority
+}
+
 // Logger returns a module-specific logger.
 func (k Keeper) Logger(ctx sdk.Context) log.Logger {
 	return ctx.Logger().With("module", fmt.Sprintf("x/%s", types.ModuleName))
@@ -209,10 +224,13 @@ func (k Keeper) GetProof(ctx sdk.Context, provider string, proofID string) (type
 func (k Keeper) VerifyProof(ctx sdk.Context, validator string, provider string, proofID string, isVerified bool, score uint64) error {
 	store := ctx.KVStore(k.storeKey)
 	
-	// Check if validator is a valid validator
-	if !k.stakingKeeper.IsValidator(ctx, sdk.MustAccAddressFromBech32(validator)) {
-		return fmt.Errorf("address is not a validator")
+	// Check if validator is a valid validator, given by account or operator
+	// address, and record it by account so that it counts only once
+	identity, err := k.GetValidatorIdentity(ctx, validator)
+	if err != nil {
+		return err
 	}
+	validator = identity.Account.String()
 	
 	// Get the proof
 	proofKey := types.GetServiceProofKey(provider, proofID)
@@ -224,6 +242,11 @@ func (k Keeper) VerifyProof(ctx sdk.Context, validator string, provider string,
 	var proof types.ServiceProof
 	k.cdc.MustUnmarshal(bz, &proof)
 	
+	// Revoked proofs must not earn their score back
+	if proof.Revoked {
+		return fmt.Errorf("proof has been revoked")
+	}
+	
 	// Check if validator has already verified this proof
 	for _, v := range proof.VerifiedBy {
 		if v == validator {
@@ -239,6 +262,7 @@ func (k Keeper) VerifyProof(ctx sdk.Context, validator string, provider string,
 	if uint32(len(proof.VerifiedBy)) >= params.MinVerifications && isVerified {
 		proof.Verified = true
 		proof.Score = sdk.NewIntFromUint64(score)
+		proof.VerifiedAt = ctx.BlockTime()
 		
 		// Update service score
 		k.updateServiceScore(ctx, provider, sdk.NewIntFromUint64(score))
@@ -268,6 +292,58 @@ func (k Keeper) VerifyProof(ctx sdk.Context, validator string, provider string,
 	return nil
 }
 
+// RevokeProof revokes a verified proof found to be fraudulent and removes its
+// score from the provider. Scores decay, so at most the current score of the
+// provider is removed.
+func (k Keeper) RevokeProof(ctx sdk.Context, provider string, proofID string, reason string) error {
+	store := ctx.KVStore(k.storeKey)
+
+	// Get the proof
+	proofKey := types.GetServiceProofKey(provider, proofID)
+	bz := store.Get(proofKey)
+	if bz == nil {
+		return fmt.Errorf("proof not found")
+	}
+
+	var proof types.ServiceProof
+	k.cdc.MustUnmarshal(bz, &proof)
+
+	if !proof.Verified {
+		return fmt.Errorf("proof is not verified")
+	}
+	if proof.Revoked {
+		return fmt.Errorf("proof already revoked")
+	}
+
+	// Remove the score of the proof from the provider
+	providerScore := k.GetServiceScore(ctx, provider)
+	revokedScore := sdk.MinInt(proof.Score, providerScore)
+	k.updateServiceScore(ctx, provider, revokedScore.Neg())
+
+	// Update proof
+	proof.Revoked = true
+	newBz := k.cdc.MustMarshal(&proof)
+	store.Set(proofKey, newBz)
+
+	// Emit event
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			types.EventTypeProofRevoked,
+			sdk.NewAttribute(types.AttributeKeyProvider, provider),
+			sdk.NewAttribute(types.AttributeKeyProofID, proofID),
+			sdk.NewAttribute(types.AttributeKeyScore, revokedScore.String()),
+			sdk.NewAttribute(types.AttributeKeyReason, reason),
+		),
+	)
+
+	// Call hooks if set
+	if k.hooks != nil {
+		k.hooks.AfterProofRevoked(ctx, provider, proofID, revokedScore, providerScore, proof.VerifiedAt)
+	}
+
+	return nil
+}
+
 // updateServiceScore updates the service score for a provider
 func (k Keeper) updateServiceScore(ctx sdk.Context, provider string, additionalScore sdk.Int) {
 	store := ctx.KVStore(k.storeKey)
@@ -346,6 +422,23 @@ func (k Keeper) GetTotalServiceScore(ctx sdk.Context) sdk.Int {
 	return totalScore
 }
 
+// IterateServiceScores iterates over the service scores of all providers
+func (k Keeper) IterateServiceScores(ctx sdk.Context, cb func(provider string, score sdk.Int) (stop bool)) {
+	store := ctx.KVStore(k.storeKey)
+	
+	iterator := sdk.KVStorePrefixIterator(store, types.ServiceScorePrefix)
+	defer iterator.Close()
+	
+	for ; iterator.Valid(); iterator.Next() {
+		var serviceScore types.ServiceScore
+		k.cdc.MustUnmarshal(iterator.Value(), &serviceScore)
+		
+		if cb(serviceScore.Provider, serviceScore.Score) {
+			break
+		}
+	}
+}
+
 // DecayServiceScores decays all service scores based on the decay rate
 func (k Keeper) DecayServiceScores(ctx sdk.Context) {
 	store := ctx.KVStore(k.storeKey)
@@ -5,6 +5,7 @@ import (
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
 	"github.com/serv-chain/serv/x/proofofservice/types"
 )
 
@@ -79,8 +80,8 @@ func (m msgServer) SubmitProof(goCtx context.Context, msg *types.MsgSubmitProof)
 func (m msgServer) VerifyProof(goCtx context.Context, msg *types.MsgVerifyProof) (*types.MsgVerifyProofResponse, error) {
 	ctx := sdk.UnwrapSDKContext(goCtx)
 
-	// Validate the message sender
-	_, err := sdk.AccAddressFromBech32(msg.Validator)
+	// Validate the message sender, given by account or operator address
+	_, err := types.ParseOperatorAddress(msg.Validator)
 	if err != nil {
 		return nil, sdkerrors.Wrapf(sdkerrors.ErrInvalidAddress, "invalid validator address: %s", err)
 	}
@@ -111,3 +112,57 @@ func (m msgServer) VerifyProof(goCtx context.Context, msg *types.MsgVerifyProof)
 
 	return &types.MsgVerifyProofResponse{}, nil
 }
+
+// RevokeProof implements the MsgServer.RevokeProof method.
+func (m msgServer) RevokeProof(goCtx context.Context, msg *types.MsgRevokeProof) (*types.MsgRevokeProofResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	if m.authority != msg.Authority {
+		return nil, sdkerrors.Wrapf(govtypes.ErrInvalidSigner, "invalid authority; expected %s, got %s", m.authority, msg.Authority)
+	}
+
+	// Revoke proof
+	err := m.Keeper.RevokeProof(ctx, msg.Provider, msg.ProofID, msg.Reason)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, err.Error())
+	}
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.ModuleName),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Authority),
+			sdk.NewAttribute(types.AttributeKeyProvider, msg.Provider),
+			sdk.NewAttribute(types.AttributeKeyProofID, msg.ProofID),
+		),
+	})
+
+	return &types.MsgRevokeProofResponse{}, nil
+}
+
+// UpdateParams implements the MsgServer.UpdateParams method.
+func (m msgServer) UpdateParams(goCtx context.Context, msg *types.MsgUpdateParams) (*types.MsgUpdateParamsResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	if m.authority != msg.Authority {
+		return nil, sdkerrors.Wrapf(govtypes.ErrInvalidSigner, "invalid authority; expected %s, got %s", m.authority, msg.Authority)
+	}
+
+	// Validate parameters
+	if err := msg.Params.Validate(); err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, err.Error())
+	}
+
+	// Update parameters
+	m.Keeper.SetServiceParams(ctx, msg.Params)
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.ModuleName),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Authority),
+		),
+	})
+
+	return &types.MsgUpdateParamsResponse{}, nil
+}
@@ -1,13 +1,19 @@
 package test
 
 import (
+	"fmt"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/require"
 	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
 
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+	epochstypes "github.com/serv-chain/serv/x/epochs/types"
 	"github.com/serv-chain/serv/x/proofofservice/keeper"
 	"github.com/serv-chain/serv/x/proofofservice/types"
 )
@@ -37,6 +43,7 @@ func Setup(t *testing.T) (*keeper.Keeper, sdk.Context, *MockStakingKeeper) {
 		storeKey,
 		subspace,
 		stakingKeeper,
+		authtypes.NewModuleAddress(govtypes.ModuleName).String(),
 	)
 
 	// Create test context
@@ -75,6 +82,32 @@ func TestGetServiceParams(t *testing.T) {
 	require.Equal(t, customParams, params)
 }
 
+// TestUpdateParams tests that only the module authority can update service parameters
+func TestUpdateParams(t *testing.T) {
+	k, ctx, _ := Setup(t)
+	msgServer := keeper.NewMsgServer(*k)
+
+	params := types.DefaultServiceParams()
+	params.MinVerifications = 7
+
+	// Any other signer is rejected
+	other := authtypes.NewModuleAddress("other").String()
+	_, err := msgServer.UpdateParams(sdk.WrapSDKContext(ctx), types.NewMsgUpdateParams(other, params))
+	require.ErrorIs(t, err, govtypes.ErrInvalidSigner)
+	require.Equal(t, types.DefaultServiceParams(), k.GetServiceParams(ctx))
+
+	// Invalid params are rejected even from the authority
+	invalid := params
+	invalid.MinVerifications = 0
+	_, err = msgServer.UpdateParams(sdk.WrapSDKContext(ctx), types.NewMsgUpdateParams(k.GetAuthority(), invalid))
+	require.Error(t, err)
+
+	// The gov module account can update the params
+	_, err = msgServer.UpdateParams(sdk.WrapSDKContext(ctx), types.NewMsgUpdateParams(k.GetAuthority(), params))
+	require.NoError(t, err)
+	require.Equal(t, params, k.GetServiceParams(ctx))
+}
+
 // TestRegisterServiceProvider tests the RegisterServiceProvider function
 func TestRegisterServiceProvider(t *testing.T) {
 	k, ctx, _ := Setup(t)
@@ -149,16 +182,16 @@ func TestSubmitProof(t *testing.T) {
 func TestVerifyProof(t *testing.T) {
 	k, ctx, stakingKeeper := Setup(t)
 
-	provider := "cosmos1abcdef"
+	provider := sdk.AccAddress("provider____________").String()
 	serviceType := "storage"
 	metadata := "{\"capacity\":\"1TB\",\"region\":\"us-east\"}"
 	proofID := "proof-123"
 	evidence := "hash-of-evidence-data"
-	validator := "cosmos1validator"
-	validatorAddr, _ := sdk.AccAddressFromBech32(validator)
+	operator := sdk.ValAddress("validator___________")
+	validator := sdk.AccAddress(operator).String()
 
 	// Set up validator
-	stakingKeeper.SetValidator(validatorAddr, true)
+	stakingKeeper.SetValidator(operator)
 
 	// Register provider and submit proof
 	err := k.RegisterServiceProvider(ctx, provider, serviceType, metadata)
@@ -179,15 +212,21 @@ func TestVerifyProof(t *testing.T) {
 	require.False(t, proof.Verified)
 	require.True(t, proof.Score.IsZero())
 
-	// Add more verifications to reach minimum
+	// Add more verifications to reach minimum, from validators signing with their operator address
 	params := k.GetServiceParams(ctx)
 	for i := 1; i < int(params.MinVerifications); i++ {
-		validatorAddr := fmt.Sprintf("cosmos1validator%d", i)
-		stakingKeeper.SetValidator(sdk.MustAccAddressFromBech32(validatorAddr), true)
-		err = k.VerifyProof(ctx, validatorAddr, provider, proofID, true, 80)
+		operator := sdk.ValAddress(fmt.Sprintf("validator_%010d", i))
+		stakingKeeper.SetValidator(operator)
+		err = k.VerifyProof(ctx, operator.String(), provider, proofID, true, 80)
 		require.NoError(t, err)
 	}
 
+	// Validators are recorded by account whichever address they verified with
+	proof, found = k.GetProof(ctx, provider, proofID)
+	require.True(t, found)
+	require.Len(t, proof.VerifiedBy, int(params.MinVerifications))
+	require.Contains(t, proof.VerifiedBy, sdk.AccAddress(sdk.ValAddress(fmt.Sprintf("validator_%010d", 1))).String())
+
 	// Check that proof is now verified
 	proof, found = k.GetProof(ctx, provider, proofID)
 	require.True(t, found)
@@ -198,16 +237,24 @@ func TestVerifyProof(t *testing.T) {
 	score := k.GetServiceScore(ctx, provider)
 	require.Equal(t, sdk.NewIntFromUint64(80), score)
 
-	// Try to verify with the same validator again
+	// Try to verify with the same validator again, by either address
 	err = k.VerifyProof(ctx, validator, provider, proofID, true, 90)
 	require.Error(t, err)
 	require.Contains(t, err.Error(), "validator has already verified this proof")
+	err = k.VerifyProof(ctx, operator.String(), provider, proofID, true, 90)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "validator has already verified this proof")
 
 	// Try to verify with non-validator
-	err = k.VerifyProof(ctx, "cosmos1nonvalidator", provider, proofID, true, 90)
+	err = k.VerifyProof(ctx, sdk.AccAddress("nonvalidator________").String(), provider, proofID, true, 90)
 	require.Error(t, err)
 	require.Contains(t, err.Error(), "address is not a validator")
 
+	// Try to verify with an address that is neither an account nor an operator address
+	err = k.VerifyProof(ctx, sdk.ConsAddress(operator).String(), provider, proofID, true, 90)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "expected an account or operator address")
+
 	// Try to verify non-existent proof
 	err = k.VerifyProof(ctx, validator, provider, "non-existent", true, 90)
 	require.Error(t, err)
@@ -263,3 +310,137 @@ func TestDecayServiceScores(t *testing.T) {
 	expectedTotal := sdk.NewInt(540) // (100 + 200 + 300) * 0.9
 	require.Equal(t, expectedTotal, totalScore)
 }
+
+// TestEpochHooks tests that service scores only decay at the end of the decay epoch
+func TestEpochHooks(t *testing.T) {
+	k, ctx, _ := Setup(t)
+
+	provider := "cosmos1a"
+	require.NoError(t, k.RegisterServiceProvider(ctx, provider, "storage", ""))
+
+	store := ctx.KVStore(k.GetStoreKey())
+	serviceScore := types.ServiceScore{
+		Provider:    provider,
+		Score:       sdk.NewInt(100),
+		LastUpdated: 0,
+	}
+	store.Set(types.GetServiceScoreKey(provider), k.GetCodec().MustMarshal(&serviceScore))
+
+	params := k.GetServiceParams(ctx)
+	params.ScoreDecayRate = sdk.NewDecWithPrec(1, 1) // 0.1
+	params.DecayEpochIdentifier = epochstypes.DayEpochIdentifier
+	k.SetServiceParams(ctx, params)
+
+	// Other epochs are ignored
+	k.Hooks().AfterEpochEnd(ctx, epochstypes.WeekEpochIdentifier, 1)
+	require.Equal(t, sdk.NewInt(100), k.GetServiceScore(ctx, provider))
+
+	k.Hooks().AfterEpochEnd(ctx, epochstypes.DayEpochIdentifier, 1)
+	require.Equal(t, sdk.NewInt(90), k.GetServiceScore(ctx, provider))
+}
+
+// TestRevokeProof tests that only the module authority can revoke a verified proof and that its score is removed
+func TestRevokeProof(t *testing.T) {
+	k, ctx, stakingKeeper := Setup(t)
+	hooks := &MockHooks{}
+	k.SetHooks(hooks)
+	msgServer := keeper.NewMsgServer(*k)
+
+	provider := authtypes.NewModuleAddress("provider").String()
+	validator := authtypes.NewModuleAddress("validator")
+	stakingKeeper.SetValidator(sdk.ValAddress(validator))
+
+	params := k.GetServiceParams(ctx)
+	params.MinVerifications = 1
+	k.SetServiceParams(ctx, params)
+
+	require.NoError(t, k.RegisterServiceProvider(ctx, provider, "storage", ""))
+	require.NoError(t, k.SubmitProof(ctx, provider, "storage", "proof-1", "hash-1"))
+	require.NoError(t, k.SubmitProof(ctx, provider, "storage", "proof-2", "hash-2"))
+	require.NoError(t, k.VerifyProof(ctx, validator.String(), provider, "proof-1", true, 80))
+
+	// Unverified proofs cannot be revoked
+	err := k.RevokeProof(ctx, provider, "proof-2", "fraud")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "proof is not verified")
+
+	// Any other signer is rejected
+	other := authtypes.NewModuleAddress("other").String()
+	_, err = msgServer.RevokeProof(sdk.WrapSDKContext(ctx), types.NewMsgRevokeProof(other, provider, "proof-1", "fraud"))
+	require.ErrorIs(t, err, govtypes.ErrInvalidSigner)
+
+	_, err = msgServer.RevokeProof(sdk.WrapSDKContext(ctx), types.NewMsgRevokeProof(k.GetAuthority(), provider, "proof-1", "fraud"))
+	require.NoError(t, err)
+
+	proof, found := k.GetProof(ctx, provider, "proof-1")
+	require.True(t, found)
+	require.True(t, proof.Revoked)
+	require.True(t, k.GetServiceScore(ctx, provider).IsZero())
+	require.True(t, k.GetTotalServiceScore(ctx).IsZero())
+
+	require.Len(t, hooks.Revoked, 1)
+	require.Equal(t, sdk.NewInt(80), hooks.Revoked[0].Score)
+	require.Equal(t, proof.VerifiedAt, hooks.Revoked[0].VerifiedAt)
+
+	// A proof is only revoked once
+	err = k.RevokeProof(ctx, provider, "proof-1", "fraud")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "proof already revoked")
+
+	// Further validators cannot verify a revoked proof back into the score
+	require.Len(t, hooks.Verified, 1)
+	late := authtypes.NewModuleAddress("late-validator")
+	stakingKeeper.SetValidator(sdk.ValAddress(late))
+	err = k.VerifyProof(ctx, late.String(), provider, "proof-1", true, 80)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "proof has been revoked")
+	require.True(t, k.GetServiceScore(ctx, provider).IsZero())
+	require.Len(t, hooks.Verified, 1)
+}
+
+// TestValidatorIdentity tests that validators resolve from either their account or operator address
+func TestValidatorIdentity(t *testing.T) {
+	k, ctx, stakingKeeper := Setup(t)
+
+	operator := sdk.ValAddress("validator___________")
+	consAddr := stakingKeeper.SetValidator(operator)
+
+	account := sdk.AccAddress(operator)
+	require.Regexp(t, "^cosmos1", account.String())
+	require.Regexp(t, "^cosmosvaloper1", operator.String())
+
+	for _, addr := range []string{account.String(), operator.String()} {
+		identity, err := k.GetValidatorIdentity(ctx, addr)
+		require.NoError(t, err)
+		require.Equal(t, account, identity.Account)
+		require.Equal(t, operator, identity.Operator)
+		require.Equal(t, consAddr, identity.ConsAddr)
+
+		parsed, err := types.ParseAccountAddress(addr)
+		require.NoError(t, err)
+		require.Equal(t, account, parsed)
+	}
+
+	// Consensus addresses are not accepted in place of the operator
+	_, err := types.ParseOperatorAddress(consAddr.String())
+	require.Error(t, err)
+	_, err = k.GetValidatorIdentity(ctx, sdk.AccAddress("nonvalidator________").String())
+	require.Error(t, err)
+
+	// Validators whose consensus key cannot be decoded have no identity
+	broken := sdk.ValAddress("broken_validator____")
+	stakingKeeper.Validators[broken.String()] = stakingtypes.Validator{
+		OperatorAddress: broken.String(),
+		ConsensusPubkey: &codectypes.Any{},
+	}
+	_, err = k.GetValidatorIdentity(ctx, broken.String())
+	require.Error(t, err)
+
+	// Messages accept either form and are signed by the operator account
+	msg := types.NewMsgVerifyProof(operator.String(), sdk.AccAddress("provider____________").String(), "proof-1", true, 80)
+	require.NoError(t, msg.ValidateBasic())
+	require.Equal(t, []sdk.AccAddress{account}, msg.GetSigners())
+	msg.Validator = account.String()
+	require.NoError(t, msg.ValidateBasic())
+	require.Equal(t, []sdk.AccAddress{account}, msg.GetSigners())
+}
@@ -1,8 +1,12 @@
 package test
 
 import (
+	"time"
+
 	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/crypto/keys/ed25519"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
 	"github.com/serv-chain/serv/x/proofofservice/types"
 )
 
@@ -10,24 +14,73 @@ import (
 // used in testing the Proof-of-Service module
 
 type MockStakingKeeper struct {
-	Validators map[string]bool
+	Validators map[string]stakingtypes.Validator
 }
 
 // NewMockStakingKeeper creates a new instance of MockStakingKeeper
 func NewMockStakingKeeper() *MockStakingKeeper {
 	return &MockStakingKeeper{
-		Validators: make(map[string]bool),
+		Validators: make(map[string]stakingtypes.Validator),
+	}
+}
+
+// SetValidator sets a validator with a consensus key derived from its
+// operator address in the mock keeper and returns its consensus address
+func (k *MockStakingKeeper) SetValidator(operator sdk.ValAddress) sdk.ConsAddress {
+	pubKey := ed25519.GenPrivKeyFromSecret(operator).PubKey()
+	validator, err := stakingtypes.NewValidator(operator, pubKey, stakingtypes.Description{})
+	if err != nil {
+		panic(err)
 	}
+	k.Validators[operator.String()] = validator
+	return sdk.ConsAddress(pubKey.Address())
+}
+
+// GetValidator implements the StakingKeeper interface
+func (k *MockStakingKeeper) GetValidator(ctx sdk.Context, addr sdk.ValAddress) (stakingtypes.Validator, bool) {
+	validator, found := k.Validators[addr.String()]
+	return validator, found
+}
+
+// GetValidatorSigningInfo implements the StakingKeeper interface
+func (k *MockStakingKeeper) GetValidatorSigningInfo(ctx sdk.Context, consAddr sdk.ConsAddress) (types.SigningInfo, bool) {
+	return nil, false
+}
+
+// SignedBlocksWindow implements the StakingKeeper interface
+func (k *MockStakingKeeper) SignedBlocksWindow(ctx sdk.Context) int64 {
+	return 100
 }
 
-// SetValidator sets a validator in the mock keeper
-func (k *MockStakingKeeper) SetValidator(addr sdk.AccAddress, isValidator bool) {
-	k.Validators[addr.String()] = isValidator
+// MockHooks records the proofs verified and revoked through the Proof-of-Service hooks
+type MockHooks struct {
+	Verified []types.ServiceProof
+	Revoked  []types.ServiceProof
+}
+
+// AfterServiceProviderRegistered implements ProofOfServiceHooks
+func (h *MockHooks) AfterServiceProviderRegistered(ctx sdk.Context, provider string) {}
+
+// AfterProofSubmitted implements ProofOfServiceHooks
+func (h *MockHooks) AfterProofSubmitted(ctx sdk.Context, provider string, proofID string) {}
+
+// AfterProofVerified implements ProofOfServiceHooks
+func (h *MockHooks) AfterProofVerified(ctx sdk.Context, provider string, proofID string, score sdk.Int) {
+	h.Verified = append(h.Verified, types.ServiceProof{
+		ProofID:  proofID,
+		Provider: provider,
+		Score:    score,
+	})
 }
 
-// IsValidator checks if an address is a validator
-func (k *MockStakingKeeper) IsValidator(addr sdk.AccAddress) bool {
-	return k.Validators[addr.String()]
+// AfterProofRevoked implements ProofOfServiceHooks
+func (h *MockHooks) AfterProofRevoked(ctx sdk.Context, provider string, proofID string, revokedScore, providerScore sdk.Int, verifiedAt time.Time) {
+	h.Revoked = append(h.Revoked, types.ServiceProof{
+		ProofID:    proofID,
+		Provider:   provider,
+		Score:      revokedScore,
+		VerifiedAt: verifiedAt,
+	})
 }
 
 // MockKeeper is a mock for the Proof-of-Service keeper
@@ -1,23 +1,19 @@
 package types
 
 import (
+	"time"
+
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
 )
 
 // StakingKeeper defines the expected staking keeper
 type StakingKeeper interface {
-	IsValidator(ctx sdk.Context, addr sdk.AccAddress) bool
-	GetValidator(ctx sdk.Context, valAddr sdk.AccAddress) (validator StakingValidator, found bool)
+	GetValidator(ctx sdk.Context, addr sdk.ValAddress) (validator stakingtypes.Validator, found bool)
 	GetValidatorSigningInfo(ctx sdk.Context, consAddr sdk.ConsAddress) (SigningInfo, bool)
 	SignedBlocksWindow(ctx sdk.Context) int64
 }
 
-// StakingValidator defines the expected validator interface
-type StakingValidator interface {
-	GetOperator() sdk.ValAddress
-	GetConsAddr() sdk.ConsAddress
-}
-
 // SigningInfo defines the expected signing info interface
 type SigningInfo interface {
 	GetMissedBlocksCounter() int64
@@ -28,4 +24,8 @@ type ProofOfServiceHooks interface {
 	AfterServiceProviderRegistered(ctx sdk.Context, provider string)
 	AfterProofSubmitted(ctx sdk.Context, provider string, proofID string)
 	AfterProofVerified(ctx sdk.Context, provider string, proofID string, score sdk.Int)
+	// AfterProofRevoked is called after a verified proof is revoked. revokedScore
+	// was removed from providerScore, the score of the provider before the
+	// revocation, and had counted since verifiedAt.
+	AfterProofRevoked(ctx sdk.Context, provider string, proofID string, revokedScore, providerScore sdk.Int, verifiedAt time.Time)
 }
@@ -27,20 +27,8 @@ type GenesisState struct {
 // Validate performs basic genesis state validation.
 func (gs GenesisState) Validate() error {
 	// Validate service parameters
-	if gs.ServiceParams.MinVerifications == 0 {
-		return fmt.Errorf("minimum verifications must be positive")
-	}
-	
-	if gs.ServiceParams.ScoreDecayRate.IsNegative() || gs.ServiceParams.ScoreDecayRate.GT(sdk.OneDec()) {
-		return fmt.Errorf("score decay rate must be between 0 and 1: %s", gs.ServiceParams.ScoreDecayRate)
-	}
-	
-	if gs.ServiceParams.ProofValidityPeriod == 0 {
-		return fmt.Errorf("proof validity period must be positive")
-	}
-	
-	if gs.ServiceParams.MaxProofsPerEpoch == 0 {
-		return fmt.Errorf("max proofs per epoch must be positive")
+	if err := gs.ServiceParams.Validate(); err != nil {
+		return err
 	}
 	
 	// Validate service providers
@@ -0,0 +1,60 @@
+package types
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+)
+
+// ValidatorIdentity links the addresses a validator is known by. The account
+// of its operator and its operator address are the same bytes under the
+// account and valoper bech32 prefixes, while its consensus address is only
+// known to the staking keeper.
+type ValidatorIdentity struct {
+	Account  sdk.AccAddress
+	Operator sdk.ValAddress
+	ConsAddr sdk.ConsAddress
+}
+
+// NewValidatorIdentity returns the identity of validator. It fails if the
+// consensus public key of the validator cannot be decoded.
+func NewValidatorIdentity(validator stakingtypes.Validator) (ValidatorIdentity, error) {
+	consAddr, err := validator.GetConsAddr()
+	if err != nil {
+		return ValidatorIdentity{}, fmt.Errorf("invalid consensus key of validator %s: %w", validator.GetOperator(), err)
+	}
+
+	operator := validator.GetOperator()
+	return ValidatorIdentity{
+		Account:  sdk.AccAddress(operator),
+		Operator: operator,
+		ConsAddr: consAddr,
+	}, nil
+}
+
+// ParseOperatorAddress parses the operator address of a validator given
+// either as its valoper bech32 or as the account bech32 of its operator
+func ParseOperatorAddress(addr string) (sdk.ValAddress, error) {
+	if operator, err := sdk.ValAddressFromBech32(addr); err == nil {
+		return operator, nil
+	}
+
+	account, err := sdk.AccAddressFromBech32(addr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid address %s: expected an account or operator address", addr)
+	}
+
+	return sdk.ValAddress(account), nil
+}
+
+// ParseAccountAddress parses an account address given either as its account
+// bech32 or as the valoper bech32 of the validator it operates
+func ParseAccountAddress(addr string) (sdk.AccAddress, error) {
+	operator, err := ParseOperatorAddress(addr)
+	if err != nil {
+		return nil, err
+	}
+
+	return sdk.AccAddress(operator), nil
+}
@@ -9,11 +9,15 @@ const (
 	TypeMsgRegisterService = "register_service"
 	TypeMsgSubmitProof     = "submit_proof"
 	TypeMsgVerifyProof     = "verify_proof"
+	TypeMsgUpdateParams    = "update_params"
+	TypeMsgRevokeProof     = "revoke_proof"
 )
 
 var _ sdk.Msg = &MsgRegisterService{}
 var _ sdk.Msg = &MsgSubmitProof{}
 var _ sdk.Msg = &MsgVerifyProof{}
+var _ sdk.Msg = &MsgUpdateParams{}
+var _ sdk.Msg = &MsgRevokeProof{}
 
 // MsgRegisterService defines a message for registering a service provider
 type MsgRegisterService struct {
@@ -159,7 +163,7 @@ func (msg MsgVerifyProof) Type() string {
 
 // ValidateBasic implements sdk.Msg
 func (msg MsgVerifyProof) ValidateBasic() error {
-	if _, err := sdk.AccAddressFromBech32(msg.Validator); err != nil {
+	if _, err := ParseOperatorAddress(msg.Validator); err != nil {
 		return sdkerrors.Wrapf(sdkerrors.ErrInvalidAddress, "invalid validator address: %s", err)
 	}
 
@@ -186,6 +190,112 @@ func (msg MsgVerifyProof) GetSignBytes() []byte {
 
 // GetSigners implements sdk.Msg
 func (msg MsgVerifyProof) GetSigners() []sdk.AccAddress {
-	addr, _ := sdk.AccAddressFromBech32(msg.Validator)
+	addr, _ := ParseAccountAddress(msg.Validator)
+	return []sdk.AccAddress{addr}
+}
+
+// MsgUpdateParams defines a governance message for updating the service parameters
+type MsgUpdateParams struct {
+	Authority string        `json:"authority"` // Address of the gov module account
+	Params    ServiceParams `json:"params"`
+}
+
+// NewMsgUpdateParams creates a new MsgUpdateParams instance
+func NewMsgUpdateParams(authority string, params ServiceParams) *MsgUpdateParams {
+	return &MsgUpdateParams{
+		Authority: authority,
+		Params:    params,
+	}
+}
+
+// Route implements sdk.Msg
+func (msg MsgUpdateParams) Route() string {
+	return RouterKey
+}
+
+// Type implements sdk.Msg
+func (msg MsgUpdateParams) Type() string {
+	return TypeMsgUpdateParams
+}
+
+// ValidateBasic implements sdk.Msg
+func (msg MsgUpdateParams) ValidateBasic() error {
+	if _, err := sdk.AccAddressFromBech32(msg.Authority); err != nil {
+		return sdkerrors.Wrapf(sdkerrors.ErrInvalidAddress, "invalid authority address: %s", err)
+	}
+
+	if err := msg.Params.Validate(); err != nil {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, err.Error())
+	}
+
+	return nil
+}
+
+// GetSignBytes implements sdk.Msg
+func (msg MsgUpdateParams) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(&msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// GetSigners implements sdk.Msg
+func (msg MsgUpdateParams) GetSigners() []sdk.AccAddress {
+	addr, _ := sdk.AccAddressFromBech32(msg.Authority)
+	return []sdk.AccAddress{addr}
+}
+
+// MsgRevokeProof defines a governance message for revoking a verified proof found to be fraudulent
+type MsgRevokeProof struct {
+	Authority string `json:"authority"` // Address of the gov module account
+	Provider  string `json:"provider"`
+	ProofID   string `json:"proof_id"`
+	Reason    string `json:"reason"`
+}
+
+// NewMsgRevokeProof creates a new MsgRevokeProof instance
+func NewMsgRevokeProof(authority, provider, proofID, reason string) *MsgRevokeProof {
+	return &MsgRevokeProof{
+		Authority: authority,
+		Provider:  provider,
+		ProofID:   proofID,
+		Reason:    reason,
+	}
+}
+
+// Route implements sdk.Msg
+func (msg MsgRevokeProof) Route() string {
+	return RouterKey
+}
+
+// Type implements sdk.Msg
+func (msg MsgRevokeProof) Type() string {
+	return TypeMsgRevokeProof
+}
+
+// ValidateBasic implements sdk.Msg
+func (msg MsgRevokeProof) ValidateBasic() error {
+	if _, err := sdk.AccAddressFromBech32(msg.Authority); err != nil {
+		return sdkerrors.Wrapf(sdkerrors.ErrInvalidAddress, "invalid authority address: %s", err)
+	}
+
+	if _, err := sdk.AccAddressFromBech32(msg.Provider); err != nil {
+		return sdkerrors.Wrapf(sdkerrors.ErrInvalidAddress, "invalid provider address: %s", err)
+	}
+
+	if msg.ProofID == "" {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "proof ID cannot be empty")
+	}
+
+	return nil
+}
+
+// GetSignBytes implements sdk.Msg
+func (msg MsgRevokeProof) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(&msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// GetSigners implements sdk.Msg
+func (msg MsgRevokeProof) GetSigners() []sdk.AccAddress {
+	addr, _ := sdk.AccAddressFromBech32(msg.Authority)
 	return []sdk.AccAddress{addr}
 }
@@ -1,9 +1,11 @@
 package types
 
 import (
+	"fmt"
 	"time"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	epochstypes "github.com/serv-chain/serv/x/epochs/types"
 )
 
 // ServiceProvider represents a registered service provider
@@ -25,6 +27,8 @@ type ServiceProof struct {
 	Verified    bool      `json:"verified"`
 	VerifiedBy  []string  `json:"verified_by"` // List of validators who verified this proof
 	Score       sdk.Int   `json:"score"`       // Score assigned to this proof
+	VerifiedAt  time.Time `json:"verified_at"` // Block time at which the proof reached its minimum verifications
+	Revoked     bool      `json:"revoked"`     // Set when governance revokes the proof, its score no longer counts
 }
 
 // ServiceScore represents the accumulated service score for a provider
@@ -40,6 +44,7 @@ type ServiceParams struct {
 	ScoreDecayRate   sdk.Dec `json:"score_decay_rate"`  // Rate at which scores decay over time
 	ProofValidityPeriod uint64 `json:"proof_validity_period"` // Number of blocks a proof is valid for
 	MaxProofsPerEpoch uint32 `json:"max_proofs_per_epoch"` // Maximum number of proofs a provider can submit per epoch
+	DecayEpochIdentifier string `json:"decay_epoch_identifier"` // x/epochs epoch at whose end scores decay
 }
 
 // DefaultServiceParams returns default parameters for service validation
@@ -49,5 +54,31 @@ func DefaultServiceParams() ServiceParams {
 		ScoreDecayRate:      sdk.NewDecWithPrec(1, 1), // 0.1 (10% decay)
 		ProofValidityPeriod: 100,                      // 100 blocks
 		MaxProofsPerEpoch:   5,
+		DecayEpochIdentifier: epochstypes.DayEpochIdentifier,
 	}
 }
+
+// Validate performs basic validation of service parameters
+func (p ServiceParams) Validate() error {
+	if p.MinVerifications == 0 {
+		return fmt.Errorf("minimum verifications must be positive")
+	}
+
+	if p.ScoreDecayRate.IsNil() || p.ScoreDecayRate.IsNegative() || p.ScoreDecayRate.GT(sdk.OneDec()) {
+		return fmt.Errorf("score decay rate must be between 0 and 1: %s", p.ScoreDecayRate)
+	}
+
+	if p.ProofValidityPeriod == 0 {
+		return fmt.Errorf("proof validity period must be positive")
+	}
+
+	if p.MaxProofsPerEpoch == 0 {
+		return fmt.Errorf("max proofs per epoch must be positive")
+	}
+
+	if err := epochstypes.ValidateEpochIdentifierString(p.DecayEpochIdentifier); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -2,31 +2,23 @@ package servrewards
 
 import (
 	"github.com/serv-chain/serv/x/servrewards/keeper"
-	"github.com/serv-chain/serv/x/servrewards/types"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 )
 
 // BeginBlocker is called at the beginning of every block
 func BeginBlocker(ctx sdk.Context, k keeper.Keeper) {
-	// No specific actions needed at the beginning of the block for servrewards
+	// Divert the fee share before the distribution module sweeps the fee collector
+	if err := k.CollectFeeShare(ctx); err != nil {
+		k.Logger(ctx).Error("failed to collect fee share for reward pool", "err", err)
+	}
 }
 
 // EndBlocker is called at the end of every block
 func EndBlocker(ctx sdk.Context, k keeper.Keeper) []sdk.ValidatorUpdate {
-	// Check if this is the end of an epoch
-	metrics := k.GetRewardMetrics(ctx)
-	params := k.GetRewardParams(ctx)
-	
-	// If we've reached the end of an epoch, update rewards
-	if uint64(ctx.BlockHeight())%params.EpochDuration == 0 {
-		k.UpdateRewards(ctx)
-		
-		// Log epoch completion
-		ctx.Logger().Info("SERV Rewards epoch completed", 
-			"epoch", metrics.EpochNumber,
-			"total_service_score", metrics.TotalServiceScore,
-			"total_staked", metrics.TotalStaked)
-	}
-	
+	// Epoch rewards are allocated from the x/epochs AfterEpochEnd hook, see keeper.Hooks.
+	// Auto-compounding of the allocated rewards is spread over the following blocks.
+	k.ProcessAutoCompound(ctx)
+	// Validators slashed for double signing have been tombstoned by now
+	k.ProcessSlashClawbacks(ctx)
 	return []sdk.ValidatorUpdate{}
 }
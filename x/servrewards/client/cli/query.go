@@ -2,14 +2,23 @@ package cli
 
 import (
 	"fmt"
+	"strconv"
 
 	"github.com/spf13/cobra"
 
 	"github.com/cosmos/cosmos-sdk/client"
 	"github.com/cosmos/cosmos-sdk/client/flags"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/version"
 	"github.com/serv-chain/serv/x/servrewards/types"
 )
 
+// Flags of the simulate query
+const (
+	FlagServiceScore = "service-score"
+	FlagStake        = "stake"
+)
+
 // GetQueryCmd returns the query commands for the servrewards module
 func GetQueryCmd(queryRoute string) *cobra.Command {
 	servRewardsQueryCmd := &cobra.Command{
@@ -24,6 +33,17 @@ func GetQueryCmd(queryRoute string) *cobra.Command {
 		GetCmdQueryRewardMetrics(),
 		GetCmdQueryRewardParams(),
 		GetCmdQueryAccumulatedRewards(),
+		GetCmdQueryRewardPool(),
+		GetCmdQueryEpochRewardRecord(),
+		GetCmdQueryRewardHistory(),
+		GetCmdQueryRewardWithdrawAddress(),
+		GetCmdQueryProviderCommission(),
+		GetCmdQueryDelegatorRewards(),
+		GetCmdQueryClawbackDebt(),
+		GetCmdQueryRewardBreakdown(),
+		GetCmdQuerySimulateRewards(),
+		GetCmdQueryVestingBalances(),
+		GetCmdQueryProjectedEmissions(),
 	)
 
 	return servRewardsQueryCmd
@@ -111,3 +131,366 @@ func GetCmdQueryAccumulatedRewards() *cobra.Command {
 
 	return cmd
 }
+
+// GetCmdQueryRewardPool implements the query reward pool command handler
+func GetCmdQueryRewardPool() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "pool",
+		Short: "Query the SERV reward pool balances",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			queryClient := types.NewQueryClient(clientCtx)
+			res, err := queryClient.RewardPool(cmd.Context(), &types.QueryRewardPoolRequest{})
+			if err != nil {
+				return err
+			}
+
+			return clientCtx.PrintProto(res)
+		},
+	}
+
+	flags.AddQueryFlagsToCmd(cmd)
+
+	return cmd
+}
+
+// GetCmdQueryEpochRewardRecord implements the query epoch reward summary command handler
+func GetCmdQueryEpochRewardRecord() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "epoch-summary [epoch]",
+		Short: "Query the SERV rewards distributed in an epoch",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			epoch, err := strconv.ParseUint(args[0], 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid epoch: %w", err)
+			}
+
+			queryClient := types.NewQueryClient(clientCtx)
+			res, err := queryClient.EpochRewardRecord(cmd.Context(), &types.QueryEpochRewardRecordRequest{
+				EpochNumber: epoch,
+			})
+			if err != nil {
+				return err
+			}
+
+			return clientCtx.PrintProto(res)
+		},
+	}
+
+	flags.AddQueryFlagsToCmd(cmd)
+
+	return cmd
+}
+
+// GetCmdQueryRewardHistory implements the query reward history command handler
+func GetCmdQueryRewardHistory() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "reward-history [address]",
+		Short: "Query the SERV rewards an address earned per epoch",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			pageReq, err := client.ReadPageRequest(cmd.Flags())
+			if err != nil {
+				return err
+			}
+
+			queryClient := types.NewQueryClient(clientCtx)
+			res, err := queryClient.RewardHistory(cmd.Context(), &types.QueryRewardHistoryRequest{
+				Address:    args[0],
+				Pagination: pageReq,
+			})
+			if err != nil {
+				return err
+			}
+
+			return clientCtx.PrintProto(res)
+		},
+	}
+
+	flags.AddQueryFlagsToCmd(cmd)
+	flags.AddPaginationFlagsToCmd(cmd, "reward-history")
+
+	return cmd
+}
+
+// GetCmdQueryRewardWithdrawAddress implements the query reward withdraw address command handler
+func GetCmdQueryRewardWithdrawAddress() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "withdraw-addr [address]",
+		Short: "Query the address the claimed SERV rewards of an address are sent to",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			queryClient := types.NewQueryClient(clientCtx)
+			res, err := queryClient.RewardWithdrawAddress(cmd.Context(), &types.QueryRewardWithdrawAddressRequest{
+				Address: args[0],
+			})
+			if err != nil {
+				return err
+			}
+
+			return clientCtx.PrintProto(res)
+		},
+	}
+
+	flags.AddQueryFlagsToCmd(cmd)
+
+	return cmd
+}
+
+// GetCmdQueryProviderCommission implements the query provider commission command handler
+func GetCmdQueryProviderCommission() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "commission [provider]",
+		Short: "Query the commission a provider keeps of its SERV rewards",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			queryClient := types.NewQueryClient(clientCtx)
+			res, err := queryClient.ProviderCommission(cmd.Context(), &types.QueryProviderCommissionRequest{
+				Provider: args[0],
+			})
+			if err != nil {
+				return err
+			}
+
+			return clientCtx.PrintProto(res)
+		},
+	}
+
+	flags.AddQueryFlagsToCmd(cmd)
+
+	return cmd
+}
+
+// GetCmdQueryClawbackDebt implements the query clawback debt command handler
+func GetCmdQueryClawbackDebt() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "clawback-debt [address]",
+		Short: "Query the clawed back SERV rewards an address still owes",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			queryClient := types.NewQueryClient(clientCtx)
+			res, err := queryClient.ClawbackDebt(cmd.Context(), &types.QueryClawbackDebtRequest{
+				Address: args[0],
+			})
+			if err != nil {
+				return err
+			}
+
+			return clientCtx.PrintProto(res)
+		},
+	}
+
+	flags.AddQueryFlagsToCmd(cmd)
+
+	return cmd
+}
+
+// GetCmdQueryDelegatorRewards implements the query delegator rewards command handler
+func GetCmdQueryDelegatorRewards() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "delegator-rewards [delegator] [provider]",
+		Short: "Query the SERV rewards a provider shares with a delegator of its validator",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			queryClient := types.NewQueryClient(clientCtx)
+			res, err := queryClient.DelegatorRewards(cmd.Context(), &types.QueryDelegatorRewardsRequest{
+				Delegator: args[0],
+				Provider:  args[1],
+			})
+			if err != nil {
+				return err
+			}
+
+			return clientCtx.PrintProto(res)
+		},
+	}
+
+	flags.AddQueryFlagsToCmd(cmd)
+
+	return cmd
+}
+
+// GetCmdQueryRewardBreakdown implements the query reward breakdown command handler
+func GetCmdQueryRewardBreakdown() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "reward-breakdown [address]",
+		Short: "Query how the next epoch SERV reward of an address is made up",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			queryClient := types.NewQueryClient(clientCtx)
+			res, err := queryClient.RewardBreakdown(cmd.Context(), &types.QueryRewardBreakdownRequest{
+				Address: args[0],
+			})
+			if err != nil {
+				return err
+			}
+
+			return clientCtx.PrintProto(res)
+		},
+	}
+
+	flags.AddQueryFlagsToCmd(cmd)
+
+	return cmd
+}
+
+// GetCmdQuerySimulateRewards implements the query simulate rewards command handler
+func GetCmdQuerySimulateRewards() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "simulate-rewards [address]",
+		Short: "Simulate the next epoch SERV reward for a service score and stake",
+		Long: `Simulate the next epoch SERV reward for a service score and stake against
+the current totals. With an address its current score and stake are replaced,
+otherwise the simulated participant is added to the totals.`,
+		Example: fmt.Sprintf("%s query %s simulate-rewards serv1... --%s 500 --%s 1000000", version.AppName, types.ModuleName, FlagServiceScore, FlagStake),
+		Args:    cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			req := &types.QuerySimulateRewardsRequest{}
+			if len(args) > 0 {
+				req.Address = args[0]
+			}
+
+			if s, _ := cmd.Flags().GetString(FlagServiceScore); s != "" {
+				score, ok := sdk.NewIntFromString(s)
+				if !ok {
+					return fmt.Errorf("invalid service score: %s", s)
+				}
+				req.ServiceScore = &score
+			}
+
+			if s, _ := cmd.Flags().GetString(FlagStake); s != "" {
+				stake, ok := sdk.NewIntFromString(s)
+				if !ok {
+					return fmt.Errorf("invalid stake: %s", s)
+				}
+				req.Stake = &stake
+			}
+
+			queryClient := types.NewQueryClient(clientCtx)
+			res, err := queryClient.SimulateRewards(cmd.Context(), req)
+			if err != nil {
+				return err
+			}
+
+			return clientCtx.PrintProto(res)
+		},
+	}
+
+	cmd.Flags().String(FlagServiceScore, "", "Service score to simulate, defaults to the current score of the address")
+	cmd.Flags().String(FlagStake, "", "Stake to simulate, defaults to the current stake of the address")
+	flags.AddQueryFlagsToCmd(cmd)
+
+	return cmd
+}
+
+// GetCmdQueryVestingBalances implements the query vesting balances command handler
+func GetCmdQueryVestingBalances() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "vesting-balances [address]",
+		Short: "Query the locked and unlocked SERV reward balances of an address",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			queryClient := types.NewQueryClient(clientCtx)
+			res, err := queryClient.VestingBalances(cmd.Context(), &types.QueryVestingBalancesRequest{
+				Address: args[0],
+			})
+			if err != nil {
+				return err
+			}
+
+			return clientCtx.PrintProto(res)
+		},
+	}
+
+	flags.AddQueryFlagsToCmd(cmd)
+
+	return cmd
+}
+
+// GetCmdQueryProjectedEmissions implements the query projected emissions command handler
+func GetCmdQueryProjectedEmissions() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "projected-emissions [epochs]",
+		Short: "Project the SERV rewards emitted over the next epochs",
+		Long: `Project the SERV rewards emitted over the next epochs under the current
+emission schedule and lifetime cap. The projection assumes every epoch is
+emitted in full and the bonded supply stays at its current level.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			epochs, err := strconv.ParseUint(args[0], 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid number of epochs: %w", err)
+			}
+
+			queryClient := types.NewQueryClient(clientCtx)
+			res, err := queryClient.ProjectedEmissions(cmd.Context(), &types.QueryProjectedEmissionsRequest{
+				Epochs: epochs,
+			})
+			if err != nil {
+				return err
+			}
+
+			return clientCtx.PrintProto(res)
+		},
+	}
+
+	flags.AddQueryFlagsToCmd(cmd)
+
+	return cmd
+}
@@ -2,7 +2,7 @@ package cli
 
 import (
 	"fmt"
-	"strconv"
+	"os"
 
 	"github.com/spf13/cobra"
 
@@ -10,9 +10,21 @@ import (
 	"github.com/cosmos/cosmos-sdk/client/flags"
 	"github.com/cosmos/cosmos-sdk/client/tx"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/version"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
 	"github.com/serv-chain/serv/x/servrewards/types"
 )
 
+// FlagDisable opts out of auto-compounding
+const FlagDisable = "disable"
+
+// Flags of the set commission command
+const (
+	FlagMaxRate       = "max-rate"
+	FlagMaxChangeRate = "max-change-rate"
+)
+
 // GetTxCmd returns the transaction commands for the servrewards module
 func GetTxCmd() *cobra.Command {
 	servRewardsTxCmd := &cobra.Command{
@@ -25,6 +37,12 @@ func GetTxCmd() *cobra.Command {
 
 	servRewardsTxCmd.AddCommand(
 		NewClaimRewardCmd(),
+		NewClaimAndDelegateCmd(),
+		NewSetAutoCompoundCmd(),
+		NewSetRewardWithdrawAddressCmd(),
+		NewSetProviderCommissionCmd(),
+		NewClaimDelegatorRewardsCmd(),
+		NewReleaseVestedRewardsCmd(),
 		NewUpdateRewardParamsCmd(),
 	)
 
@@ -57,45 +75,306 @@ func NewClaimRewardCmd() *cobra.Command {
 	return cmd
 }
 
-// NewUpdateRewardParamsCmd implements the update reward parameters command handler
-// This would typically be a governance proposal, but we provide a direct command for testing
-func NewUpdateRewardParamsCmd() *cobra.Command {
+// NewClaimAndDelegateCmd implements the claim and delegate command handler
+func NewClaimAndDelegateCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "claim-and-delegate [validator-addr]",
+		Short: "Claim accumulated SERV rewards and delegate them to a validator",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgClaimAndDelegate(clientCtx.GetFromAddress().String(), args[0])
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), msg)
+		},
+	}
+
+	flags.AddTxFlagsToCmd(cmd)
+
+	return cmd
+}
+
+// NewSetAutoCompoundCmd implements the set auto-compound command handler
+func NewSetAutoCompoundCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "set-auto-compound [validator-addr]",
+		Short: "Delegate SERV rewards to a validator at every epoch close",
+		Long: `Delegate SERV rewards to a validator at every epoch close.
+
+Pass --disable instead of a validator address to opt out again.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			disable, err := cmd.Flags().GetBool(FlagDisable)
+			if err != nil {
+				return err
+			}
+
+			validator := ""
+			if len(args) > 0 {
+				validator = args[0]
+			}
+			if !disable && validator == "" {
+				return fmt.Errorf("a validator address is required unless --%s is set", FlagDisable)
+			}
+
+			msg := types.NewMsgSetAutoCompound(clientCtx.GetFromAddress().String(), validator, !disable)
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), msg)
+		},
+	}
+
+	cmd.Flags().Bool(FlagDisable, false, "Opt out of auto-compounding")
+	flags.AddTxFlagsToCmd(cmd)
+
+	return cmd
+}
+
+// NewSetRewardWithdrawAddressCmd implements the set reward withdraw address command handler
+func NewSetRewardWithdrawAddressCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "set-withdraw-addr [withdraw-addr]",
+		Short: "Change the address claimed SERV rewards are sent to",
+		Long: `Change the address claimed SERV rewards are sent to.
+
+Set your own address to send rewards to it again. Module accounts are not
+allowed as withdraw addresses. claim-and-delegate always pays out to your own
+address, as it delegates from there.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgSetRewardWithdrawAddress(clientCtx.GetFromAddress().String(), args[0])
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), msg)
+		},
+	}
+
+	flags.AddTxFlagsToCmd(cmd)
+
+	return cmd
+}
+
+// NewSetProviderCommissionCmd implements the set provider commission command handler
+func NewSetProviderCommissionCmd() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "update-params [service-score-weight] [staking-weight] [reward-per-epoch] [epoch-duration]",
-		Short: "Update SERV reward parameters (governance)",
-		Args:  cobra.ExactArgs(4),
+		Use:   "set-commission [rate]",
+		Short: "Share SERV rewards with the delegators of your validator, keeping a commission",
+		Long: `Share SERV rewards with the delegators of your validator, keeping rate as
+commission. The rest of every reward is split among the delegators by their
+delegation shares.
+
+The first commission requires --max-rate and --max-change-rate, which cannot be
+changed afterwards. The rate can then change once per epoch, by at most the max
+change rate.`,
+		Example: fmt.Sprintf("%s tx %s set-commission 0.1 --%s 0.2 --%s 0.01", version.AppName, types.ModuleName, FlagMaxRate, FlagMaxChangeRate),
+		Args:    cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			clientCtx, err := client.GetClientTxContext(cmd)
 			if err != nil {
 				return err
 			}
 
-			serviceScoreWeight, err := sdk.NewDecFromStr(args[0])
+			rate, err := sdk.NewDecFromStr(args[0])
 			if err != nil {
-				return fmt.Errorf("invalid service score weight: %w", err)
+				return fmt.Errorf("invalid commission rate: %w", err)
+			}
+
+			var maxRate, maxChangeRate *sdk.Dec
+			if s, _ := cmd.Flags().GetString(FlagMaxRate); s != "" {
+				dec, err := sdk.NewDecFromStr(s)
+				if err != nil {
+					return fmt.Errorf("invalid max rate: %w", err)
+				}
+				maxRate = &dec
+			}
+			if s, _ := cmd.Flags().GetString(FlagMaxChangeRate); s != "" {
+				dec, err := sdk.NewDecFromStr(s)
+				if err != nil {
+					return fmt.Errorf("invalid max change rate: %w", err)
+				}
+				maxChangeRate = &dec
+			}
+
+			msg := types.NewMsgSetProviderCommission(clientCtx.GetFromAddress().String(), rate, maxRate, maxChangeRate)
+			if err := msg.ValidateBasic(); err != nil {
+				return err
 			}
 
-			stakingWeight, err := sdk.NewDecFromStr(args[1])
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), msg)
+		},
+	}
+
+	cmd.Flags().String(FlagMaxRate, "", "Highest commission rate you can ever set, required for the first commission")
+	cmd.Flags().String(FlagMaxChangeRate, "", "Largest change of the commission rate per epoch, required for the first commission")
+	flags.AddTxFlagsToCmd(cmd)
+
+	return cmd
+}
+
+// NewClaimDelegatorRewardsCmd implements the claim delegator rewards command handler
+func NewClaimDelegatorRewardsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "claim-delegator-rewards [provider]",
+		Short: "Claim the SERV rewards a provider shares with you as a delegator of its validator",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgClaimDelegatorRewards(clientCtx.GetFromAddress().String(), args[0])
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), msg)
+		},
+	}
+
+	flags.AddTxFlagsToCmd(cmd)
+
+	return cmd
+}
+
+// NewReleaseVestedRewardsCmd implements the release vested rewards command handler
+func NewReleaseVestedRewardsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "release-vested-rewards",
+		Short: "Release the unlocked part of your vesting SERV rewards without claiming new rewards",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
 			if err != nil {
-				return fmt.Errorf("invalid staking weight: %w", err)
+				return err
 			}
 
-			rewardPerEpoch, ok := sdk.NewIntFromString(args[2])
-			if !ok {
-				return fmt.Errorf("invalid reward per epoch: %s", args[2])
+			msg := types.NewMsgReleaseVestedRewards(clientCtx.GetFromAddress().String())
+			if err := msg.ValidateBasic(); err != nil {
+				return err
 			}
 
-			epochDuration, err := strconv.ParseUint(args[3], 10, 64)
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), msg)
+		},
+	}
+
+	flags.AddTxFlagsToCmd(cmd)
+
+	return cmd
+}
+
+// NewUpdateRewardParamsCmd implements the update reward parameters command handler.
+// The message is signed by the gov module account, so it can only be executed
+// as part of an x/gov v1 proposal.
+func NewUpdateRewardParamsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "update-params [params-file]",
+		Short: "Update SERV reward parameters from a JSON file (governance)",
+		Long: `Update SERV reward parameters from a JSON file (governance).
+
+Example params file:
+{
+  "service_score_weight": "0.6",
+  "staking_weight": "0.4",
+  "reward_per_epoch": [
+    {"denom": "serv", "amount": "1000000"},
+    {"denom": "ibc/27394FB092D2ECCD56123C74F36E4C1F926001CEADA9CA97EA622B25F41E5EB2", "amount": "500000"}
+  ],
+  "epoch_identifier": "day",
+  "reward_source": "fee_share",
+  "fee_share": "0.1",
+  "inflation_cap": "0.0001",
+  "mint_denom": "serv",
+  "history_retention_epochs": "365",
+  "emission": {
+    "mode": "halving",
+    "start_epoch": "0",
+    "halving_period_epochs": "1460",
+    "decay_rate": "0",
+    "target_inflation": "0",
+    "epochs_per_year": "365",
+    "lifetime_cap": [{"denom": "serv", "amount": "2920000000"}]
+  },
+  "vesting": {
+    "mode": "periodic",
+    "locked_fraction": "0.5",
+    "duration": "7776000s",
+    "periods": "3"
+  },
+  "auto_compound_gas_budget": "10000000",
+  "unclaimed_expiry_epochs": "365",
+  "expiry_warning_epochs": "30",
+  "expiry_destination": "reward_pool",
+  "reward_curve": "capped",
+  "max_provider_share": "0.05",
+  "slash_clawback_epochs": "7"
+}
+
+epoch_identifier names the x/epochs epoch at whose end rewards are allocated.
+reward_source is one of mint, fee_share, community_pool or module_account.
+Every denom in reward_per_epoch is paid out in the same proportions; with the
+mint source only mint_denom is minted and other denoms must be sent to the
+module account. history_retention_epochs is how many epochs of per-address
+earnings are kept, 0 keeps them forever.
+emission.mode is one of fixed, halving, exponential_decay or target_inflation.
+reward_per_epoch is the emission at start_epoch; target_inflation instead emits
+target_inflation of the bonded supply per year in mint_denom. lifetime_cap
+bounds the total ever emitted per denom.
+vesting.mode is one of none, continuous or periodic. locked_fraction of every
+claim vests over duration and is paid out with later claims as it unlocks.
+auto_compound_gas_budget is the gas spent per block on auto-compounding the
+rewards of opted-in addresses after an epoch closes, 0 disables it.
+Rewards left unclaimed for unclaimed_expiry_epochs epochs are swept to
+expiry_destination, either reward_pool or community_pool; 0 disables expiry.
+reward_curve is one of linear, sqrt, quadratic or capped, or a curve registered
+by the app; capped limits every provider to max_provider_share of the budget.
+A validator slash claws back the slash fraction of what its provider earned in
+the last slash_clawback_epochs epochs; 0 disables it.
+
+The message is only accepted from the x/gov module account. Use --generate-only
+and include the generated message in a proposal submitted with
+"tx gov submit-proposal".`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			bz, err := os.ReadFile(args[0])
 			if err != nil {
-				return fmt.Errorf("invalid epoch duration: %w", err)
+				return fmt.Errorf("failed to read params file: %w", err)
+			}
+
+			var params types.RewardParams
+			if err := clientCtx.Codec.UnmarshalJSON(bz, &params); err != nil {
+				return fmt.Errorf("invalid params file: %w", err)
 			}
 
 			msg := types.NewMsgUpdateRewardParams(
-				clientCtx.GetFromAddress().String(),
-				serviceScoreWeight,
-				stakingWeight,
-				rewardPerEpoch,
-				epochDuration,
+				authtypes.NewModuleAddress(govtypes.ModuleName).String(),
+				params,
 			)
 			if err := msg.ValidateBasic(); err != nil {
 				return err
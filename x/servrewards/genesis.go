@@ -14,25 +14,155 @@ func InitGenesis(ctx sdk.Context, k keeper.Keeper, genState types.GenesisState)
 	// Set reward parameters
 	k.SetRewardParams(ctx, genState.RewardParams)
 	
+	// Set reward pool
+	k.SetRewardPool(ctx, genState.RewardPool)
+	
+	// Set emission state
+	k.SetEmissionState(ctx, genState.EmissionState)
+	
 	// Set accumulated rewards
 	for _, reward := range genState.AccumulatedRewards {
 		k.SetAccumulatedRewards(ctx, reward)
 	}
+	
+	// Set vesting rewards
+	for _, vesting := range genState.VestingRewards {
+		k.SetVestingRewards(ctx, vesting)
+	}
+	
+	// Set auto-compound settings
+	for _, setting := range genState.AutoCompound {
+		k.SetAutoCompound(ctx, setting)
+	}
+	
+	// Set withdraw addresses
+	for _, withdraw := range genState.WithdrawAddresses {
+		if err := k.SetRewardWithdrawAddress(ctx, sdk.MustAccAddressFromBech32(withdraw.Address), sdk.MustAccAddressFromBech32(withdraw.WithdrawAddress)); err != nil {
+			panic(err)
+		}
+	}
+	
+	// Set provider commissions and the rewards shared with delegators
+	for _, commission := range genState.ProviderCommissions {
+		k.SetProviderCommission(ctx, commission)
+	}
+	for _, pool := range genState.DelegatorRewardsPools {
+		k.SetDelegatorRewardsPool(ctx, pool)
+	}
+	for _, ratio := range genState.DelegatorStartingRatios {
+		k.SetDelegatorStartingRatio(ctx, ratio)
+	}
+	
+	// Set clawbacks
+	for _, record := range genState.ClawbackRecords {
+		k.SetClawbackRecord(ctx, record)
+	}
+	for _, debt := range genState.ClawbackDebts {
+		k.SetClawbackDebt(ctx, debt)
+	}
+	
+	// Set reward history
+	for _, record := range genState.EpochRewardRecords {
+		if err := k.SetEpochRewardRecord(ctx, record); err != nil {
+			panic(err)
+		}
+	}
+	for _, earning := range genState.AddressEpochRewards {
+		k.SetAddressEpochReward(ctx, earning)
+	}
 }
 
 // ExportGenesis returns the servrewards module's exported genesis.
 func ExportGenesis(ctx sdk.Context, k keeper.Keeper) *types.GenesisState {
 	rewardMetrics := k.GetRewardMetrics(ctx)
 	rewardParams := k.GetRewardParams(ctx)
+	rewardPool := k.GetRewardPool(ctx)
+	emissionState := k.GetEmissionState(ctx)
 	
-	// Get all accumulated rewards
-	// In a real implementation, we would iterate over all accumulated rewards in the store
-	// For now, we'll return an empty list
 	accumulatedRewards := []types.AccumulatedRewards{}
+	k.IterateAccumulatedRewards(ctx, func(rewards types.AccumulatedRewards) bool {
+		accumulatedRewards = append(accumulatedRewards, rewards)
+		return false
+	})
+	
+	vestingRewards := []types.VestingRewards{}
+	k.IterateVestingRewards(ctx, func(vesting types.VestingRewards) bool {
+		vestingRewards = append(vestingRewards, vesting)
+		return false
+	})
+	
+	autoCompound := []types.AutoCompoundSetting{}
+	k.IterateAutoCompound(ctx, func(setting types.AutoCompoundSetting) bool {
+		autoCompound = append(autoCompound, setting)
+		return false
+	})
+	
+	withdrawAddresses := []types.RewardWithdrawAddress{}
+	k.IterateRewardWithdrawAddresses(ctx, func(addr, withdrawAddr sdk.AccAddress) bool {
+		withdrawAddresses = append(withdrawAddresses, types.RewardWithdrawAddress{
+			Address:         addr.String(),
+			WithdrawAddress: withdrawAddr.String(),
+		})
+		return false
+	})
+	
+	providerCommissions := []types.ProviderCommission{}
+	k.IterateProviderCommissions(ctx, func(commission types.ProviderCommission) bool {
+		providerCommissions = append(providerCommissions, commission)
+		return false
+	})
+	
+	delegatorRewardsPools := []types.DelegatorRewardsPool{}
+	k.IterateDelegatorRewardsPools(ctx, func(pool types.DelegatorRewardsPool) bool {
+		delegatorRewardsPools = append(delegatorRewardsPools, pool)
+		return false
+	})
+	
+	delegatorStartingRatios := []types.DelegatorStartingRatio{}
+	k.IterateDelegatorStartingRatios(ctx, func(ratio types.DelegatorStartingRatio) bool {
+		delegatorStartingRatios = append(delegatorStartingRatios, ratio)
+		return false
+	})
+	
+	clawbackRecords := []types.ClawbackRecord{}
+	k.IterateClawbackRecords(ctx, func(record types.ClawbackRecord) bool {
+		clawbackRecords = append(clawbackRecords, record)
+		return false
+	})
+	
+	clawbackDebts := []types.ClawbackDebt{}
+	k.IterateClawbackDebts(ctx, func(debt types.ClawbackDebt) bool {
+		clawbackDebts = append(clawbackDebts, debt)
+		return false
+	})
+	
+	epochRewardRecords := []types.EpochRewardRecord{}
+	k.IterateEpochRewardRecords(ctx, func(record types.EpochRewardRecord) bool {
+		epochRewardRecords = append(epochRewardRecords, record)
+		return false
+	})
+	
+	addressEpochRewards := []types.AddressEpochReward{}
+	k.IterateAllAddressEpochRewards(ctx, func(earning types.AddressEpochReward) bool {
+		addressEpochRewards = append(addressEpochRewards, earning)
+		return false
+	})
 	
 	return &types.GenesisState{
-		RewardMetrics:      rewardMetrics,
-		RewardParams:       rewardParams,
-		AccumulatedRewards: accumulatedRewards,
+		RewardMetrics:       rewardMetrics,
+		RewardParams:        rewardParams,
+		RewardPool:          rewardPool,
+		AccumulatedRewards:  accumulatedRewards,
+		EpochRewardRecords:  epochRewardRecords,
+		AddressEpochRewards: addressEpochRewards,
+		EmissionState:       emissionState,
+		VestingRewards:      vestingRewards,
+		AutoCompound:        autoCompound,
+		WithdrawAddresses:   withdrawAddresses,
+		ProviderCommissions:     providerCommissions,
+		DelegatorRewardsPools:   delegatorRewardsPools,
+		DelegatorStartingRatios: delegatorStartingRatios,
+		ClawbackRecords:         clawbackRecords,
+		ClawbackDebts:           clawbackDebts,
 	}
 }
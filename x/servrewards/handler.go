@@ -23,6 +23,24 @@ func NewHandler(k keeper.Keeper) sdk.Handler {
 		case *types.MsgUpdateRewardParams:
 			res, err := msgServer.UpdateRewardParams(sdk.WrapSDKContext(ctx), msg)
 			return sdk.WrapServiceResult(ctx, res, err)
+		case *types.MsgClaimAndDelegate:
+			res, err := msgServer.ClaimAndDelegate(sdk.WrapSDKContext(ctx), msg)
+			return sdk.WrapServiceResult(ctx, res, err)
+		case *types.MsgSetAutoCompound:
+			res, err := msgServer.SetAutoCompound(sdk.WrapSDKContext(ctx), msg)
+			return sdk.WrapServiceResult(ctx, res, err)
+		case *types.MsgSetRewardWithdrawAddress:
+			res, err := msgServer.SetRewardWithdrawAddress(sdk.WrapSDKContext(ctx), msg)
+			return sdk.WrapServiceResult(ctx, res, err)
+		case *types.MsgSetProviderCommission:
+			res, err := msgServer.SetProviderCommission(sdk.WrapSDKContext(ctx), msg)
+			return sdk.WrapServiceResult(ctx, res, err)
+		case *types.MsgClaimDelegatorRewards:
+			res, err := msgServer.ClaimDelegatorRewards(sdk.WrapSDKContext(ctx), msg)
+			return sdk.WrapServiceResult(ctx, res, err)
+		case *types.MsgReleaseVestedRewards:
+			res, err := msgServer.ReleaseVestedRewards(sdk.WrapSDKContext(ctx), msg)
+			return sdk.WrapServiceResult(ctx, res, err)
 		default:
 			errMsg := fmt.Sprintf("unrecognized %s message type: %T", types.ModuleName, msg)
 			return nil, sdkerrors.Wrap(sdkerrors.ErrUnknownRequest, errMsg)
@@ -0,0 +1,106 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/serv-chain/serv/x/servrewards/types"
+)
+
+// nextEpochBudget returns the budget of the next epoch, assuming its emission
+// is funded in full: the emission plus the remainder carried over
+func (k Keeper) nextEpochBudget(ctx sdk.Context) sdk.Coins {
+	budget := k.EpochEmission(ctx, k.GetRewardMetrics(ctx).EpochNumber+1)
+	return budget.Add(k.GetRewardPool(ctx).Remainder...)
+}
+
+// rewardBreakdown breaks down the share of budget earned by an address with
+// the given score and stake against the given totals. The reward is the share
+// the active reward curve gives the address among the current participants,
+// with the address taking part with score and stake.
+func (k Keeper) rewardBreakdown(ctx sdk.Context, addr string, budget sdk.Coins, score, stake, totalScore, totalStaked sdk.Int) types.RewardBreakdown {
+	params := k.GetRewardParams(ctx)
+	breakdown := types.NewRewardBreakdown(params, addr, budget, score, totalScore, stake, totalStaked)
+
+	participants := k.participants(ctx)
+	index := -1
+	for i, p := range participants {
+		if addr != "" && p.Address == addr {
+			index = i
+			break
+		}
+	}
+	if index < 0 {
+		participants = append(participants, types.Participant{})
+		index = len(participants) - 1
+	}
+	participants[index] = types.Participant{Address: addr, ServiceScore: score, Stake: stake}
+
+	curve := k.rewardCurve(ctx, params)
+	share := curve.Shares(params, participants, totalScore, totalStaked)[index]
+	breakdown.Reward = types.ShareOfBudget(budget, share)
+
+	if curve.Name() != types.RewardCurveLinear {
+		factor := sdk.ZeroDec()
+		linear := params.ServiceScoreWeight.Mul(breakdown.ServiceShare).Add(params.StakingWeight.Mul(breakdown.StakeShare))
+		if linear.IsPositive() {
+			factor = share.Quo(linear)
+		}
+
+		breakdown.Modifiers = append(breakdown.Modifiers, types.RewardModifier{
+			Name:        types.ModifierRewardCurve,
+			Factor:      factor,
+			Description: "share under the " + curve.Name() + " reward curve relative to the linear share",
+		})
+	}
+
+	if commission, found := k.GetProviderCommission(ctx, addr); found {
+		breakdown.Modifiers = append(breakdown.Modifiers, types.RewardModifier{
+			Name:        types.ModifierCommission,
+			Factor:      commission.Rate,
+			Description: "fraction of the reward kept by the provider, the rest is shared with the delegators of its validator",
+		})
+	}
+
+	return breakdown
+}
+
+// RewardBreakdown breaks down the reward an address can expect at the end of
+// the next epoch, given the current scores, stakes and emission schedule
+func (k Keeper) RewardBreakdown(ctx sdk.Context, addr string) types.RewardBreakdown {
+	budget := k.nextEpochBudget(ctx)
+	totalScore := k.posKeeper.GetTotalServiceScore(ctx)
+	totalStaked := k.stakingKeeper.GetTotalBondedTokens(ctx)
+
+	score := k.posKeeper.GetServiceScore(ctx, addr)
+	stake := k.stakingKeeper.GetDelegatorStake(ctx, sdk.MustAccAddressFromBech32(addr))
+
+	return k.rewardBreakdown(ctx, addr, budget, score, stake, totalScore, totalStaked)
+}
+
+// SimulateRewards breaks down the reward of the next epoch for a hypothetical
+// service score and stake. When addr is set its current score and stake are
+// replaced in the totals, otherwise they are added as a new participant. A
+// nil score or stake keeps the current value of addr.
+func (k Keeper) SimulateRewards(ctx sdk.Context, addr string, score, stake *sdk.Int) types.RewardBreakdown {
+	budget := k.nextEpochBudget(ctx)
+	totalScore := k.posKeeper.GetTotalServiceScore(ctx)
+	totalStaked := k.stakingKeeper.GetTotalBondedTokens(ctx)
+
+	currentScore, currentStake := sdk.ZeroInt(), sdk.ZeroInt()
+	if addr != "" {
+		currentScore = k.posKeeper.GetServiceScore(ctx, addr)
+		currentStake = k.stakingKeeper.GetDelegatorStake(ctx, sdk.MustAccAddressFromBech32(addr))
+	}
+
+	simScore, simStake := currentScore, currentStake
+	if score != nil {
+		simScore = *score
+	}
+	if stake != nil {
+		simStake = *stake
+	}
+
+	totalScore = totalScore.Sub(currentScore).Add(simScore)
+	totalStaked = totalStaked.Sub(currentStake).Add(simStake)
+
+	return k.rewardBreakdown(ctx, addr, budget, simScore, simStake, totalScore, totalStaked)
+}
@@ -0,0 +1,312 @@
+package keeper
+
+import (
+	"fmt"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/serv-chain/serv/x/servrewards/types"
+)
+
+// GetClawbackRecord returns the part of the earnings of an address in an epoch clawed back so far
+func (k Keeper) GetClawbackRecord(ctx sdk.Context, epoch uint64, addr string) types.ClawbackRecord {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(types.GetClawbackRecordKey(epoch, addr))
+	if bz == nil {
+		return types.ClawbackRecord{
+			Address:     addr,
+			EpochNumber: epoch,
+			Amount:      sdk.NewCoins(),
+		}
+	}
+
+	var record types.ClawbackRecord
+	k.cdc.MustUnmarshal(bz, &record)
+	return record
+}
+
+// SetClawbackRecord sets the part of the earnings of an address in an epoch clawed back so far
+func (k Keeper) SetClawbackRecord(ctx sdk.Context, record types.ClawbackRecord) {
+	store := ctx.KVStore(k.storeKey)
+	bz := k.cdc.MustMarshal(&record)
+	store.Set(types.GetClawbackRecordKey(record.EpochNumber, record.Address), bz)
+}
+
+// IterateClawbackRecords iterates over all clawback records ordered by epoch
+func (k Keeper) IterateClawbackRecords(ctx sdk.Context, cb func(record types.ClawbackRecord) (stop bool)) {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, types.ClawbackRecordPrefix)
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		var record types.ClawbackRecord
+		k.cdc.MustUnmarshal(iterator.Value(), &record)
+		if cb(record) {
+			break
+		}
+	}
+}
+
+// GetClawbackDebt returns the clawed back rewards an address still owes
+func (k Keeper) GetClawbackDebt(ctx sdk.Context, addr string) types.ClawbackDebt {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(types.GetClawbackDebtKey(addr))
+	if bz == nil {
+		return types.ClawbackDebt{
+			Address: addr,
+			Amount:  sdk.NewCoins(),
+		}
+	}
+
+	var debt types.ClawbackDebt
+	k.cdc.MustUnmarshal(bz, &debt)
+	return debt
+}
+
+// SetClawbackDebt sets the clawed back rewards an address still owes, removing settled debts
+func (k Keeper) SetClawbackDebt(ctx sdk.Context, debt types.ClawbackDebt) {
+	store := ctx.KVStore(k.storeKey)
+	if debt.Amount.IsZero() {
+		store.Delete(types.GetClawbackDebtKey(debt.Address))
+		return
+	}
+
+	bz := k.cdc.MustMarshal(&debt)
+	store.Set(types.GetClawbackDebtKey(debt.Address), bz)
+}
+
+// IterateClawbackDebts iterates over the clawback debts of all addresses
+func (k Keeper) IterateClawbackDebts(ctx sdk.Context, cb func(debt types.ClawbackDebt) (stop bool)) {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, types.ClawbackDebtPrefix)
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		var debt types.ClawbackDebt
+		k.cdc.MustUnmarshal(iterator.Value(), &debt)
+		if cb(debt) {
+			break
+		}
+	}
+}
+
+// Clawback claws back fraction of what addr earned in every epoch from
+// fromEpoch on, as recorded in its earnings ledger. Earnings pruned from the
+// ledger can no longer be clawed back, and no epoch is clawed back beyond what
+// was earned in it. The clawed back rewards are taken from the unclaimed
+// rewards of addr and return to the pool with the remainder; what exceeds
+// them is recorded as debt and repaid out of future rewards. It returns the
+// total clawed back.
+func (k Keeper) Clawback(ctx sdk.Context, addr string, fromEpoch uint64, fraction sdk.Dec, reason string) (sdk.Coins, error) {
+	if err := types.ValidateClawbackFraction(fraction); err != nil {
+		return sdk.NewCoins(), err
+	}
+
+	var earnings []types.AddressEpochReward
+	iterator := k.addressEpochRewardsStore(ctx, addr).Iterator(sdk.Uint64ToBigEndian(fromEpoch), nil)
+	for ; iterator.Valid(); iterator.Next() {
+		var earning types.AddressEpochReward
+		k.cdc.MustUnmarshal(iterator.Value(), &earning)
+		earnings = append(earnings, earning)
+	}
+	iterator.Close()
+
+	clawedBack := sdk.NewCoins()
+	for _, earning := range earnings {
+		record := k.GetClawbackRecord(ctx, earning.EpochNumber, addr)
+		amount := types.ShareOfBudget(earning.Amount, fraction).Min(earning.Amount.Sub(record.Amount...))
+		if amount.IsZero() {
+			continue
+		}
+
+		record.Amount = record.Amount.Add(amount...)
+		k.SetClawbackRecord(ctx, record)
+		clawedBack = clawedBack.Add(amount...)
+	}
+
+	if clawedBack.IsZero() {
+		return clawedBack, nil
+	}
+
+	// Take what is still unclaimed, the rest becomes debt
+	rewards := k.GetAccumulatedRewards(ctx, addr)
+	taken := clawedBack.Min(rewards.Rewards)
+	if !taken.IsZero() {
+		rewards.Rewards = rewards.Rewards.Sub(taken...)
+		k.SetAccumulatedRewards(ctx, rewards)
+
+		// Clawed back rewards were already emitted, so they are redistributed with the remainder
+		pool := k.GetRewardPool(ctx)
+		k.releaseOutstanding(ctx, &pool, taken)
+		pool.Available = pool.Available.Add(taken...)
+		pool.Remainder = pool.Remainder.Add(taken...)
+		k.SetRewardPool(ctx, pool)
+	}
+
+	debt := k.GetClawbackDebt(ctx, addr)
+	debt.Amount = debt.Amount.Add(clawedBack.Sub(taken...)...)
+	k.SetClawbackDebt(ctx, debt)
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			types.EventTypeRewardsClawedBack,
+			sdk.NewAttribute(types.AttributeKeyAddress, addr),
+			sdk.NewAttribute(types.AttributeKeyEpoch, fmt.Sprintf("%d", fromEpoch)),
+			sdk.NewAttribute(types.AttributeKeyFraction, fraction.String()),
+			sdk.NewAttribute(types.AttributeKeyReason, reason),
+			sdk.NewAttribute(types.AttributeKeyAmount, clawedBack.String()),
+			sdk.NewAttribute(types.AttributeKeyDebt, debt.Amount.String()),
+		),
+	)
+
+	return clawedBack, nil
+}
+
+// repayClawbackDebt repays the clawback debt of addr out of reward and
+// returns the amount repaid
+func (k Keeper) repayClawbackDebt(ctx sdk.Context, addr string, reward sdk.Coins) sdk.Coins {
+	debt := k.GetClawbackDebt(ctx, addr)
+	repaid := debt.Amount.Min(reward)
+	if repaid.IsZero() {
+		return repaid
+	}
+
+	debt.Amount = debt.Amount.Sub(repaid...)
+	k.SetClawbackDebt(ctx, debt)
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			types.EventTypeClawbackDebtRepaid,
+			sdk.NewAttribute(types.AttributeKeyAddress, addr),
+			sdk.NewAttribute(types.AttributeKeyAmount, repaid.String()),
+			sdk.NewAttribute(types.AttributeKeyDebt, debt.Amount.String()),
+		),
+	)
+
+	return repaid
+}
+
+// clawbackRevokedScore claws back the service part of what a provider earned
+// since a revoked proof was verified, in proportion to the revoked score
+// against the score of the provider before the revocation
+func (k Keeper) clawbackRevokedScore(ctx sdk.Context, provider string, revokedScore, providerScore sdk.Int, verifiedAt time.Time) error {
+	if !revokedScore.IsPositive() || !providerScore.IsPositive() {
+		return nil
+	}
+
+	// Nothing was earned with the proof before the first epoch settled after it
+	fromEpoch, found := k.firstEpochSettledSince(ctx, verifiedAt)
+	if !found {
+		return nil
+	}
+
+	params := k.GetRewardParams(ctx)
+	fraction := sdk.MinDec(sdk.NewDecFromInt(revokedScore).QuoInt(providerScore), sdk.OneDec()).Mul(params.ServiceScoreWeight)
+	if !fraction.IsPositive() {
+		return nil
+	}
+
+	_, err := k.Clawback(ctx, provider, fromEpoch, fraction, types.ClawbackReasonProofRevoked)
+	return err
+}
+
+// firstEpochSettledSince returns the first epoch settled at or after t
+func (k Keeper) firstEpochSettledSince(ctx sdk.Context, t time.Time) (uint64, bool) {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStoreReversePrefixIterator(store, types.EpochRewardRecordPrefix)
+	defer iterator.Close()
+
+	// Walk back from the latest epoch, recent proofs are the common case
+	epoch, found := uint64(0), false
+	for ; iterator.Valid(); iterator.Next() {
+		var record types.EpochRewardRecord
+		k.cdc.MustUnmarshal(iterator.Value(), &record)
+		if record.Time.Before(t) {
+			break
+		}
+		epoch, found = record.EpochNumber, true
+	}
+
+	return epoch, found
+}
+
+// slashClawbackStartEpoch returns the first epoch whose earnings a validator
+// slash claws back, or false if slashes do not claw back rewards
+func (k Keeper) slashClawbackStartEpoch(ctx sdk.Context) (uint64, bool) {
+	params := k.GetRewardParams(ctx)
+	if params.SlashClawbackEpochs == 0 {
+		return 0, false
+	}
+
+	epoch := k.GetRewardMetrics(ctx).EpochNumber
+	if epoch < params.SlashClawbackEpochs {
+		return 0, true
+	}
+
+	return epoch - params.SlashClawbackEpochs + 1, true
+}
+
+// markSlashedValidator records that a validator has been slashed in the
+// current block, to be settled by ProcessSlashClawbacks
+func (k Keeper) markSlashedValidator(ctx sdk.Context, valAddr sdk.ValAddress) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.GetSlashedValidatorKey(valAddr), []byte{})
+}
+
+// ProcessSlashClawbacks claws back rewards from the providers of the
+// validators slashed in the current block for double signing. Slash hooks are
+// not told the infraction, so slashes are settled at the end of the block:
+// x/evidence tombstones a validator right after slashing it for double
+// signing, while downtime slashes leave it untombstoned and claw nothing back.
+// Double sign slashes claw back at the double sign slash fraction.
+func (k Keeper) ProcessSlashClawbacks(ctx sdk.Context) {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, types.SlashedValidatorPrefix)
+
+	var slashed []sdk.ValAddress
+	for ; iterator.Valid(); iterator.Next() {
+		slashed = append(slashed, sdk.ValAddress(iterator.Key()[len(types.SlashedValidatorPrefix):]))
+	}
+	iterator.Close()
+
+	for _, valAddr := range slashed {
+		store.Delete(types.GetSlashedValidatorKey(valAddr))
+	}
+
+	fromEpoch, enabled := k.slashClawbackStartEpoch(ctx)
+	fraction := sdk.MinDec(k.slashingKeeper.SlashFractionDoubleSign(ctx), sdk.OneDec())
+	if !enabled || !fraction.IsPositive() {
+		return
+	}
+
+	for _, valAddr := range slashed {
+		doubleSigned, err := k.isTombstoned(ctx, valAddr)
+		if err != nil {
+			k.Logger(ctx).Error("failed to look up slashed validator", "validator", valAddr.String(), "err", err)
+			continue
+		}
+		if !doubleSigned {
+			continue
+		}
+
+		provider := sdk.AccAddress(valAddr).String()
+		if _, err := k.Clawback(ctx, provider, fromEpoch, fraction, types.ClawbackReasonSlashed); err != nil {
+			k.Logger(ctx).Error("failed to claw back rewards of double signing validator", "validator", valAddr.String(), "err", err)
+		}
+	}
+}
+
+// isTombstoned returns whether the validator has been tombstoned for double signing
+func (k Keeper) isTombstoned(ctx sdk.Context, valAddr sdk.ValAddress) (bool, error) {
+	validator, found := k.stakingKeeper.GetValidator(ctx, valAddr)
+	if !found {
+		return false, fmt.Errorf("validator %s not found", valAddr)
+	}
+
+	consAddr, err := validator.GetConsAddr()
+	if err != nil {
+		return false, err
+	}
+
+	return k.slashingKeeper.IsTombstoned(ctx, consAddr), nil
+}
@@ -0,0 +1,330 @@
+package keeper
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/serv-chain/serv/x/servrewards/types"
+)
+
+// GetProviderCommission returns the commission of a provider
+func (k Keeper) GetProviderCommission(ctx sdk.Context, provider string) (types.ProviderCommission, bool) {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(types.GetProviderCommissionKey(provider))
+	if bz == nil {
+		return types.ProviderCommission{}, false
+	}
+
+	var commission types.ProviderCommission
+	k.cdc.MustUnmarshal(bz, &commission)
+	return commission, true
+}
+
+// SetProviderCommission sets the commission of a provider
+func (k Keeper) SetProviderCommission(ctx sdk.Context, commission types.ProviderCommission) {
+	store := ctx.KVStore(k.storeKey)
+	bz := k.cdc.MustMarshal(&commission)
+	store.Set(types.GetProviderCommissionKey(commission.Provider), bz)
+}
+
+// IterateProviderCommissions iterates over the commissions of all providers
+func (k Keeper) IterateProviderCommissions(ctx sdk.Context, cb func(commission types.ProviderCommission) (stop bool)) {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, types.ProviderCommissionPrefix)
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		var commission types.ProviderCommission
+		k.cdc.MustUnmarshal(iterator.Value(), &commission)
+		if cb(commission) {
+			break
+		}
+	}
+}
+
+// UpdateProviderCommission sets up the commission of a provider, or changes
+// its rate. The max rate and max change rate are required to set up a
+// commission and are fixed afterwards; the rate can change once per epoch by
+// at most the max change rate.
+func (k Keeper) UpdateProviderCommission(ctx sdk.Context, provider string, rate sdk.Dec, maxRate, maxChangeRate *sdk.Dec) error {
+	epoch := k.GetRewardMetrics(ctx).EpochNumber
+
+	commission, found := k.GetProviderCommission(ctx, provider)
+	if !found {
+		if maxRate == nil || maxChangeRate == nil {
+			return fmt.Errorf("max rate and max change rate are required to set up a commission")
+		}
+
+		commission = types.NewProviderCommission(provider, rate, *maxRate, *maxChangeRate, epoch)
+		if err := commission.Validate(); err != nil {
+			return err
+		}
+	} else {
+		if (maxRate != nil && !maxRate.Equal(commission.MaxRate)) || (maxChangeRate != nil && !maxChangeRate.Equal(commission.MaxChangeRate)) {
+			return fmt.Errorf("max rate and max change rate of a commission cannot be changed")
+		}
+
+		if err := commission.ValidateNewRate(rate, epoch); err != nil {
+			return err
+		}
+
+		commission.Rate = rate
+		commission.UpdateEpoch = epoch
+	}
+
+	k.SetProviderCommission(ctx, commission)
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			types.EventTypeSetCommission,
+			sdk.NewAttribute(types.AttributeKeyProvider, provider),
+			sdk.NewAttribute(types.AttributeKeyCommissionRate, commission.Rate.String()),
+		),
+	)
+
+	return nil
+}
+
+// GetDelegatorRewardsPool returns the rewards a provider shares with its delegators
+func (k Keeper) GetDelegatorRewardsPool(ctx sdk.Context, provider string) (types.DelegatorRewardsPool, bool) {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(types.GetDelegatorRewardsPoolKey(provider))
+	if bz == nil {
+		return types.DelegatorRewardsPool{
+			Provider:    provider,
+			Ratio:       sdk.NewDecCoins(),
+			Outstanding: sdk.NewCoins(),
+		}, false
+	}
+
+	var pool types.DelegatorRewardsPool
+	k.cdc.MustUnmarshal(bz, &pool)
+	return pool, true
+}
+
+// SetDelegatorRewardsPool sets the rewards a provider shares with its delegators
+func (k Keeper) SetDelegatorRewardsPool(ctx sdk.Context, pool types.DelegatorRewardsPool) {
+	store := ctx.KVStore(k.storeKey)
+	bz := k.cdc.MustMarshal(&pool)
+	store.Set(types.GetDelegatorRewardsPoolKey(pool.Provider), bz)
+}
+
+// IterateDelegatorRewardsPools iterates over the delegator rewards pools of all providers
+func (k Keeper) IterateDelegatorRewardsPools(ctx sdk.Context, cb func(pool types.DelegatorRewardsPool) (stop bool)) {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, types.DelegatorRewardsPoolPrefix)
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		var pool types.DelegatorRewardsPool
+		k.cdc.MustUnmarshal(iterator.Value(), &pool)
+		if cb(pool) {
+			break
+		}
+	}
+}
+
+// GetDelegatorStartingRatio returns the ratio of the delegator rewards pool of
+// a provider at the last withdrawal of a delegator. Delegations that never
+// withdrew start at zero.
+func (k Keeper) GetDelegatorStartingRatio(ctx sdk.Context, provider, delegator string) sdk.DecCoins {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(types.GetDelegatorStartingRatioKey(provider, delegator))
+	if bz == nil {
+		return sdk.NewDecCoins()
+	}
+
+	var ratio types.DelegatorStartingRatio
+	k.cdc.MustUnmarshal(bz, &ratio)
+	return ratio.Ratio
+}
+
+// SetDelegatorStartingRatio sets the ratio at which a delegator last withdrew
+func (k Keeper) SetDelegatorStartingRatio(ctx sdk.Context, ratio types.DelegatorStartingRatio) {
+	store := ctx.KVStore(k.storeKey)
+	bz := k.cdc.MustMarshal(&ratio)
+	store.Set(types.GetDelegatorStartingRatioKey(ratio.Provider, ratio.Delegator), bz)
+}
+
+// DeleteDelegatorStartingRatio removes the starting ratio of a delegator
+func (k Keeper) DeleteDelegatorStartingRatio(ctx sdk.Context, provider, delegator string) {
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(types.GetDelegatorStartingRatioKey(provider, delegator))
+}
+
+// IterateDelegatorStartingRatios iterates over the starting ratios of all delegators
+func (k Keeper) IterateDelegatorStartingRatios(ctx sdk.Context, cb func(ratio types.DelegatorStartingRatio) (stop bool)) {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, types.DelegatorStartingRatioPrefix)
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		var ratio types.DelegatorStartingRatio
+		k.cdc.MustUnmarshal(iterator.Value(), &ratio)
+		if cb(ratio) {
+			break
+		}
+	}
+}
+
+// shareProviderReward shares the reward of a provider with the delegators of
+// its validator and returns the commission the provider keeps. Providers
+// without a commission, or without a validator with delegations, keep
+// everything. The shared part is credited lazily: it only raises the rewards
+// per delegation share, which delegators withdraw when they claim or change
+// their delegation.
+func (k Keeper) shareProviderReward(ctx sdk.Context, provider string, reward sdk.Coins) sdk.Coins {
+	commission, found := k.GetProviderCommission(ctx, provider)
+	if !found {
+		return reward
+	}
+
+	providerAddr, err := sdk.AccAddressFromBech32(provider)
+	if err != nil {
+		return reward
+	}
+
+	validator, found := k.stakingKeeper.GetValidator(ctx, sdk.ValAddress(providerAddr))
+	if !found || !validator.DelegatorShares.IsPositive() {
+		return reward
+	}
+
+	kept, shared := commission.Split(reward)
+	if shared.IsZero() {
+		return reward
+	}
+
+	pool, _ := k.GetDelegatorRewardsPool(ctx, provider)
+	pool.Ratio = pool.Ratio.Add(sdk.NewDecCoinsFromCoins(shared...).QuoDecTruncate(validator.DelegatorShares)...)
+	pool.Outstanding = pool.Outstanding.Add(shared...)
+	k.SetDelegatorRewardsPool(ctx, pool)
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			types.EventTypeDelegatorRewardsShared,
+			sdk.NewAttribute(types.AttributeKeyProvider, provider),
+			sdk.NewAttribute(types.AttributeKeyCommission, kept.String()),
+			sdk.NewAttribute(types.AttributeKeyShared, shared.String()),
+		),
+	)
+
+	return kept
+}
+
+// DelegatorRewards returns the rewards a delegator can claim from a provider
+// for its delegation to the provider's validator
+func (k Keeper) DelegatorRewards(ctx sdk.Context, delegator sdk.AccAddress, provider string) sdk.Coins {
+	pool, found := k.GetDelegatorRewardsPool(ctx, provider)
+	if !found {
+		return sdk.NewCoins()
+	}
+
+	providerAddr, err := sdk.AccAddressFromBech32(provider)
+	if err != nil {
+		return sdk.NewCoins()
+	}
+
+	delegation, found := k.stakingKeeper.GetDelegation(ctx, delegator, sdk.ValAddress(providerAddr))
+	if !found {
+		return sdk.NewCoins()
+	}
+
+	return pool.Rewards(delegation.Shares, k.GetDelegatorStartingRatio(ctx, provider, delegator.String()))
+}
+
+// ClaimDelegatorRewards claims the rewards a delegator is owed by a provider.
+// Like ClaimRewards, the locked fraction of the claim vests and the payout is
+// sent to the reward withdraw address of the delegator. It returns the amount
+// paid out.
+func (k Keeper) ClaimDelegatorRewards(ctx sdk.Context, delegator sdk.AccAddress, provider string) (sdk.Coins, error) {
+	pool, found := k.GetDelegatorRewardsPool(ctx, provider)
+	if !found {
+		return sdk.NewCoins(), nil
+	}
+
+	claimed := k.DelegatorRewards(ctx, delegator, provider)
+	recipient := k.GetRewardWithdrawAddress(ctx, delegator)
+	payout, locked, unlocked, err := k.payRewards(ctx, delegator.String(), recipient, claimed)
+	if err != nil {
+		return sdk.NewCoins(), err
+	}
+
+	pool.Outstanding = pool.Outstanding.Sub(claimed...)
+	k.SetDelegatorRewardsPool(ctx, pool)
+	k.SetDelegatorStartingRatio(ctx, types.DelegatorStartingRatio{
+		Provider:  provider,
+		Delegator: delegator.String(),
+		Ratio:     pool.Ratio,
+	})
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			types.EventTypeDelegatorRewardsClaimed,
+			sdk.NewAttribute(types.AttributeKeyDelegator, delegator.String()),
+			sdk.NewAttribute(types.AttributeKeyProvider, provider),
+			sdk.NewAttribute(types.AttributeKeyWithdrawAddress, recipient.String()),
+			sdk.NewAttribute(types.AttributeKeyAmount, payout.String()),
+			sdk.NewAttribute(types.AttributeKeyLocked, locked.String()),
+			sdk.NewAttribute(types.AttributeKeyUnlocked, unlocked.String()),
+		),
+	)
+
+	return payout, nil
+}
+
+// settleDelegatorRewards settles the rewards a delegator is owed by a provider
+// before its delegation changes. They are paid out like a claim when possible.
+// A payout that fails, such as from an underfunded module account, must not
+// abort the staking operation, so the rewards are credited to the accumulated
+// rewards of the delegator instead and paid out with its next claim.
+func (k Keeper) settleDelegatorRewards(ctx sdk.Context, delegator sdk.AccAddress, provider string) {
+	cacheCtx, write := ctx.CacheContext()
+	_, err := k.ClaimDelegatorRewards(cacheCtx, delegator, provider)
+	if err == nil {
+		write()
+		return
+	}
+
+	pool, found := k.GetDelegatorRewardsPool(ctx, provider)
+	if !found {
+		return
+	}
+
+	// Both balances are outstanding in the reward pool, so it is left untouched
+	owed := k.DelegatorRewards(ctx, delegator, provider)
+	pool.Outstanding = pool.Outstanding.Sub(owed...)
+	k.SetDelegatorRewardsPool(ctx, pool)
+	k.SetDelegatorStartingRatio(ctx, types.DelegatorStartingRatio{
+		Provider:  provider,
+		Delegator: delegator.String(),
+		Ratio:     pool.Ratio,
+	})
+
+	k.Logger(ctx).Error("failed to pay out delegator rewards, crediting them instead",
+		"delegator", delegator.String(),
+		"provider", provider,
+		"amount", owed,
+		"err", err)
+
+	if owed.IsZero() {
+		return
+	}
+
+	epoch := k.GetRewardMetrics(ctx).EpochNumber
+	rewards := k.GetAccumulatedRewards(ctx, delegator.String())
+	if rewards.Rewards.IsZero() {
+		rewards.UnclaimedSince = epoch
+	}
+	rewards.Rewards = rewards.Rewards.Add(owed...)
+	k.SetAccumulatedRewards(ctx, rewards)
+	k.recordAddressEpochReward(ctx, delegator.String(), epoch, owed)
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			types.EventTypeDelegatorRewardsCredited,
+			sdk.NewAttribute(types.AttributeKeyDelegator, delegator.String()),
+			sdk.NewAttribute(types.AttributeKeyProvider, provider),
+			sdk.NewAttribute(types.AttributeKeyAmount, owed.String()),
+		),
+	)
+}
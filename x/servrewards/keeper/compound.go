@@ -0,0 +1,169 @@
+package keeper
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+	"github.com/serv-chain/serv/x/servrewards/types"
+)
+
+// ClaimAndDelegate claims the accumulated rewards of an address and delegates
+// the bond denom part of the payout to a validator. The payout goes to addr
+// itself rather than its reward withdraw address, as it has to fund the
+// delegation. It returns the payout and the amount delegated.
+func (k Keeper) ClaimAndDelegate(ctx sdk.Context, addr string, valAddr sdk.ValAddress) (sdk.Coins, sdk.Coin, error) {
+	bondDenom := k.stakingKeeper.BondDenom(ctx)
+	validator, found := k.stakingKeeper.GetValidator(ctx, valAddr)
+	if !found {
+		return sdk.NewCoins(), sdk.NewCoin(bondDenom, sdk.ZeroInt()), fmt.Errorf("validator %s not found", valAddr)
+	}
+
+	claimed, err := k.claimRewards(ctx, addr, sdk.MustAccAddressFromBech32(addr))
+	if err != nil {
+		return sdk.NewCoins(), sdk.NewCoin(bondDenom, sdk.ZeroInt()), err
+	}
+
+	delegated := sdk.NewCoin(bondDenom, claimed.AmountOf(bondDenom))
+	if delegated.IsZero() {
+		return claimed, delegated, nil
+	}
+
+	if _, err := k.stakingKeeper.Delegate(ctx, sdk.MustAccAddressFromBech32(addr), delegated.Amount, stakingtypes.Unbonded, validator, true); err != nil {
+		return sdk.NewCoins(), sdk.NewCoin(bondDenom, sdk.ZeroInt()), err
+	}
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			types.EventTypeRewardDelegated,
+			sdk.NewAttribute(types.AttributeKeyAddress, addr),
+			sdk.NewAttribute(types.AttributeKeyValidator, valAddr.String()),
+			sdk.NewAttribute(types.AttributeKeyAmount, delegated.String()),
+		),
+	)
+
+	return claimed, delegated, nil
+}
+
+// GetAutoCompound returns the auto-compound setting of an address
+func (k Keeper) GetAutoCompound(ctx sdk.Context, addr string) (types.AutoCompoundSetting, bool) {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(types.GetAutoCompoundKey(addr))
+	if bz == nil {
+		return types.AutoCompoundSetting{}, false
+	}
+
+	var setting types.AutoCompoundSetting
+	k.cdc.MustUnmarshal(bz, &setting)
+	return setting, true
+}
+
+// SetAutoCompound opts an address into auto-compounding
+func (k Keeper) SetAutoCompound(ctx sdk.Context, setting types.AutoCompoundSetting) {
+	store := ctx.KVStore(k.storeKey)
+	bz := k.cdc.MustMarshal(&setting)
+	store.Set(types.GetAutoCompoundKey(setting.Address), bz)
+}
+
+// DeleteAutoCompound opts an address out of auto-compounding
+func (k Keeper) DeleteAutoCompound(ctx sdk.Context, addr string) {
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(types.GetAutoCompoundKey(addr))
+}
+
+// IterateAutoCompound iterates over all auto-compound settings ordered by address
+func (k Keeper) IterateAutoCompound(ctx sdk.Context, cb func(setting types.AutoCompoundSetting) (stop bool)) {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, types.AutoCompoundPrefix)
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		var setting types.AutoCompoundSetting
+		k.cdc.MustUnmarshal(iterator.Value(), &setting)
+		if cb(setting) {
+			break
+		}
+	}
+}
+
+// StartAutoCompound schedules an auto-compound pass over all opted-in
+// addresses. A pass that has not finished yet starts over.
+func (k Keeper) StartAutoCompound(ctx sdk.Context) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.AutoCompoundCursorKey, types.AutoCompoundPrefix)
+}
+
+// ProcessAutoCompound continues the pending auto-compound pass until the
+// per-block gas budget is spent. Addresses that fail to compound are skipped.
+func (k Keeper) ProcessAutoCompound(ctx sdk.Context) {
+	budget := k.GetRewardParams(ctx).AutoCompoundGasBudget
+	store := ctx.KVStore(k.storeKey)
+	cursor := store.Get(types.AutoCompoundCursorKey)
+	if cursor == nil || budget == 0 {
+		return
+	}
+
+	start := ctx.GasMeter().GasConsumed()
+	for ctx.GasMeter().GasConsumed()-start < budget {
+		setting, key, found := k.nextAutoCompound(ctx, cursor)
+		if !found {
+			store.Delete(types.AutoCompoundCursorKey)
+			return
+		}
+
+		k.autoCompound(ctx, setting)
+		cursor = append(append([]byte{}, key...), 0x00)
+	}
+
+	// Resume after the last processed address in the next block
+	store.Set(types.AutoCompoundCursorKey, cursor)
+}
+
+// nextAutoCompound returns the first auto-compound setting at or after cursor.
+// The iterator is closed before returning, as compounding writes to the store.
+func (k Keeper) nextAutoCompound(ctx sdk.Context, cursor []byte) (types.AutoCompoundSetting, []byte, bool) {
+	store := ctx.KVStore(k.storeKey)
+	iterator := store.Iterator(cursor, sdk.PrefixEndBytes(types.AutoCompoundPrefix))
+	defer iterator.Close()
+
+	if !iterator.Valid() {
+		return types.AutoCompoundSetting{}, nil, false
+	}
+
+	var setting types.AutoCompoundSetting
+	k.cdc.MustUnmarshal(iterator.Value(), &setting)
+	return setting, iterator.Key(), true
+}
+
+// autoCompound claims and delegates the rewards of an opted-in address,
+// discarding all state changes if it fails
+func (k Keeper) autoCompound(ctx sdk.Context, setting types.AutoCompoundSetting) {
+	rewards := k.GetAccumulatedRewards(ctx, setting.Address)
+	if rewards.Rewards.IsZero() {
+		return
+	}
+
+	valAddr, err := sdk.ValAddressFromBech32(setting.Validator)
+	if err != nil {
+		k.Logger(ctx).Error("invalid auto-compound validator", "address", setting.Address, "err", err)
+		return
+	}
+
+	cacheCtx, write := ctx.CacheContext()
+	claimed, delegated, err := k.ClaimAndDelegate(cacheCtx, setting.Address, valAddr)
+	if err != nil {
+		k.Logger(ctx).Debug("failed to auto-compound rewards", "address", setting.Address, "err", err)
+		return
+	}
+	write()
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			types.EventTypeAutoCompound,
+			sdk.NewAttribute(types.AttributeKeyAddress, setting.Address),
+			sdk.NewAttribute(types.AttributeKeyValidator, setting.Validator),
+			sdk.NewAttribute(types.AttributeKeyAmount, claimed.String()),
+			sdk.NewAttribute(types.AttributeKeyDelegated, delegated.String()),
+		),
+	)
+}
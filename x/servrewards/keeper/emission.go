@@ -0,0 +1,66 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/serv-chain/serv/x/servrewards/types"
+)
+
+// GetEmissionState returns the lifetime emission state
+func (k Keeper) GetEmissionState(ctx sdk.Context) types.EmissionState {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(types.EmissionStateKey)
+	if bz == nil {
+		return types.DefaultEmissionState()
+	}
+
+	var state types.EmissionState
+	k.cdc.MustUnmarshal(bz, &state)
+	return state
+}
+
+// SetEmissionState sets the lifetime emission state
+func (k Keeper) SetEmissionState(ctx sdk.Context, state types.EmissionState) {
+	store := ctx.KVStore(k.storeKey)
+	bz := k.cdc.MustMarshal(&state)
+	store.Set(types.EmissionStateKey, bz)
+}
+
+// recordEmission adds amount to the total emitted over the lifetime of the module
+func (k Keeper) recordEmission(ctx sdk.Context, amount sdk.Coins) {
+	state := k.GetEmissionState(ctx)
+	state.TotalEmitted = state.TotalEmitted.Add(amount...)
+	k.SetEmissionState(ctx, state)
+}
+
+// EpochEmission returns the amount the emission schedule releases in an
+// epoch, limited by what is left of the lifetime cap
+func (k Keeper) EpochEmission(ctx sdk.Context, epoch uint64) sdk.Coins {
+	params := k.GetRewardParams(ctx)
+	bonded := k.stakingKeeper.GetTotalBondedTokens(ctx)
+
+	emission := params.Emission.Emission(params.RewardPerEpoch, params.MintDenom, epoch, bonded)
+	return params.Emission.ApplyCap(emission, k.GetEmissionState(ctx).TotalEmitted)
+}
+
+// ProjectEmissions projects the emission of the next epochs, assuming every
+// epoch is emitted in full and the bonded supply stays at its current level
+func (k Keeper) ProjectEmissions(ctx sdk.Context, epochs uint64) []types.EpochEmission {
+	params := k.GetRewardParams(ctx)
+	bonded := k.stakingKeeper.GetTotalBondedTokens(ctx)
+	emitted := k.GetEmissionState(ctx).TotalEmitted
+	next := k.GetRewardMetrics(ctx).EpochNumber + 1
+
+	projection := make([]types.EpochEmission, 0, epochs)
+	for epoch := next; epoch < next+epochs; epoch++ {
+		emission := params.Emission.Emission(params.RewardPerEpoch, params.MintDenom, epoch, bonded)
+		emission = params.Emission.ApplyCap(emission, emitted)
+		emitted = emitted.Add(emission...)
+
+		projection = append(projection, types.EpochEmission{
+			EpochNumber: epoch,
+			Amount:      emission,
+		})
+	}
+
+	return projection
+}
@@ -0,0 +1,144 @@
+package keeper
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	"github.com/serv-chain/serv/x/servrewards/types"
+)
+
+// ExpireUnclaimedRewards sweeps the accumulated rewards that addresses have
+// left unclaimed for UnclaimedExpiryEpochs epochs to the configured expiry
+// destination, and warns addresses ExpiryWarningEpochs epochs before their
+// rewards expire. Rewards age by the epoch they were credited in, as recorded
+// in the earnings ledger, so only the aged part of a balance expires and the
+// rewards credited since stay claimable. Claiming resets the age of a balance.
+func (k Keeper) ExpireUnclaimedRewards(ctx sdk.Context, currentEpoch uint64) {
+	params := k.GetRewardParams(ctx)
+	if params.UnclaimedExpiryEpochs == 0 {
+		return
+	}
+
+	if currentEpoch >= params.UnclaimedExpiryEpochs {
+		k.expireAgedRewards(ctx, params, currentEpoch-params.UnclaimedExpiryEpochs)
+	}
+
+	// Warn addresses about what expires in the next ExpiryWarningEpochs epochs
+	if params.ExpiryWarningEpochs > 0 && currentEpoch+params.ExpiryWarningEpochs > params.UnclaimedExpiryEpochs {
+		since := currentEpoch + params.ExpiryWarningEpochs - params.UnclaimedExpiryEpochs
+		for _, addr := range k.unclaimedRewardsQueue(ctx, 0, since) {
+			rewards := k.GetAccumulatedRewards(ctx, addr)
+			expiring, _ := k.agedRewards(ctx, rewards, since)
+			if expiring.IsZero() {
+				continue
+			}
+
+			ctx.EventManager().EmitEvent(
+				sdk.NewEvent(
+					types.EventTypeRewardsExpiring,
+					sdk.NewAttribute(types.AttributeKeyAddress, addr),
+					sdk.NewAttribute(types.AttributeKeyAmount, expiring.String()),
+					sdk.NewAttribute(types.AttributeKeyExpiryEpoch, fmt.Sprintf("%d", since+params.UnclaimedExpiryEpochs)),
+				),
+			)
+		}
+	}
+}
+
+// agedRewards splits the unclaimed rewards of an address into the part
+// credited up to and including the cutoff epoch and the epoch of the oldest
+// reward credited after it, if any. Rewards the earnings ledger does not
+// account for, such as those credited before it was pruned, count as aged.
+func (k Keeper) agedRewards(ctx sdk.Context, rewards types.AccumulatedRewards, cutoff uint64) (aged sdk.Coins, oldest uint64) {
+	recent := sdk.NewCoins()
+	iterator := k.addressEpochRewardsStore(ctx, rewards.Address).Iterator(sdk.Uint64ToBigEndian(cutoff+1), nil)
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		var earning types.AddressEpochReward
+		k.cdc.MustUnmarshal(iterator.Value(), &earning)
+		if earning.Amount.IsZero() {
+			continue
+		}
+		if recent.IsZero() {
+			oldest = earning.EpochNumber
+		}
+		recent = recent.Add(earning.Amount...)
+	}
+
+	// Clawbacks may have taken more than the recent rewards left
+	return rewards.Rewards.Sub(recent.Min(rewards.Rewards)...), oldest
+}
+
+// expireAgedRewards sweeps the unclaimed rewards credited up to and including
+// the cutoff epoch
+func (k Keeper) expireAgedRewards(ctx sdk.Context, params types.RewardParams, cutoff uint64) {
+	expired := sdk.NewCoins()
+	for _, addr := range k.unclaimedRewardsQueue(ctx, 0, cutoff) {
+		rewards := k.GetAccumulatedRewards(ctx, addr)
+		amount, oldest := k.agedRewards(ctx, rewards, cutoff)
+		expired = expired.Add(amount...)
+
+		// The rest of the balance ages from its oldest reward
+		rewards.Rewards = rewards.Rewards.Sub(amount...)
+		rewards.UnclaimedSince = oldest
+		k.SetAccumulatedRewards(ctx, rewards)
+
+		if amount.IsZero() {
+			continue
+		}
+
+		ctx.EventManager().EmitEvent(
+			sdk.NewEvent(
+				types.EventTypeRewardsExpired,
+				sdk.NewAttribute(types.AttributeKeyAddress, addr),
+				sdk.NewAttribute(types.AttributeKeyAmount, amount.String()),
+				sdk.NewAttribute(types.AttributeKeyDestination, params.ExpiryDestination),
+			),
+		)
+	}
+
+	if expired.IsZero() {
+		return
+	}
+
+	pool := k.GetRewardPool(ctx)
+	k.releaseOutstanding(ctx, &pool, expired)
+
+	switch params.ExpiryDestination {
+	case types.ExpiryDestinationCommunityPool:
+		moduleAddr := authtypes.NewModuleAddress(types.ModuleName)
+		if err := k.distrKeeper.FundCommunityPool(ctx, expired, moduleAddr); err != nil {
+			// Keep the expired rewards in the module rather than losing track of them
+			k.Logger(ctx).Error("failed to send expired rewards to community pool", "amount", expired, "err", err)
+			pool.Available = pool.Available.Add(expired...)
+			pool.Remainder = pool.Remainder.Add(expired...)
+		}
+	default:
+		// Expired rewards were already emitted, so they are redistributed with the remainder
+		pool.Available = pool.Available.Add(expired...)
+		pool.Remainder = pool.Remainder.Add(expired...)
+	}
+
+	k.SetRewardPool(ctx, pool)
+}
+
+// unclaimedRewardsQueue returns the addresses with unclaimed rewards first
+// credited between the from and to epochs, inclusive
+func (k Keeper) unclaimedRewardsQueue(ctx sdk.Context, from, to uint64) []string {
+	store := ctx.KVStore(k.storeKey)
+	start := append(types.UnclaimedRewardsQueuePrefix, sdk.Uint64ToBigEndian(from)...)
+	end := sdk.PrefixEndBytes(append(types.UnclaimedRewardsQueuePrefix, sdk.Uint64ToBigEndian(to)...))
+
+	iterator := store.Iterator(start, end)
+	defer iterator.Close()
+
+	var addrs []string
+	for ; iterator.Valid(); iterator.Next() {
+		_, addr := types.ParseUnclaimedRewardsQueueKey(iterator.Key())
+		addrs = append(addrs, addr)
+	}
+
+	return addrs
+}
@@ -7,6 +7,7 @@ import (
 	"google.golang.org/grpc/status"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/query"
 	"github.com/serv-chain/serv/x/servrewards/types"
 )
 
@@ -65,3 +66,228 @@ func (q Querier) AccumulatedRewards(c context.Context, req *types.QueryAccumulat
 		Rewards: &rewards,
 	}, nil
 }
+
+// RewardPool implements the Query/RewardPool gRPC method
+func (q Querier) RewardPool(c context.Context, req *types.QueryRewardPoolRequest) (*types.QueryRewardPoolResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+
+	ctx := sdk.UnwrapSDKContext(c)
+	pool := q.Keeper.GetRewardPool(ctx)
+
+	return &types.QueryRewardPoolResponse{
+		Pool: &pool,
+	}, nil
+}
+
+// EpochRewardRecord implements the Query/EpochRewardRecord gRPC method
+func (q Querier) EpochRewardRecord(c context.Context, req *types.QueryEpochRewardRecordRequest) (*types.QueryEpochRewardRecordResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+
+	ctx := sdk.UnwrapSDKContext(c)
+	record, found := q.Keeper.GetEpochRewardRecord(ctx, req.EpochNumber)
+	if !found {
+		return nil, status.Errorf(codes.NotFound, "no reward record for epoch %d", req.EpochNumber)
+	}
+
+	return &types.QueryEpochRewardRecordResponse{
+		Record: &record,
+	}, nil
+}
+
+// RewardHistory implements the Query/RewardHistory gRPC method
+func (q Querier) RewardHistory(c context.Context, req *types.QueryRewardHistoryRequest) (*types.QueryRewardHistoryResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+
+	if req.Address == "" {
+		return nil, status.Error(codes.InvalidArgument, "address cannot be empty")
+	}
+
+	ctx := sdk.UnwrapSDKContext(c)
+	store := q.Keeper.addressEpochRewardsStore(ctx, req.Address)
+
+	var earnings []types.AddressEpochReward
+	pageRes, err := query.Paginate(store, req.Pagination, func(key []byte, value []byte) error {
+		var earning types.AddressEpochReward
+		if err := q.cdc.Unmarshal(value, &earning); err != nil {
+			return err
+		}
+		earnings = append(earnings, earning)
+		return nil
+	})
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &types.QueryRewardHistoryResponse{
+		Rewards:    earnings,
+		Pagination: pageRes,
+	}, nil
+}
+
+// RewardWithdrawAddress implements the Query/RewardWithdrawAddress gRPC method
+func (q Querier) RewardWithdrawAddress(c context.Context, req *types.QueryRewardWithdrawAddressRequest) (*types.QueryRewardWithdrawAddressResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+
+	addr, err := sdk.AccAddressFromBech32(req.Address)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	ctx := sdk.UnwrapSDKContext(c)
+	withdrawAddr := q.Keeper.GetRewardWithdrawAddress(ctx, addr)
+
+	return &types.QueryRewardWithdrawAddressResponse{
+		WithdrawAddress: withdrawAddr.String(),
+	}, nil
+}
+
+// ProviderCommission implements the Query/ProviderCommission gRPC method
+func (q Querier) ProviderCommission(c context.Context, req *types.QueryProviderCommissionRequest) (*types.QueryProviderCommissionResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+
+	if _, err := sdk.AccAddressFromBech32(req.Provider); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	ctx := sdk.UnwrapSDKContext(c)
+	commission, found := q.Keeper.GetProviderCommission(ctx, req.Provider)
+	if !found {
+		return nil, status.Errorf(codes.NotFound, "no commission set for %s", req.Provider)
+	}
+
+	return &types.QueryProviderCommissionResponse{
+		Commission: commission,
+	}, nil
+}
+
+// DelegatorRewards implements the Query/DelegatorRewards gRPC method
+func (q Querier) DelegatorRewards(c context.Context, req *types.QueryDelegatorRewardsRequest) (*types.QueryDelegatorRewardsResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+
+	delegator, err := sdk.AccAddressFromBech32(req.Delegator)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if _, err := sdk.AccAddressFromBech32(req.Provider); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	ctx := sdk.UnwrapSDKContext(c)
+
+	return &types.QueryDelegatorRewardsResponse{
+		Rewards: q.Keeper.DelegatorRewards(ctx, delegator, req.Provider),
+	}, nil
+}
+
+// ClawbackDebt implements the Query/ClawbackDebt gRPC method
+func (q Querier) ClawbackDebt(c context.Context, req *types.QueryClawbackDebtRequest) (*types.QueryClawbackDebtResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+
+	if _, err := sdk.AccAddressFromBech32(req.Address); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	ctx := sdk.UnwrapSDKContext(c)
+
+	return &types.QueryClawbackDebtResponse{
+		Debt: q.Keeper.GetClawbackDebt(ctx, req.Address).Amount,
+	}, nil
+}
+
+// RewardBreakdown implements the Query/RewardBreakdown gRPC method
+func (q Querier) RewardBreakdown(c context.Context, req *types.QueryRewardBreakdownRequest) (*types.QueryRewardBreakdownResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+
+	if _, err := sdk.AccAddressFromBech32(req.Address); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	ctx := sdk.UnwrapSDKContext(c)
+
+	return &types.QueryRewardBreakdownResponse{
+		Breakdown: q.Keeper.RewardBreakdown(ctx, req.Address),
+	}, nil
+}
+
+// SimulateRewards implements the Query/SimulateRewards gRPC method
+func (q Querier) SimulateRewards(c context.Context, req *types.QuerySimulateRewardsRequest) (*types.QuerySimulateRewardsResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+
+	if req.Address != "" {
+		if _, err := sdk.AccAddressFromBech32(req.Address); err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+	}
+
+	if req.ServiceScore != nil && req.ServiceScore.IsNegative() {
+		return nil, status.Error(codes.InvalidArgument, "service score cannot be negative")
+	}
+
+	if req.Stake != nil && req.Stake.IsNegative() {
+		return nil, status.Error(codes.InvalidArgument, "stake cannot be negative")
+	}
+
+	ctx := sdk.UnwrapSDKContext(c)
+
+	return &types.QuerySimulateRewardsResponse{
+		Breakdown: q.Keeper.SimulateRewards(ctx, req.Address, req.ServiceScore, req.Stake),
+	}, nil
+}
+
+// VestingBalances implements the Query/VestingBalances gRPC method
+func (q Querier) VestingBalances(c context.Context, req *types.QueryVestingBalancesRequest) (*types.QueryVestingBalancesResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+
+	if req.Address == "" {
+		return nil, status.Error(codes.InvalidArgument, "address cannot be empty")
+	}
+
+	ctx := sdk.UnwrapSDKContext(c)
+	vesting := q.Keeper.GetVestingRewards(ctx, req.Address)
+	locked, unlocked := vesting.Balances(ctx.BlockTime())
+
+	return &types.QueryVestingBalancesResponse{
+		Locked:   locked,
+		Unlocked: unlocked,
+		Entries:  vesting.Entries,
+	}, nil
+}
+
+// ProjectedEmissions implements the Query/ProjectedEmissions gRPC method
+func (q Querier) ProjectedEmissions(c context.Context, req *types.QueryProjectedEmissionsRequest) (*types.QueryProjectedEmissionsResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+
+	if req.Epochs == 0 || req.Epochs > types.MaxEmissionProjectionEpochs {
+		return nil, status.Errorf(codes.InvalidArgument, "epochs must be between 1 and %d", types.MaxEmissionProjectionEpochs)
+	}
+
+	ctx := sdk.UnwrapSDKContext(c)
+
+	return &types.QueryProjectedEmissionsResponse{
+		Emissions:    q.Keeper.ProjectEmissions(ctx, req.Epochs),
+		TotalEmitted: q.Keeper.GetEmissionState(ctx).TotalEmitted,
+	}, nil
+}
@@ -0,0 +1,158 @@
+package keeper
+
+import (
+	"fmt"
+
+	"github.com/cosmos/cosmos-sdk/store/prefix"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/serv-chain/serv/x/servrewards/types"
+)
+
+// GetEpochRewardRecord returns the reward record of an epoch
+func (k Keeper) GetEpochRewardRecord(ctx sdk.Context, epoch uint64) (types.EpochRewardRecord, bool) {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(types.GetEpochRewardRecordKey(epoch))
+	if bz == nil {
+		return types.EpochRewardRecord{}, false
+	}
+
+	var record types.EpochRewardRecord
+	k.cdc.MustUnmarshal(bz, &record)
+	return record, true
+}
+
+// SetEpochRewardRecord stores the reward record of an epoch. Records are
+// immutable, so an epoch can only be recorded once.
+func (k Keeper) SetEpochRewardRecord(ctx sdk.Context, record types.EpochRewardRecord) error {
+	store := ctx.KVStore(k.storeKey)
+	key := types.GetEpochRewardRecordKey(record.EpochNumber)
+	if store.Has(key) {
+		return fmt.Errorf("rewards of epoch %d already recorded", record.EpochNumber)
+	}
+
+	bz := k.cdc.MustMarshal(&record)
+	store.Set(key, bz)
+	return nil
+}
+
+// IterateEpochRewardRecords iterates over all epoch reward records ordered by epoch
+func (k Keeper) IterateEpochRewardRecords(ctx sdk.Context, cb func(record types.EpochRewardRecord) (stop bool)) {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, types.EpochRewardRecordPrefix)
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		var record types.EpochRewardRecord
+		k.cdc.MustUnmarshal(iterator.Value(), &record)
+		if cb(record) {
+			break
+		}
+	}
+}
+
+// GetAddressEpochReward returns the earnings of an address in an epoch
+func (k Keeper) GetAddressEpochReward(ctx sdk.Context, addr string, epoch uint64) (types.AddressEpochReward, bool) {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(types.GetAddressEpochRewardKey(addr, epoch))
+	if bz == nil {
+		return types.AddressEpochReward{}, false
+	}
+
+	var earning types.AddressEpochReward
+	k.cdc.MustUnmarshal(bz, &earning)
+	return earning, true
+}
+
+// SetAddressEpochReward sets the earnings of an address in an epoch and indexes them by epoch
+func (k Keeper) SetAddressEpochReward(ctx sdk.Context, earning types.AddressEpochReward) {
+	store := ctx.KVStore(k.storeKey)
+	bz := k.cdc.MustMarshal(&earning)
+	store.Set(types.GetAddressEpochRewardKey(earning.Address, earning.EpochNumber), bz)
+	store.Set(types.GetEpochAddressRewardIndexKey(earning.EpochNumber, earning.Address), []byte{})
+}
+
+// recordAddressEpochReward adds amount to the earnings of an address in an epoch
+func (k Keeper) recordAddressEpochReward(ctx sdk.Context, addr string, epoch uint64, amount sdk.Coins) {
+	earning, found := k.GetAddressEpochReward(ctx, addr, epoch)
+	if !found {
+		earning = types.AddressEpochReward{
+			Address:     addr,
+			EpochNumber: epoch,
+			Amount:      sdk.NewCoins(),
+		}
+	}
+
+	earning.Amount = earning.Amount.Add(amount...)
+	k.SetAddressEpochReward(ctx, earning)
+}
+
+// IterateAddressEpochRewards iterates over the earnings of an address ordered by epoch
+func (k Keeper) IterateAddressEpochRewards(ctx sdk.Context, addr string, cb func(earning types.AddressEpochReward) (stop bool)) {
+	k.iterateAddressEpochRewards(ctx, types.GetAddressEpochRewardsPrefix(addr), cb)
+}
+
+// IterateAllAddressEpochRewards iterates over the earnings of all addresses
+func (k Keeper) IterateAllAddressEpochRewards(ctx sdk.Context, cb func(earning types.AddressEpochReward) (stop bool)) {
+	k.iterateAddressEpochRewards(ctx, types.AddressEpochRewardPrefix, cb)
+}
+
+func (k Keeper) iterateAddressEpochRewards(ctx sdk.Context, keyPrefix []byte, cb func(earning types.AddressEpochReward) (stop bool)) {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, keyPrefix)
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		var earning types.AddressEpochReward
+		k.cdc.MustUnmarshal(iterator.Value(), &earning)
+		if cb(earning) {
+			break
+		}
+	}
+}
+
+// addressEpochRewardsStore returns the earnings ledger of an address, keyed by epoch
+func (k Keeper) addressEpochRewardsStore(ctx sdk.Context, addr string) prefix.Store {
+	return prefix.NewStore(ctx.KVStore(k.storeKey), types.GetAddressEpochRewardsPrefix(addr))
+}
+
+// PruneAddressEpochRewards removes the earnings of epochs that fall outside
+// HistoryRetentionEpochs. Epoch reward records are never pruned.
+func (k Keeper) PruneAddressEpochRewards(ctx sdk.Context, currentEpoch uint64) {
+	params := k.GetRewardParams(ctx)
+	if params.HistoryRetentionEpochs == 0 || currentEpoch <= params.HistoryRetentionEpochs {
+		return
+	}
+
+	// Earnings of epochs up to and including the cutoff are pruned
+	cutoff := currentEpoch - params.HistoryRetentionEpochs
+	end := append(types.EpochAddressRewardIndexPrefix, sdk.Uint64ToBigEndian(cutoff+1)...)
+
+	store := ctx.KVStore(k.storeKey)
+	iterator := store.Iterator(types.EpochAddressRewardIndexPrefix, end)
+
+	var indexKeys [][]byte
+	for ; iterator.Valid(); iterator.Next() {
+		indexKeys = append(indexKeys, iterator.Key())
+	}
+	iterator.Close()
+
+	for _, indexKey := range indexKeys {
+		epoch, addr := types.ParseEpochAddressRewardIndexKey(indexKey)
+		store.Delete(types.GetAddressEpochRewardKey(addr, epoch))
+		store.Delete(indexKey)
+	}
+
+	// Clawback records only bound clawbacks of earnings still in the ledger
+	clawbackEnd := append(types.ClawbackRecordPrefix, sdk.Uint64ToBigEndian(cutoff+1)...)
+	clawbackIterator := store.Iterator(types.ClawbackRecordPrefix, clawbackEnd)
+
+	var clawbackKeys [][]byte
+	for ; clawbackIterator.Valid(); clawbackIterator.Next() {
+		clawbackKeys = append(clawbackKeys, clawbackIterator.Key())
+	}
+	clawbackIterator.Close()
+
+	for _, key := range clawbackKeys {
+		store.Delete(key)
+	}
+}
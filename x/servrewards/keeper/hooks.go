@@ -0,0 +1,166 @@
+package keeper
+
+import (
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+	epochstypes "github.com/serv-chain/serv/x/epochs/types"
+	proofofservicetypes "github.com/serv-chain/serv/x/proofofservice/types"
+	"github.com/serv-chain/serv/x/servrewards/types"
+)
+
+var _ epochstypes.EpochHooks = Hooks{}
+
+// Hooks wrapper struct for the servrewards keeper
+type Hooks struct {
+	k Keeper
+}
+
+// Hooks returns the epoch hooks through which servrewards allocates epoch rewards
+func (k Keeper) Hooks() Hooks {
+	return Hooks{k}
+}
+
+// AfterEpochEnd allocates the rewards of the ended epoch if it is the reward epoch
+func (h Hooks) AfterEpochEnd(ctx sdk.Context, epochIdentifier string, epochNumber uint64) {
+	params := h.k.GetRewardParams(ctx)
+	if epochIdentifier != params.EpochIdentifier {
+		return
+	}
+
+	h.k.UpdateRewards(ctx)
+
+	metrics := h.k.GetRewardMetrics(ctx)
+	h.k.Logger(ctx).Info("SERV Rewards epoch completed",
+		"epoch", metrics.EpochNumber,
+		"total_service_score", metrics.TotalServiceScore,
+		"total_staked", metrics.TotalStaked)
+}
+
+// BeforeEpochStart implements EpochHooks
+func (h Hooks) BeforeEpochStart(ctx sdk.Context, epochIdentifier string, epochNumber uint64) {}
+
+var _ stakingtypes.StakingHooks = StakingHooks{}
+
+// StakingHooks wrapper struct for the servrewards keeper
+type StakingHooks struct {
+	k Keeper
+}
+
+// StakingHooks returns the staking hooks through which servrewards settles the
+// rewards providers share with their delegators whenever a delegation changes
+func (k Keeper) StakingHooks() StakingHooks {
+	return StakingHooks{k}
+}
+
+// BeforeDelegationSharesModified settles the rewards accrued by the delegation so far
+func (h StakingHooks) BeforeDelegationSharesModified(ctx sdk.Context, delAddr sdk.AccAddress, valAddr sdk.ValAddress) error {
+	h.k.settleDelegatorRewards(ctx, delAddr, sdk.AccAddress(valAddr).String())
+	return nil
+}
+
+// BeforeDelegationRemoved settles the rewards accrued by the delegation and forgets it
+func (h StakingHooks) BeforeDelegationRemoved(ctx sdk.Context, delAddr sdk.AccAddress, valAddr sdk.ValAddress) error {
+	provider := sdk.AccAddress(valAddr).String()
+	h.k.settleDelegatorRewards(ctx, delAddr, provider)
+	h.k.DeleteDelegatorStartingRatio(ctx, provider, delAddr.String())
+	return nil
+}
+
+// AfterDelegationModified starts the modified delegation at the current rewards per share
+func (h StakingHooks) AfterDelegationModified(ctx sdk.Context, delAddr sdk.AccAddress, valAddr sdk.ValAddress) error {
+	provider := sdk.AccAddress(valAddr).String()
+	pool, found := h.k.GetDelegatorRewardsPool(ctx, provider)
+	if !found {
+		return nil
+	}
+
+	h.k.SetDelegatorStartingRatio(ctx, types.DelegatorStartingRatio{
+		Provider:  provider,
+		Delegator: delAddr.String(),
+		Ratio:     pool.Ratio,
+	})
+	return nil
+}
+
+// AfterValidatorCreated implements StakingHooks
+func (h StakingHooks) AfterValidatorCreated(ctx sdk.Context, valAddr sdk.ValAddress) error {
+	return nil
+}
+
+// BeforeValidatorModified implements StakingHooks
+func (h StakingHooks) BeforeValidatorModified(ctx sdk.Context, valAddr sdk.ValAddress) error {
+	return nil
+}
+
+// AfterValidatorRemoved implements StakingHooks
+func (h StakingHooks) AfterValidatorRemoved(ctx sdk.Context, consAddr sdk.ConsAddress, valAddr sdk.ValAddress) error {
+	return nil
+}
+
+// AfterValidatorBonded implements StakingHooks
+func (h StakingHooks) AfterValidatorBonded(ctx sdk.Context, consAddr sdk.ConsAddress, valAddr sdk.ValAddress) error {
+	return nil
+}
+
+// AfterValidatorBeginUnbonding implements StakingHooks
+func (h StakingHooks) AfterValidatorBeginUnbonding(ctx sdk.Context, consAddr sdk.ConsAddress, valAddr sdk.ValAddress) error {
+	return nil
+}
+
+// BeforeDelegationCreated implements StakingHooks
+func (h StakingHooks) BeforeDelegationCreated(ctx sdk.Context, delAddr sdk.AccAddress, valAddr sdk.ValAddress) error {
+	return nil
+}
+
+// BeforeValidatorSlashed marks the validator as slashed. Only double signing
+// claws back what its provider earned, which is told apart from downtime once
+// the validator is tombstoned, see Keeper.ProcessSlashClawbacks.
+func (h StakingHooks) BeforeValidatorSlashed(ctx sdk.Context, valAddr sdk.ValAddress, fraction sdk.Dec) error {
+	if _, enabled := h.k.slashClawbackStartEpoch(ctx); !enabled || !fraction.IsPositive() {
+		return nil
+	}
+
+	// Tombstoned validators cannot double sign again
+	if tombstoned, err := h.k.isTombstoned(ctx, valAddr); err != nil || tombstoned {
+		return nil
+	}
+
+	h.k.markSlashedValidator(ctx, valAddr)
+	return nil
+}
+
+// AfterUnbondingInitiated implements StakingHooks
+func (h StakingHooks) AfterUnbondingInitiated(ctx sdk.Context, id uint64) error {
+	return nil
+}
+
+var _ proofofservicetypes.ProofOfServiceHooks = ProofOfServiceHooks{}
+
+// ProofOfServiceHooks wrapper struct for the servrewards keeper
+type ProofOfServiceHooks struct {
+	k Keeper
+}
+
+// ProofOfServiceHooks returns the proofofservice hooks through which
+// servrewards claws back rewards earned with revoked proofs
+func (k Keeper) ProofOfServiceHooks() ProofOfServiceHooks {
+	return ProofOfServiceHooks{k}
+}
+
+// AfterServiceProviderRegistered implements ProofOfServiceHooks
+func (h ProofOfServiceHooks) AfterServiceProviderRegistered(ctx sdk.Context, provider string) {}
+
+// AfterProofSubmitted implements ProofOfServiceHooks
+func (h ProofOfServiceHooks) AfterProofSubmitted(ctx sdk.Context, provider string, proofID string) {}
+
+// AfterProofVerified implements ProofOfServiceHooks
+func (h ProofOfServiceHooks) AfterProofVerified(ctx sdk.Context, provider string, proofID string, score sdk.Int) {}
+
+// AfterProofRevoked claws back the rewards earned with the revoked proof
+func (h ProofOfServiceHooks) AfterProofRevoked(ctx sdk.Context, provider string, proofID string, revokedScore, providerScore sdk.Int, verifiedAt time.Time) {
+	if err := h.k.clawbackRevokedScore(ctx, provider, revokedScore, providerScore, verifiedAt); err != nil {
+		h.k.Logger(ctx).Error("failed to claw back rewards of revoked proof", "provider", provider, "proof_id", proofID, "err", err)
+	}
+}
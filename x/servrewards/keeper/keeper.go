@@ -19,8 +19,15 @@ type Keeper struct {
 
 	bankKeeper       types.BankKeeper
 	stakingKeeper    types.StakingKeeper
+	slashingKeeper   types.SlashingKeeper
+	distrKeeper      types.DistrKeeper
 	posKeeper        types.ProofOfServiceKeeper
 	hooks            types.ServRewardsHooks
+	curves           map[string]types.RewardCurve
+
+	// the address capable of executing a MsgUpdateRewardParams message,
+	// typically the x/gov module account
+	authority string
 }
 
 // NewKeeper creates a new servrewards Keeper instance
@@ -30,23 +37,44 @@ func NewKeeper(
 	ps paramtypes.Subspace,
 	bankKeeper types.BankKeeper,
 	stakingKeeper types.StakingKeeper,
+	slashingKeeper types.SlashingKeeper,
+	distrKeeper types.DistrKeeper,
 	posKeeper types.ProofOfServiceKeeper,
+	authority string,
 ) *Keeper {
+	if _, err := sdk.AccAddressFromBech32(authority); err != nil {
+		panic(fmt.Errorf("invalid servrewards authority address: %w", err))
+	}
+
 	// set KeyTable if it has not already been set
 	if !ps.HasKeyTable() {
 		ps = ps.WithKeyTable(types.ParamKeyTable())
 	}
 
+	curves := make(map[string]types.RewardCurve)
+	for _, curve := range types.BuiltinRewardCurves() {
+		curves[curve.Name()] = curve
+	}
+
 	return &Keeper{
-		storeKey:      storeKey,
-		cdc:           cdc,
-		paramstore:    ps,
-		bankKeeper:    bankKeeper,
-		stakingKeeper: stakingKeeper,
-		posKeeper:     posKeeper,
+		storeKey:       storeKey,
+		cdc:            cdc,
+		paramstore:     ps,
+		bankKeeper:     bankKeeper,
+		stakingKeeper:  stakingKeeper,
+		slashingKeeper: slashingKeeper,
+		distrKeeper:    distrKeeper,
+		posKeeper:      posKeeper,
+		authority:      authority,
+		curves:         curves,
 	}
 }
 
+// GetAuthority returns the module's authority
+func (k Keeper) GetAuthority() string {
+	return k.authority
+}
+
 // Logger returns a module-specific logger.
 func (k Keeper) Logger(ctx sdk.Context) log.Logger {
 	return ctx.Logger().With("module", fmt.Sprintf("x/%s", types.ModuleName))
@@ -61,6 +89,21 @@ func (k *Keeper) SetHooks(h types.ServRewardsHooks) *Keeper {
 	return k
 }
 
+// RegisterRewardCurve makes a reward curve selectable through the RewardCurve parameter
+func (k *Keeper) RegisterRewardCurve(curve types.RewardCurve) *Keeper {
+	if _, exists := k.curves[curve.Name()]; exists {
+		panic(fmt.Sprintf("reward curve %s already registered", curve.Name()))
+	}
+	k.curves[curve.Name()] = curve
+	return k
+}
+
+// HasRewardCurve returns whether a reward curve is registered under name
+func (k Keeper) HasRewardCurve(name string) bool {
+	_, found := k.curves[name]
+	return found
+}
+
 // GetRewardMetrics returns the current reward metrics
 func (k Keeper) GetRewardMetrics(ctx sdk.Context) types.RewardMetrics {
 	store := ctx.KVStore(k.storeKey)
@@ -109,7 +152,7 @@ func (k Keeper) GetAccumulatedRewards(ctx sdk.Context, addr string) types.Accumu
 	if bz == nil {
 		return types.AccumulatedRewards{
 			Address:   addr,
-			Rewards:   sdk.ZeroInt(),
+			Rewards:   sdk.NewCoins(),
 			LastClaim: 0,
 		}
 	}
@@ -119,64 +162,81 @@ func (k Keeper) GetAccumulatedRewards(ctx sdk.Context, addr string) types.Accumu
 	return rewards
 }
 
-// SetAccumulatedRewards sets the accumulated rewards for an address
+// SetAccumulatedRewards sets the accumulated rewards for an address and keeps
+// the unclaimed rewards queue in sync
 func (k Keeper) SetAccumulatedRewards(ctx sdk.Context, rewards types.AccumulatedRewards) {
 	store := ctx.KVStore(k.storeKey)
 	key := types.GetAccumulatedRewardsKey(rewards.Address)
+	if bz := store.Get(key); bz != nil {
+		var old types.AccumulatedRewards
+		k.cdc.MustUnmarshal(bz, &old)
+		store.Delete(types.GetUnclaimedRewardsQueueKey(old.UnclaimedSince, old.Address))
+	}
+	if !rewards.Rewards.IsZero() {
+		store.Set(types.GetUnclaimedRewardsQueueKey(rewards.UnclaimedSince, rewards.Address), []byte{})
+	}
+
 	bz := k.cdc.MustMarshal(&rewards)
 	store.Set(key, bz)
 }
 
+// IterateAccumulatedRewards iterates over the accumulated rewards of all addresses
+func (k Keeper) IterateAccumulatedRewards(ctx sdk.Context, cb func(rewards types.AccumulatedRewards) (stop bool)) {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, types.AccumulatedRewardsPrefix)
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		var rewards types.AccumulatedRewards
+		k.cdc.MustUnmarshal(iterator.Value(), &rewards)
+		if cb(rewards) {
+			break
+		}
+	}
+}
+
 // CalculateRewards calculates rewards for an address based on service score and staking amount
-func (k Keeper) CalculateRewards(ctx sdk.Context, addr string) sdk.Int {
+func (k Keeper) CalculateRewards(ctx sdk.Context, addr string) sdk.Coins {
 	params := k.GetRewardParams(ctx)
+	return k.calculateRewards(ctx, addr, params.RewardPerEpoch)
+}
+
+// calculateRewards calculates the share of epochReward earned by an address in every reward denom
+func (k Keeper) calculateRewards(ctx sdk.Context, addr string, epochReward sdk.Coins) sdk.Coins {
 	metrics := k.GetRewardMetrics(ctx)
-	
-	// Get service score from proof of service module
-	serviceScore := k.posKeeper.GetServiceScore(ctx, addr)
-	
-	// Get staking amount from staking module
-	stakingAmount := k.stakingKeeper.GetDelegatorStake(ctx, sdk.MustAccAddressFromBech32(addr))
-	
-	// Calculate rewards based on service score and staking amount
-	serviceReward := sdk.NewDecFromInt(params.RewardPerEpoch).
-		Mul(params.ServiceScoreWeight).
-		Mul(sdk.NewDecFromInt(serviceScore)).
-		Quo(sdk.NewDecFromInt(metrics.TotalServiceScore))
-	
-	stakingReward := sdk.NewDecFromInt(params.RewardPerEpoch).
-		Mul(params.StakingWeight).
-		Mul(sdk.NewDecFromInt(stakingAmount)).
-		Quo(sdk.NewDecFromInt(metrics.TotalStaked))
-	
-	totalReward := serviceReward.Add(stakingReward).TruncateInt()
-	
-	return totalReward
+	score := k.posKeeper.GetServiceScore(ctx, addr)
+	stake := k.stakingKeeper.GetDelegatorStake(ctx, sdk.MustAccAddressFromBech32(addr))
+
+	return k.rewardBreakdown(ctx, addr, epochReward, score, stake, metrics.TotalServiceScore, metrics.TotalStaked).Reward
 }
 
-// ClaimRewards claims accumulated rewards for an address
-func (k Keeper) ClaimRewards(ctx sdk.Context, addr string) (sdk.Int, error) {
+// ClaimRewards claims accumulated rewards for an address. When vesting is
+// enabled the locked fraction of the claim starts vesting instead of being
+// paid out, and whatever earlier claims have unlocked since is paid out with
+// it. The payout is sent to the reward withdraw address of addr. It returns
+// the amount paid out.
+func (k Keeper) ClaimRewards(ctx sdk.Context, addr string) (sdk.Coins, error) {
+	recipient := k.GetRewardWithdrawAddress(ctx, sdk.MustAccAddressFromBech32(addr))
+	return k.claimRewards(ctx, addr, recipient)
+}
+
+// claimRewards claims accumulated rewards for an address and pays them out to recipient
+func (k Keeper) claimRewards(ctx sdk.Context, addr string, recipient sdk.AccAddress) (sdk.Coins, error) {
 	rewards := k.GetAccumulatedRewards(ctx, addr)
 	metrics := k.GetRewardMetrics(ctx)
 	
 	// Check if rewards have already been claimed for this epoch
 	if rewards.LastClaim == metrics.EpochNumber {
-		return sdk.ZeroInt(), fmt.Errorf("rewards already claimed for this epoch")
-	}
-	
-	// Mint coins to the address
-	coins := sdk.NewCoins(sdk.NewCoin("serv", rewards.Rewards))
-	if err := k.bankKeeper.MintCoins(ctx, types.ModuleName, coins); err != nil {
-		return sdk.ZeroInt(), err
+		return sdk.NewCoins(), fmt.Errorf("rewards already claimed for this epoch")
 	}
 	
-	if err := k.bankKeeper.SendCoinsFromModuleToAccount(ctx, types.ModuleName, sdk.MustAccAddressFromBech32(addr), coins); err != nil {
-		return sdk.ZeroInt(), err
+	payout, locked, unlocked, err := k.payRewards(ctx, addr, recipient, rewards.Rewards)
+	if err != nil {
+		return sdk.NewCoins(), err
 	}
 	
 	// Update accumulated rewards
-	claimedAmount := rewards.Rewards
-	rewards.Rewards = sdk.ZeroInt()
+	rewards.Rewards = sdk.NewCoins()
 	rewards.LastClaim = metrics.EpochNumber
 	k.SetAccumulatedRewards(ctx, rewards)
 	
@@ -185,12 +245,43 @@ func (k Keeper) ClaimRewards(ctx sdk.Context, addr string) (sdk.Int, error) {
 		sdk.NewEvent(
 			types.EventTypeRewardClaimed,
 			sdk.NewAttribute(types.AttributeKeyAddress, addr),
-			sdk.NewAttribute(types.AttributeKeyAmount, claimedAmount.String()),
+			sdk.NewAttribute(types.AttributeKeyWithdrawAddress, recipient.String()),
+			sdk.NewAttribute(types.AttributeKeyAmount, payout.String()),
+			sdk.NewAttribute(types.AttributeKeyLocked, locked.String()),
+			sdk.NewAttribute(types.AttributeKeyUnlocked, unlocked.String()),
 			sdk.NewAttribute(types.AttributeKeyEpoch, fmt.Sprintf("%d", metrics.EpochNumber)),
 		),
 	)
 	
-	return claimedAmount, nil
+	return payout, nil
+}
+
+// payRewards pays out rewards claimed by addr to recipient out of the funds
+// already allocated in the reward pool. The locked fraction of the claim starts
+// vesting instead, and whatever earlier claims of addr have unlocked since is
+// paid out with it.
+func (k Keeper) payRewards(ctx sdk.Context, addr string, recipient sdk.AccAddress, claimed sdk.Coins) (payout, locked, unlocked sdk.Coins, err error) {
+	pool := k.GetRewardPool(ctx)
+	if err := subOutstanding(&pool, claimed); err != nil {
+		return sdk.NewCoins(), sdk.NewCoins(), sdk.NewCoins(), err
+	}
+	
+	locked = k.GetRewardParams(ctx).Vesting.LockedAmount(claimed)
+	unlocked = k.releaseVestedRewards(ctx, addr)
+	payout = claimed.Sub(locked...).Add(unlocked...)
+	
+	pool.Vesting = pool.Vesting.Add(locked...)
+	if err := subVesting(&pool, unlocked); err != nil {
+		return sdk.NewCoins(), sdk.NewCoins(), sdk.NewCoins(), err
+	}
+	
+	if err := k.bankKeeper.SendCoinsFromModuleToAccount(ctx, types.ModuleName, recipient, payout); err != nil {
+		return sdk.NewCoins(), sdk.NewCoins(), sdk.NewCoins(), err
+	}
+	k.lockRewards(ctx, addr, locked)
+	k.SetRewardPool(ctx, pool)
+	
+	return payout, locked, unlocked, nil
 }
 
 // UpdateRewards updates accumulated rewards for all addresses at the end of an epoch
@@ -205,6 +296,32 @@ func (k Keeper) UpdateRewards(ctx sdk.Context) {
 	// Update metrics
 	k.SetRewardMetrics(ctx, metrics)
 	
+	// Sweep rewards left unclaimed for too long, then top up the reward pool
+	// and allocate this epoch's rewards out of it
+	k.ExpireUnclaimedRewards(ctx, metrics.EpochNumber)
+	k.FundRewardPool(ctx)
+	allocated, participants := k.AllocateEpochRewards(ctx)
+	
+	// Record the epoch and drop earnings that fell out of the retention window
+	record := types.EpochRewardRecord{
+		EpochNumber:       metrics.EpochNumber,
+		Height:            ctx.BlockHeight(),
+		Time:              ctx.BlockTime(),
+		TotalServiceScore: metrics.TotalServiceScore,
+		TotalStaked:       metrics.TotalStaked,
+		TotalDistributed:  allocated,
+		Participants:      participants,
+		Params:            k.GetRewardParams(ctx),
+		Remainder:         k.GetRewardPool(ctx).Remainder,
+	}
+	if err := k.SetEpochRewardRecord(ctx, record); err != nil {
+		k.Logger(ctx).Error("failed to record epoch rewards", "epoch", metrics.EpochNumber, "err", err)
+	}
+	k.PruneAddressEpochRewards(ctx, metrics.EpochNumber)
+	
+	// Compound the rewards of opted-in addresses over the following blocks
+	k.StartAutoCompound(ctx)
+	
 	// Emit event
 	ctx.EventManager().EmitEvent(
 		sdk.NewEvent(
@@ -0,0 +1,146 @@
+package keeper
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	"github.com/serv-chain/serv/x/servrewards/types"
+)
+
+// Migrator is a struct for handling in-place store migrations.
+type Migrator struct {
+	keeper Keeper
+}
+
+// NewMigrator returns a new Migrator.
+func NewMigrator(keeper Keeper) Migrator {
+	return Migrator{keeper: keeper}
+}
+
+// Migrate1to2 migrates the store from consensus version 1 to 2. Rewards used
+// to be a single Int of DefaultRewardDenom, so the reward parameters and the
+// accumulated rewards of every address are decoded in their legacy encoding
+// and rewritten as coins. Parameters added since take their defaults.
+//
+// Rewards also used to be minted on claim, so balances accumulated before the
+// reward pool were never counted as outstanding nor held by the module
+// account. The migration seeds the outstanding balance of the pool with every
+// reward owed, and mints whatever the module account lacks to pay them out,
+// which is what their claims would have minted.
+func (m Migrator) Migrate1to2(ctx sdk.Context) error {
+	if err := m.migrateRewardParams(ctx); err != nil {
+		return err
+	}
+	if err := m.migrateAccumulatedRewards(ctx); err != nil {
+		return err
+	}
+
+	return m.seedOutstandingRewards(ctx)
+}
+
+// migrateRewardParams rewrites the legacy reward parameters, if any were set
+func (m Migrator) migrateRewardParams(ctx sdk.Context) error {
+	k := m.keeper
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(types.RewardParamsKey)
+	if bz == nil {
+		return nil
+	}
+
+	var legacy types.LegacyRewardParams
+	if err := k.cdc.Unmarshal(bz, &legacy); err != nil {
+		return fmt.Errorf("failed to decode legacy reward params: %w", err)
+	}
+
+	params := legacy.Migrate()
+	if err := params.Validate(); err != nil {
+		return fmt.Errorf("invalid migrated reward params: %w", err)
+	}
+	k.SetRewardParams(ctx, params)
+	return nil
+}
+
+// migrateAccumulatedRewards rewrites the legacy accumulated rewards of every
+// address. They are aged from the current epoch, as v1 did not record when
+// they were credited.
+func (m Migrator) migrateAccumulatedRewards(ctx sdk.Context) error {
+	k := m.keeper
+	store := ctx.KVStore(k.storeKey)
+	epoch := k.GetRewardMetrics(ctx).EpochNumber
+
+	var legacyRewards []types.LegacyAccumulatedRewards
+	iterator := sdk.KVStorePrefixIterator(store, types.AccumulatedRewardsPrefix)
+	for ; iterator.Valid(); iterator.Next() {
+		var legacy types.LegacyAccumulatedRewards
+		if err := k.cdc.Unmarshal(iterator.Value(), &legacy); err != nil {
+			iterator.Close()
+			return fmt.Errorf("failed to decode legacy accumulated rewards: %w", err)
+		}
+		legacyRewards = append(legacyRewards, legacy)
+	}
+	iterator.Close()
+
+	for _, legacy := range legacyRewards {
+		// The legacy record is removed first, SetAccumulatedRewards decodes the one it replaces
+		store.Delete(types.GetAccumulatedRewardsKey(legacy.Address))
+		k.SetAccumulatedRewards(ctx, legacy.Migrate(epoch))
+	}
+
+	return nil
+}
+
+// seedOutstandingRewards counts the rewards owed to addresses and delegators
+// as outstanding in the reward pool, which consensus version 1 did not have
+func (m Migrator) seedOutstandingRewards(ctx sdk.Context) error {
+	k := m.keeper
+
+	owed := sdk.NewCoins()
+	k.IterateAccumulatedRewards(ctx, func(rewards types.AccumulatedRewards) bool {
+		owed = owed.Add(rewards.Rewards...)
+		return false
+	})
+	k.IterateDelegatorRewardsPools(ctx, func(pool types.DelegatorRewardsPool) bool {
+		owed = owed.Add(pool.Outstanding...)
+		return false
+	})
+
+	pool := k.GetRewardPool(ctx)
+	seeded := sdk.NewCoins()
+	for _, coin := range owed {
+		missing := coin.Amount.Sub(pool.Outstanding.AmountOf(coin.Denom))
+		if missing.IsPositive() {
+			seeded = seeded.Add(sdk.NewCoin(coin.Denom, missing))
+		}
+	}
+	if seeded.IsZero() {
+		return nil
+	}
+	pool.Outstanding = pool.Outstanding.Add(seeded...)
+
+	// The module account must hold everything the pool accounts for
+	moduleAddr := authtypes.NewModuleAddress(types.ModuleName)
+	unbacked := sdk.NewCoins()
+	for _, coin := range seeded {
+		held := k.bankKeeper.GetBalance(ctx, moduleAddr, coin.Denom).Amount
+		tracked := pool.Available.AmountOf(coin.Denom).
+			Add(pool.Outstanding.AmountOf(coin.Denom)).
+			Add(pool.Vesting.AmountOf(coin.Denom))
+		if lacking := tracked.Sub(held); lacking.IsPositive() {
+			unbacked = unbacked.Add(sdk.NewCoin(coin.Denom, sdk.MinInt(lacking, coin.Amount)))
+		}
+	}
+	if !unbacked.IsZero() {
+		if err := k.bankKeeper.MintCoins(ctx, types.ModuleName, unbacked); err != nil {
+			return err
+		}
+	}
+
+	k.SetRewardPool(ctx, pool)
+
+	k.Logger(ctx).Info("Seeded outstanding rewards of the reward pool",
+		"seeded", seeded,
+		"minted", unbacked)
+
+	return nil
+}
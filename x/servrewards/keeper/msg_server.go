@@ -54,63 +54,197 @@ func (m msgServer) ClaimReward(goCtx context.Context, msg *types.MsgClaimReward)
 func (m msgServer) UpdateRewardParams(goCtx context.Context, msg *types.MsgUpdateRewardParams) (*types.MsgUpdateRewardParamsResponse, error) {
 	ctx := sdk.UnwrapSDKContext(goCtx)
 
-	// Ensure the message sender has the authority to update parameters
-	// In a real implementation, this would check if the sender is the governance module account
-	// or has another appropriate permission
-	authority, err := sdk.AccAddressFromBech32(msg.Authority)
+	if m.authority != msg.Authority {
+		return nil, sdkerrors.Wrapf(govtypes.ErrInvalidSigner, "invalid authority; expected %s, got %s", m.authority, msg.Authority)
+	}
+
+	// Validate parameters
+	if err := msg.Params.Validate(); err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, err.Error())
+	}
+
+	if msg.Params.RewardCurve != "" && !m.Keeper.HasRewardCurve(msg.Params.RewardCurve) {
+		return nil, sdkerrors.Wrapf(sdkerrors.ErrInvalidRequest, "unknown reward curve: %q", msg.Params.RewardCurve)
+	}
+
+	// Update parameters
+	m.Keeper.SetRewardParams(ctx, msg.Params)
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.ModuleName),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Authority),
+			sdk.NewAttribute(types.AttributeKeyServiceScoreWeight, msg.Params.ServiceScoreWeight.String()),
+			sdk.NewAttribute(types.AttributeKeyStakingWeight, msg.Params.StakingWeight.String()),
+			sdk.NewAttribute(types.AttributeKeyRewardPerEpoch, msg.Params.RewardPerEpoch.String()),
+			sdk.NewAttribute(types.AttributeKeyEpochIdentifier, msg.Params.EpochIdentifier),
+			sdk.NewAttribute(types.AttributeKeyRewardSource, msg.Params.RewardSource),
+		),
+	})
+
+	return &types.MsgUpdateRewardParamsResponse{}, nil
+}
+
+// ClaimAndDelegate implements the MsgServer.ClaimAndDelegate method.
+func (m msgServer) ClaimAndDelegate(goCtx context.Context, msg *types.MsgClaimAndDelegate) (*types.MsgClaimAndDelegateResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	valAddr, err := sdk.ValAddressFromBech32(msg.ValidatorAddress)
 	if err != nil {
-		return nil, sdkerrors.Wrapf(sdkerrors.ErrInvalidAddress, "invalid authority address: %s", err)
+		return nil, sdkerrors.Wrapf(sdkerrors.ErrInvalidAddress, "invalid validator address: %s", err)
 	}
 
-	// Check if the authority is the governance module account
-	govModuleAddr := govtypes.NewModuleAddress(govtypes.ModuleName)
-	if !authority.Equals(govModuleAddr) {
-		return nil, sdkerrors.Wrap(sdkerrors.ErrUnauthorized, "only the governance module can update reward parameters")
+	amount, delegated, err := m.Keeper.ClaimAndDelegate(ctx, msg.Delegator, valAddr)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, err.Error())
 	}
 
-	// Validate parameters
-	if msg.ServiceScoreWeight.IsNegative() {
-		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "service score weight cannot be negative")
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.ModuleName),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Delegator),
+			sdk.NewAttribute(types.AttributeKeyAmount, amount.String()),
+			sdk.NewAttribute(types.AttributeKeyDelegated, delegated.String()),
+		),
+	})
+
+	return &types.MsgClaimAndDelegateResponse{
+		Amount:    amount,
+		Delegated: delegated,
+	}, nil
+}
+
+// SetAutoCompound implements the MsgServer.SetAutoCompound method.
+func (m msgServer) SetAutoCompound(goCtx context.Context, msg *types.MsgSetAutoCompound) (*types.MsgSetAutoCompoundResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	if msg.Enabled {
+		valAddr, err := sdk.ValAddressFromBech32(msg.ValidatorAddress)
+		if err != nil {
+			return nil, sdkerrors.Wrapf(sdkerrors.ErrInvalidAddress, "invalid validator address: %s", err)
+		}
+		if _, found := m.stakingKeeper.GetValidator(ctx, valAddr); !found {
+			return nil, sdkerrors.Wrapf(sdkerrors.ErrInvalidRequest, "validator %s not found", msg.ValidatorAddress)
+		}
+
+		m.Keeper.SetAutoCompound(ctx, types.AutoCompoundSetting{
+			Address:   msg.Delegator,
+			Validator: msg.ValidatorAddress,
+		})
+	} else {
+		m.Keeper.DeleteAutoCompound(ctx, msg.Delegator)
+	}
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.ModuleName),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Delegator),
+			sdk.NewAttribute(types.AttributeKeyValidator, msg.ValidatorAddress),
+			sdk.NewAttribute(types.AttributeKeyEnabled, fmt.Sprintf("%t", msg.Enabled)),
+		),
+	})
+
+	return &types.MsgSetAutoCompoundResponse{}, nil
+}
+
+// SetRewardWithdrawAddress implements the MsgServer.SetRewardWithdrawAddress method.
+func (m msgServer) SetRewardWithdrawAddress(goCtx context.Context, msg *types.MsgSetRewardWithdrawAddress) (*types.MsgSetRewardWithdrawAddressResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	addr, err := sdk.AccAddressFromBech32(msg.Address)
+	if err != nil {
+		return nil, sdkerrors.Wrapf(sdkerrors.ErrInvalidAddress, "invalid address: %s", err)
+	}
+
+	withdrawAddr, err := sdk.AccAddressFromBech32(msg.WithdrawAddress)
+	if err != nil {
+		return nil, sdkerrors.Wrapf(sdkerrors.ErrInvalidAddress, "invalid withdraw address: %s", err)
 	}
 
-	if msg.StakingWeight.IsNegative() {
-		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "staking weight cannot be negative")
+	if err := m.Keeper.SetRewardWithdrawAddress(ctx, addr, withdrawAddr); err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrUnauthorized, err.Error())
 	}
 
-	if msg.RewardPerEpoch.IsNegative() {
-		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "reward per epoch cannot be negative")
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.ModuleName),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Address),
+		),
+	})
+
+	return &types.MsgSetRewardWithdrawAddressResponse{}, nil
+}
+
+// SetProviderCommission implements the MsgServer.SetProviderCommission method.
+func (m msgServer) SetProviderCommission(goCtx context.Context, msg *types.MsgSetProviderCommission) (*types.MsgSetProviderCommissionResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	if err := m.Keeper.UpdateProviderCommission(ctx, msg.Provider, msg.Rate, msg.MaxRate, msg.MaxChangeRate); err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, err.Error())
 	}
 
-	if msg.EpochDuration == 0 {
-		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "epoch duration must be positive")
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.ModuleName),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Provider),
+		),
+	})
+
+	return &types.MsgSetProviderCommissionResponse{}, nil
+}
+
+// ClaimDelegatorRewards implements the MsgServer.ClaimDelegatorRewards method.
+func (m msgServer) ClaimDelegatorRewards(goCtx context.Context, msg *types.MsgClaimDelegatorRewards) (*types.MsgClaimDelegatorRewardsResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	delegator, err := sdk.AccAddressFromBech32(msg.Delegator)
+	if err != nil {
+		return nil, sdkerrors.Wrapf(sdkerrors.ErrInvalidAddress, "invalid delegator address: %s", err)
 	}
 
-	// Ensure weights sum to 1
-	sumWeights := msg.ServiceScoreWeight.Add(msg.StakingWeight)
-	if !sumWeights.Equal(sdk.NewDec(1)) {
-		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, fmt.Sprintf("service score weight and staking weight must sum to 1, got: %s", sumWeights))
+	amount, err := m.Keeper.ClaimDelegatorRewards(ctx, delegator, msg.Provider)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, err.Error())
 	}
 
-	// Update parameters
-	params := types.RewardParams{
-		ServiceScoreWeight: msg.ServiceScoreWeight,
-		StakingWeight:      msg.StakingWeight,
-		RewardPerEpoch:     msg.RewardPerEpoch,
-		EpochDuration:      msg.EpochDuration,
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.ModuleName),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Delegator),
+			sdk.NewAttribute(types.AttributeKeyAmount, amount.String()),
+		),
+	})
+
+	return &types.MsgClaimDelegatorRewardsResponse{
+		Amount: amount,
+	}, nil
+}
+
+// ReleaseVestedRewards implements the MsgServer.ReleaseVestedRewards method.
+func (m msgServer) ReleaseVestedRewards(goCtx context.Context, msg *types.MsgReleaseVestedRewards) (*types.MsgReleaseVestedRewardsResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	amount, err := m.Keeper.ReleaseVestedRewards(ctx, msg.Address)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, err.Error())
 	}
-	m.Keeper.SetRewardParams(ctx, params)
 
 	ctx.EventManager().EmitEvents(sdk.Events{
 		sdk.NewEvent(
 			sdk.EventTypeMessage,
 			sdk.NewAttribute(sdk.AttributeKeyModule, types.ModuleName),
-			sdk.NewAttribute(sdk.AttributeKeySender, msg.Authority),
-			sdk.NewAttribute(types.AttributeKeyServiceScoreWeight, msg.ServiceScoreWeight.String()),
-			sdk.NewAttribute(types.AttributeKeyStakingWeight, msg.StakingWeight.String()),
-			sdk.NewAttribute(types.AttributeKeyRewardPerEpoch, msg.RewardPerEpoch.String()),
-			sdk.NewAttribute(types.AttributeKeyEpochDuration, fmt.Sprintf("%d", msg.EpochDuration)),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Address),
+			sdk.NewAttribute(types.AttributeKeyAmount, amount.String()),
 		),
 	})
 
-	return &types.MsgUpdateRewardParamsResponse{}, nil
+	return &types.MsgReleaseVestedRewardsResponse{
+		Amount: amount,
+	}, nil
 }
@@ -0,0 +1,326 @@
+package keeper
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	"github.com/serv-chain/serv/x/servrewards/types"
+)
+
+// GetRewardPool returns the current reward pool
+func (k Keeper) GetRewardPool(ctx sdk.Context) types.RewardPool {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(types.RewardPoolKey)
+	if bz == nil {
+		return types.DefaultRewardPool()
+	}
+
+	var pool types.RewardPool
+	k.cdc.MustUnmarshal(bz, &pool)
+	return pool
+}
+
+// SetRewardPool sets the current reward pool
+func (k Keeper) SetRewardPool(ctx sdk.Context, pool types.RewardPool) {
+	store := ctx.KVStore(k.storeKey)
+	bz := k.cdc.MustMarshal(&pool)
+	store.Set(types.RewardPoolKey, bz)
+}
+
+// subOutstanding removes amount from the outstanding balance of the pool. It
+// fails rather than going negative, which would mean the pool no longer
+// accounts for the rewards it owes.
+func subOutstanding(pool *types.RewardPool, amount sdk.Coins) error {
+	outstanding, hasNeg := pool.Outstanding.SafeSub(amount...)
+	if hasNeg {
+		return fmt.Errorf("rewards %s exceed outstanding rewards %s", amount, pool.Outstanding)
+	}
+
+	pool.Outstanding = outstanding
+	return nil
+}
+
+// subVesting removes amount from the vesting balance of the pool. It fails
+// rather than going negative, which would mean the pool no longer accounts for
+// the rewards it escrows.
+func subVesting(pool *types.RewardPool, amount sdk.Coins) error {
+	vesting, hasNeg := pool.Vesting.SafeSub(amount...)
+	if hasNeg {
+		return fmt.Errorf("released rewards %s exceed vesting rewards %s", amount, pool.Vesting)
+	}
+
+	pool.Vesting = vesting
+	return nil
+}
+
+// releaseOutstanding removes amount from the outstanding balance of the pool
+// outside the claim path, where failing would halt the chain. Outstanding
+// rewards that no longer cover amount are logged and floored at zero.
+func (k Keeper) releaseOutstanding(ctx sdk.Context, pool *types.RewardPool, amount sdk.Coins) {
+	if err := subOutstanding(pool, amount); err != nil {
+		k.Logger(ctx).Error("reward pool outstanding rewards drifted", "err", err)
+		pool.Outstanding = pool.Outstanding.Sub(amount.Min(pool.Outstanding)...)
+	}
+}
+
+// CollectFeeShare diverts the configured share of the fees collected in this
+// block from the fee collector into the reward pool. Only reward denoms are
+// diverted. It must run before the distribution module allocates the fee
+// collector balance.
+func (k Keeper) CollectFeeShare(ctx sdk.Context) error {
+	params := k.GetRewardParams(ctx)
+	if params.RewardSource != types.RewardSourceFeeShare || !params.FeeShare.IsPositive() {
+		return nil
+	}
+
+	feeCollector := authtypes.NewModuleAddress(authtypes.FeeCollectorName)
+	share := sdk.NewCoins()
+	for _, coin := range params.RewardPerEpoch {
+		fees := k.bankKeeper.GetBalance(ctx, feeCollector, coin.Denom)
+		amount := sdk.NewDecFromInt(fees.Amount).Mul(params.FeeShare).TruncateInt()
+		share = share.Add(sdk.NewCoin(coin.Denom, amount))
+	}
+
+	if share.IsZero() {
+		return nil
+	}
+
+	if err := k.bankKeeper.SendCoinsFromModuleToModule(ctx, authtypes.FeeCollectorName, types.ModuleName, share); err != nil {
+		return err
+	}
+
+	pool := k.GetRewardPool(ctx)
+	pool.Available = pool.Available.Add(share...)
+	k.SetRewardPool(ctx, pool)
+
+	return nil
+}
+
+// FundRewardPool tops up the available balance of the reward pool towards the
+// emission of the current epoch, on top of the remainder carried over from the
+// previous epoch, from the configured reward source. Sources never provide
+// more than they hold, so an epoch may end up funded below its emission.
+func (k Keeper) FundRewardPool(ctx sdk.Context) {
+	params := k.GetRewardParams(ctx)
+	pool := k.GetRewardPool(ctx)
+	target := k.EpochEmission(ctx, k.GetRewardMetrics(ctx).EpochNumber).Add(pool.Remainder...)
+
+	var funded sdk.Coins
+	switch params.RewardSource {
+	case types.RewardSourceMint:
+		funded = k.fundFromMint(ctx, params, target, pool)
+	case types.RewardSourceCommunityPool:
+		funded = k.fundFromCommunityPool(ctx, target, pool)
+	case types.RewardSourceModuleAccount:
+		funded = k.fundFromModuleAccount(ctx, target, pool)
+	default:
+		// fee_share funds the pool every block in CollectFeeShare
+		funded = sdk.NewCoins()
+	}
+
+	if funded.IsZero() {
+		return
+	}
+
+	pool.Available = pool.Available.Add(funded...)
+	k.SetRewardPool(ctx, pool)
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			types.EventTypeRewardPoolFunded,
+			sdk.NewAttribute(types.AttributeKeyRewardSource, params.RewardSource),
+			sdk.NewAttribute(types.AttributeKeyAmount, funded.String()),
+			sdk.NewAttribute(types.AttributeKeyAvailable, pool.Available.String()),
+		),
+	)
+}
+
+// shortfall returns how much of denom the pool lacks to pay the target emission
+func shortfall(target sdk.Coins, pool types.RewardPool, denom string) sdk.Int {
+	return target.AmountOf(denom).Sub(pool.Available.AmountOf(denom))
+}
+
+// fundFromMint mints the shortfall of the pool in MintDenom, limited to
+// InflationCap of its current supply. Other reward denoms, such as IBC
+// vouchers, are never minted and must be funded by sending them to the module.
+func (k Keeper) fundFromMint(ctx sdk.Context, params types.RewardParams, target sdk.Coins, pool types.RewardPool) sdk.Coins {
+	needed := shortfall(target, pool, params.MintDenom)
+	if !needed.IsPositive() {
+		return sdk.NewCoins()
+	}
+
+	supply := k.bankKeeper.GetSupply(ctx, params.MintDenom).Amount
+	maxMint := sdk.NewDecFromInt(supply).Mul(params.InflationCap).TruncateInt()
+	amount := sdk.MinInt(needed, maxMint)
+	if !amount.IsPositive() {
+		return sdk.NewCoins()
+	}
+
+	coins := sdk.NewCoins(sdk.NewCoin(params.MintDenom, amount))
+	if err := k.bankKeeper.MintCoins(ctx, types.ModuleName, coins); err != nil {
+		k.Logger(ctx).Error("failed to mint rewards", "amount", coins, "err", err)
+		return sdk.NewCoins()
+	}
+
+	return coins
+}
+
+// fundFromCommunityPool withdraws the shortfall of the pool from the community pool
+func (k Keeper) fundFromCommunityPool(ctx sdk.Context, target sdk.Coins, pool types.RewardPool) sdk.Coins {
+	communityPool := k.distrKeeper.GetFeePoolCommunityCoins(ctx)
+
+	coins := sdk.NewCoins()
+	for _, coin := range target {
+		needed := shortfall(target, pool, coin.Denom)
+		if !needed.IsPositive() {
+			continue
+		}
+
+		available := communityPool.AmountOf(coin.Denom).TruncateInt()
+		coins = coins.Add(sdk.NewCoin(coin.Denom, sdk.MinInt(needed, available)))
+	}
+
+	if coins.IsZero() {
+		return coins
+	}
+
+	moduleAddr := authtypes.NewModuleAddress(types.ModuleName)
+	if err := k.distrKeeper.DistributeFromFeePool(ctx, coins, moduleAddr); err != nil {
+		k.Logger(ctx).Error("failed to withdraw rewards from community pool", "amount", coins, "err", err)
+		return sdk.NewCoins()
+	}
+
+	return coins
+}
+
+// fundFromModuleAccount makes anything the module account holds beyond what is
+// already in the pool available for allocation
+func (k Keeper) fundFromModuleAccount(ctx sdk.Context, target sdk.Coins, pool types.RewardPool) sdk.Coins {
+	moduleAddr := authtypes.NewModuleAddress(types.ModuleName)
+
+	coins := sdk.NewCoins()
+	for _, coin := range target {
+		balance := k.bankKeeper.GetBalance(ctx, moduleAddr, coin.Denom).Amount
+		deposited := balance.Sub(pool.Outstanding.AmountOf(coin.Denom)).
+			Sub(pool.Vesting.AmountOf(coin.Denom)).
+			Sub(pool.Available.AmountOf(coin.Denom))
+		if deposited.IsPositive() {
+			coins = coins.Add(sdk.NewCoin(coin.Denom, deposited))
+		}
+	}
+
+	return coins
+}
+
+// participants returns every service provider with its score and stake
+func (k Keeper) participants(ctx sdk.Context) []types.Participant {
+	var participants []types.Participant
+	k.posKeeper.IterateServiceScores(ctx, func(provider string, score sdk.Int) bool {
+		participants = append(participants, types.Participant{
+			Address:      provider,
+			ServiceScore: score,
+			Stake:        k.stakingKeeper.GetDelegatorStake(ctx, sdk.MustAccAddressFromBech32(provider)),
+		})
+		return false
+	})
+
+	return participants
+}
+
+// rewardCurve returns the reward curve selected by params. Curves that are
+// not registered, such as one removed in an upgrade, fall back to linear.
+func (k Keeper) rewardCurve(ctx sdk.Context, params types.RewardParams) types.RewardCurve {
+	if params.RewardCurve == "" {
+		return types.LinearCurve{}
+	}
+
+	curve, found := k.curves[params.RewardCurve]
+	if !found {
+		k.Logger(ctx).Error("unknown reward curve, falling back to linear", "curve", params.RewardCurve)
+		return types.LinearCurve{}
+	}
+
+	return curve
+}
+
+// AllocateEpochRewards credits every service provider with its share of the
+// epoch's budget. The budget is the epoch's emission, limited per denom to what
+// the pool has available beyond the remainder, plus the remainder carried over
+// from the previous epoch. Whatever is not distributed, such as the shares of
+// stake not held by providers and the dust lost to truncation, becomes the
+// remainder of the next epoch, so that over the lifetime of the module the
+// total distributed plus the remainder equals the total emitted. It returns
+// the total allocated and the number of addresses credited.
+func (k Keeper) AllocateEpochRewards(ctx sdk.Context) (sdk.Coins, uint64) {
+	metrics := k.GetRewardMetrics(ctx)
+	pool := k.GetRewardPool(ctx)
+
+	// The remainder is part of the available balance, so only the rest can be emitted
+	pool.Remainder = pool.Remainder.Min(pool.Available)
+	emitted := k.EpochEmission(ctx, metrics.EpochNumber).Min(pool.Available.Sub(pool.Remainder...))
+	budget := emitted.Add(pool.Remainder...)
+	if budget.IsZero() {
+		return sdk.NewCoins(), 0
+	}
+
+	// Shares of an empty total are zero, so an epoch without service or stake
+	// carries its whole budget over
+	params := k.GetRewardParams(ctx)
+	providers := k.participants(ctx)
+	shares := k.rewardCurve(ctx, params).Shares(params, providers, metrics.TotalServiceScore, metrics.TotalStaked)
+
+	allocated := sdk.NewCoins()
+	participants := uint64(0)
+	for i, p := range providers {
+		provider := p.Address
+
+		// Totals that lag behind the scores and stakes must not overdraw the budget
+		reward := types.ShareOfBudget(budget, shares[i]).Min(budget.Sub(allocated...))
+		if reward.IsZero() {
+			continue
+		}
+
+		allocated = allocated.Add(reward...)
+		participants++
+
+		// Providers with a commission share the rest with their delegators
+		credited := k.shareProviderReward(ctx, provider, reward)
+
+		// Clawback debts are repaid first, the repaid rewards stay in the budget
+		// and are carried over with the remainder
+		repaid := k.repayClawbackDebt(ctx, provider, credited)
+		allocated = allocated.Sub(repaid...)
+		credited = credited.Sub(repaid...)
+		if credited.IsZero() {
+			continue
+		}
+
+		rewards := k.GetAccumulatedRewards(ctx, provider)
+		if rewards.Rewards.IsZero() {
+			rewards.UnclaimedSince = metrics.EpochNumber
+		}
+		rewards.Rewards = rewards.Rewards.Add(credited...)
+		k.SetAccumulatedRewards(ctx, rewards)
+		k.recordAddressEpochReward(ctx, provider, metrics.EpochNumber, credited)
+	}
+
+	pool.Available = pool.Available.Sub(allocated...)
+	pool.Outstanding = pool.Outstanding.Add(allocated...)
+	pool.Remainder = budget.Sub(allocated...)
+	k.SetRewardPool(ctx, pool)
+	k.recordEmission(ctx, emitted)
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			types.EventTypeRewardsAllocated,
+			sdk.NewAttribute(types.AttributeKeyEpoch, fmt.Sprintf("%d", metrics.EpochNumber)),
+			sdk.NewAttribute(types.AttributeKeyAmount, allocated.String()),
+			sdk.NewAttribute(types.AttributeKeyParticipants, fmt.Sprintf("%d", participants)),
+			sdk.NewAttribute(types.AttributeKeyAvailable, pool.Available.String()),
+			sdk.NewAttribute(types.AttributeKeyRemainder, pool.Remainder.String()),
+		),
+	)
+
+	return allocated, participants
+}
@@ -1,25 +1,46 @@
 package test
 
 import (
+	"fmt"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/require"
 	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	"pgregory.net/rapid"
 
+	"github.com/cosmos/cosmos-sdk/crypto/keys/ed25519"
+	storetypes "github.com/cosmos/cosmos-sdk/store/types"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/types/module"
 	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
 	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+	epochstypes "github.com/serv-chain/serv/x/epochs/types"
 	"github.com/serv-chain/serv/x/servrewards/keeper"
 	"github.com/serv-chain/serv/x/servrewards/types"
 )
 
+// servCoins returns amount of the default reward denom as coins
+func servCoins(amount int64) sdk.Coins {
+	return sdk.NewCoins(sdk.NewInt64Coin(types.DefaultRewardDenom, amount))
+}
+
 // Setup initializes a test keeper with mock dependencies
-func Setup(t *testing.T) (*keeper.Keeper, sdk.Context, *MockBankKeeper, *MockStakingKeeper, *MockPosKeeper) {
+func Setup(t *testing.T) (*keeper.Keeper, sdk.Context, *MockBankKeeper, *MockStakingKeeper, *MockSlashingKeeper, *MockDistrKeeper, *MockPosKeeper) {
+	k, ctx, bankKeeper, stakingKeeper, slashingKeeper, distrKeeper, posKeeper, _ := SetupWithStoreKey(t)
+	return k, ctx, bankKeeper, stakingKeeper, slashingKeeper, distrKeeper, posKeeper
+}
+
+// SetupWithStoreKey initializes a test keeper with mock dependencies and
+// returns its store key, for tests that write the store directly
+func SetupWithStoreKey(t *testing.T) (*keeper.Keeper, sdk.Context, *MockBankKeeper, *MockStakingKeeper, *MockSlashingKeeper, *MockDistrKeeper, *MockPosKeeper, storetypes.StoreKey) {
 	// Initialize keepers
 	bankKeeper := NewMockBankKeeper()
 	stakingKeeper := NewMockStakingKeeper()
+	slashingKeeper := NewMockSlashingKeeper()
+	distrKeeper := NewMockDistrKeeper()
 	posKeeper := NewMockPosKeeper()
 
 	// Initialize codec
@@ -43,7 +64,10 @@ func Setup(t *testing.T) (*keeper.Keeper, sdk.Context, *MockBankKeeper, *MockSta
 		subspace,
 		bankKeeper,
 		stakingKeeper,
+		slashingKeeper,
+		distrKeeper,
 		posKeeper,
+		authtypes.NewModuleAddress(govtypes.ModuleName).String(),
 	)
 
 	// Create test context
@@ -57,12 +81,12 @@ func Setup(t *testing.T) (*keeper.Keeper, sdk.Context, *MockBankKeeper, *MockSta
 	// Initialize params
 	subspace.SetParamSet(ctx, &types.Params{})
 
-	return k, ctx, bankKeeper, stakingKeeper, posKeeper
+	return k, ctx, bankKeeper, stakingKeeper, slashingKeeper, distrKeeper, posKeeper, storeKey
 }
 
 // TestGetRewardMetrics tests the GetRewardMetrics function
 func TestGetRewardMetrics(t *testing.T) {
-	k, ctx, _, _, _ := Setup(t)
+	k, ctx, _, _, _, _, _ := Setup(t)
 
 	// Test default metrics
 	metrics := k.GetRewardMetrics(ctx)
@@ -83,7 +107,7 @@ func TestGetRewardMetrics(t *testing.T) {
 
 // TestGetRewardParams tests the GetRewardParams function
 func TestGetRewardParams(t *testing.T) {
-	k, ctx, _, _, _ := Setup(t)
+	k, ctx, _, _, _, _, _ := Setup(t)
 
 	// Test default params
 	params := k.GetRewardParams(ctx)
@@ -93,8 +117,8 @@ func TestGetRewardParams(t *testing.T) {
 	customParams := types.RewardParams{
 		ServiceScoreWeight: sdk.NewDecWithPrec(7, 1), // 0.7
 		StakingWeight:      sdk.NewDecWithPrec(3, 1), // 0.3
-		RewardPerEpoch:     sdk.NewInt(2000000),
-		EpochDuration:      200,
+		RewardPerEpoch:     servCoins(2000000),
+		EpochIdentifier:    "week",
 	}
 	k.SetRewardParams(ctx, customParams)
 
@@ -105,7 +129,7 @@ func TestGetRewardParams(t *testing.T) {
 
 // TestGetAccumulatedRewards tests the GetAccumulatedRewards function
 func TestGetAccumulatedRewards(t *testing.T) {
-	k, ctx, _, _, _ := Setup(t)
+	k, ctx, _, _, _, _, _ := Setup(t)
 
 	addr := "cosmos1abcdef"
 
@@ -118,7 +142,7 @@ func TestGetAccumulatedRewards(t *testing.T) {
 	// Set custom rewards
 	customRewards := types.AccumulatedRewards{
 		Address:   addr,
-		Rewards:   sdk.NewInt(1000),
+		Rewards:   servCoins(1000),
 		LastClaim: 5,
 	}
 	k.SetAccumulatedRewards(ctx, customRewards)
@@ -130,7 +154,7 @@ func TestGetAccumulatedRewards(t *testing.T) {
 
 // TestCalculateRewards tests the CalculateRewards function
 func TestCalculateRewards(t *testing.T) {
-	k, ctx, _, stakingKeeper, posKeeper := Setup(t)
+	k, ctx, _, stakingKeeper, _, _, posKeeper := Setup(t)
 
 	addr := "cosmos1abcdef"
 	addrAcc, _ := sdk.AccAddressFromBech32(addr)
@@ -139,8 +163,8 @@ func TestCalculateRewards(t *testing.T) {
 	params := types.RewardParams{
 		ServiceScoreWeight: sdk.NewDecWithPrec(6, 1), // 0.6
 		StakingWeight:      sdk.NewDecWithPrec(4, 1), // 0.4
-		RewardPerEpoch:     sdk.NewInt(1000),
-		EpochDuration:      100,
+		RewardPerEpoch:     servCoins(1000),
+		EpochIdentifier:    "day",
 	}
 	k.SetRewardParams(ctx, params)
 
@@ -162,12 +186,12 @@ func TestCalculateRewards(t *testing.T) {
 	// serviceReward = 1000 * 0.6 * 100 / 1000 = 60
 	// stakingReward = 1000 * 0.4 * 1000 / 10000 = 40
 	// totalReward = 60 + 40 = 100
-	require.Equal(t, sdk.NewInt(100), reward)
+	require.Equal(t, servCoins(100), reward)
 }
 
 // TestClaimRewards tests the ClaimRewards function
 func TestClaimRewards(t *testing.T) {
-	k, ctx, bankKeeper, _, _ := Setup(t)
+	k, ctx, bankKeeper, _, _, _, _ := Setup(t)
 
 	addr := "cosmos1abcdef"
 	addrAcc, _ := sdk.AccAddressFromBech32(addr)
@@ -182,26 +206,35 @@ func TestClaimRewards(t *testing.T) {
 
 	rewards := types.AccumulatedRewards{
 		Address:   addr,
-		Rewards:   sdk.NewInt(1000),
+		Rewards:   servCoins(1000),
 		LastClaim: 0,
 	}
 	k.SetAccumulatedRewards(ctx, rewards)
+	k.SetRewardPool(ctx, types.RewardPool{
+		Available:   servCoins(500),
+		Outstanding: servCoins(1000),
+	})
 
 	// Claim rewards
 	claimed, err := k.ClaimRewards(ctx, addr)
 	require.NoError(t, err)
-	require.Equal(t, sdk.NewInt(1000), claimed)
+	require.Equal(t, servCoins(1000), claimed)
 
-	// Check that rewards were minted and sent
-	require.Equal(t, sdk.NewCoins(sdk.NewCoin("serv", sdk.NewInt(1000))), bankKeeper.MintedCoins)
+	// Check that rewards were paid out of the pool without minting
+	require.True(t, bankKeeper.MintedCoins.Empty())
 	require.Equal(t, addrAcc, bankKeeper.SentCoinsToAddr)
-	require.Equal(t, sdk.NewCoins(sdk.NewCoin("serv", sdk.NewInt(1000))), bankKeeper.SentCoins)
+	require.Equal(t, servCoins(1000), bankKeeper.SentCoins)
 
 	// Check that accumulated rewards were updated
 	updatedRewards := k.GetAccumulatedRewards(ctx, addr)
 	require.True(t, updatedRewards.Rewards.IsZero())
 	require.Equal(t, metrics.EpochNumber, updatedRewards.LastClaim)
 
+	// Check that the claim settled the outstanding balance of the pool
+	pool := k.GetRewardPool(ctx)
+	require.Equal(t, servCoins(500), pool.Available)
+	require.True(t, pool.Outstanding.IsZero())
+
 	// Try to claim again in the same epoch
 	_, err = k.ClaimRewards(ctx, addr)
 	require.Error(t, err)
@@ -210,7 +243,7 @@ func TestClaimRewards(t *testing.T) {
 
 // TestUpdateRewards tests the UpdateRewards function
 func TestUpdateRewards(t *testing.T) {
-	k, ctx, _, _, posKeeper := Setup(t)
+	k, ctx, _, _, _, _, posKeeper := Setup(t)
 
 	// Set up test data
 	metrics := types.RewardMetrics{
@@ -231,3 +264,1256 @@ func TestUpdateRewards(t *testing.T) {
 	require.Equal(t, uint64(6), updatedMetrics.EpochNumber)
 	require.Equal(t, sdk.NewInt(2000), updatedMetrics.TotalServiceScore)
 }
+
+// TestEpochHooks tests that rewards are only updated at the end of the reward epoch
+func TestEpochHooks(t *testing.T) {
+	k, ctx, _, _, _, _, _ := Setup(t)
+
+	params := types.DefaultRewardParams()
+	params.EpochIdentifier = epochstypes.WeekEpochIdentifier
+	k.SetRewardParams(ctx, params)
+
+	// Other epochs are ignored
+	k.Hooks().AfterEpochEnd(ctx, epochstypes.DayEpochIdentifier, 1)
+	require.Equal(t, uint64(0), k.GetRewardMetrics(ctx).EpochNumber)
+
+	k.Hooks().AfterEpochEnd(ctx, epochstypes.WeekEpochIdentifier, 1)
+	require.Equal(t, uint64(1), k.GetRewardMetrics(ctx).EpochNumber)
+}
+
+// TestUpdateRewardParams tests that only the module authority can update reward parameters
+func TestUpdateRewardParams(t *testing.T) {
+	k, ctx, _, _, _, _, _ := Setup(t)
+	msgServer := keeper.NewMsgServer(*k)
+
+	params := types.DefaultRewardParams()
+	params.RewardPerEpoch = servCoins(2000)
+
+	// Any other signer is rejected
+	other := authtypes.NewModuleAddress("other").String()
+	_, err := msgServer.UpdateRewardParams(sdk.WrapSDKContext(ctx), types.NewMsgUpdateRewardParams(other, params))
+	require.ErrorIs(t, err, govtypes.ErrInvalidSigner)
+	require.Equal(t, types.DefaultRewardParams(), k.GetRewardParams(ctx))
+
+	// The gov module account can update the params
+	_, err = msgServer.UpdateRewardParams(sdk.WrapSDKContext(ctx), types.NewMsgUpdateRewardParams(k.GetAuthority(), params))
+	require.NoError(t, err)
+	require.Equal(t, params, k.GetRewardParams(ctx))
+}
+
+// TestFundRewardPool tests funding the reward pool from each reward source
+func TestFundRewardPool(t *testing.T) {
+	k, ctx, bankKeeper, _, _, distrKeeper, _ := Setup(t)
+
+	params := types.DefaultRewardParams()
+	params.RewardPerEpoch = servCoins(1000)
+
+	// Minting is limited by the inflation cap: 0.01% of 5,000,000 = 500
+	params.RewardSource = types.RewardSourceMint
+	params.InflationCap = sdk.NewDecWithPrec(1, 4)
+	k.SetRewardParams(ctx, params)
+	bankKeeper.Supply = servCoins(5000000)
+
+	k.FundRewardPool(ctx)
+	require.Equal(t, servCoins(500), bankKeeper.MintedCoins)
+	require.Equal(t, servCoins(500), k.GetRewardPool(ctx).Available)
+
+	// The community pool only tops up the shortfall of the pool
+	params.RewardSource = types.RewardSourceCommunityPool
+	k.SetRewardParams(ctx, params)
+	distrKeeper.CommunityPool = sdk.NewDecCoins(sdk.NewDecCoin(types.DefaultRewardDenom, sdk.NewInt(10000)))
+
+	k.FundRewardPool(ctx)
+	require.Equal(t, servCoins(500), distrKeeper.Distributed)
+	require.Equal(t, servCoins(1000), k.GetRewardPool(ctx).Available)
+
+	// A pre-funded module account makes anything not owed to claimers available
+	params.RewardSource = types.RewardSourceModuleAccount
+	k.SetRewardParams(ctx, params)
+	k.SetRewardPool(ctx, types.RewardPool{Available: servCoins(1000), Outstanding: servCoins(200)})
+	moduleAddr := authtypes.NewModuleAddress(types.ModuleName)
+	bankKeeper.SetBalance(moduleAddr, servCoins(1500))
+
+	k.FundRewardPool(ctx)
+	require.Equal(t, servCoins(1300), k.GetRewardPool(ctx).Available)
+}
+
+// TestCollectFeeShare tests diverting collected fees into the reward pool
+func TestCollectFeeShare(t *testing.T) {
+	k, ctx, bankKeeper, _, _, _, _ := Setup(t)
+
+	params := types.DefaultRewardParams()
+	params.RewardSource = types.RewardSourceFeeShare
+	params.FeeShare = sdk.NewDecWithPrec(25, 2) // 0.25
+	k.SetRewardParams(ctx, params)
+
+	feeCollector := authtypes.NewModuleAddress(authtypes.FeeCollectorName)
+	bankKeeper.SetBalance(feeCollector, servCoins(1000))
+
+	require.NoError(t, k.CollectFeeShare(ctx))
+	require.Equal(t, servCoins(250), bankKeeper.ModuleTransfers)
+	require.Equal(t, servCoins(250), k.GetRewardPool(ctx).Available)
+}
+
+// TestAllocateEpochRewards tests that allocation stays within the pool and leftovers roll over
+func TestAllocateEpochRewards(t *testing.T) {
+	k, ctx, _, stakingKeeper, _, _, posKeeper := Setup(t)
+
+	addr := "cosmos1abcdef"
+	addrAcc, _ := sdk.AccAddressFromBech32(addr)
+
+	params := types.DefaultRewardParams()
+	params.RewardPerEpoch = servCoins(1000)
+	k.SetRewardParams(ctx, params)
+
+	k.SetRewardMetrics(ctx, types.RewardMetrics{
+		TotalServiceScore: sdk.NewInt(1000),
+		TotalStaked:       sdk.NewInt(10000),
+		EpochNumber:       1,
+	})
+	posKeeper.SetServiceScore(addr, sdk.NewInt(100))
+	stakingKeeper.SetDelegatorStake(addrAcc, sdk.NewInt(1000))
+
+	// Only half of RewardPerEpoch is available, so the budget is 500
+	k.SetRewardPool(ctx, types.RewardPool{Available: servCoins(500), Outstanding: sdk.NewCoins()})
+	allocated, participants := k.AllocateEpochRewards(ctx)
+	require.Equal(t, servCoins(50), allocated)
+	require.Equal(t, uint64(1), participants)
+
+	// serviceReward = 500 * 0.6 * 100 / 1000 = 30
+	// stakingReward = 500 * 0.4 * 1000 / 10000 = 20
+	rewards := k.GetAccumulatedRewards(ctx, addr)
+	require.Equal(t, servCoins(50), rewards.Rewards)
+
+	pool := k.GetRewardPool(ctx)
+	require.Equal(t, servCoins(450), pool.Available)
+	require.Equal(t, servCoins(50), pool.Outstanding)
+
+	// The earnings are recorded in the address ledger
+	earning, found := k.GetAddressEpochReward(ctx, addr, 1)
+	require.True(t, found)
+	require.Equal(t, servCoins(50), earning.Amount)
+}
+
+// TestAllocateEpochRewardsMultiDenom tests that every reward denom is allocated in the same proportions
+func TestAllocateEpochRewardsMultiDenom(t *testing.T) {
+	k, ctx, _, stakingKeeper, _, _, posKeeper := Setup(t)
+
+	addr := "cosmos1abcdef"
+	addrAcc, _ := sdk.AccAddressFromBech32(addr)
+	ibcDenom := "ibc/27394FB092D2ECCD56123C74F36E4C1F926001CEADA9CA97EA622B25F41E5EB2"
+
+	params := types.DefaultRewardParams()
+	params.RewardPerEpoch = sdk.NewCoins(
+		sdk.NewInt64Coin(types.DefaultRewardDenom, 1000),
+		sdk.NewInt64Coin(ibcDenom, 200),
+	)
+	k.SetRewardParams(ctx, params)
+
+	k.SetRewardMetrics(ctx, types.RewardMetrics{
+		TotalServiceScore: sdk.NewInt(1000),
+		TotalStaked:       sdk.NewInt(10000),
+		EpochNumber:       1,
+	})
+	posKeeper.SetServiceScore(addr, sdk.NewInt(100))
+	stakingKeeper.SetDelegatorStake(addrAcc, sdk.NewInt(1000))
+
+	// The pool holds a full epoch of both denoms plus an unrelated token
+	k.SetRewardPool(ctx, types.RewardPool{
+		Available: sdk.NewCoins(
+			sdk.NewInt64Coin(types.DefaultRewardDenom, 1000),
+			sdk.NewInt64Coin(ibcDenom, 200),
+			sdk.NewInt64Coin("other", 50),
+		),
+		Outstanding: sdk.NewCoins(),
+	})
+	k.AllocateEpochRewards(ctx)
+
+	// Both denoms are paid out at 10% of the epoch budget
+	expected := sdk.NewCoins(
+		sdk.NewInt64Coin(types.DefaultRewardDenom, 100),
+		sdk.NewInt64Coin(ibcDenom, 20),
+	)
+	require.Equal(t, expected, k.GetAccumulatedRewards(ctx, addr).Rewards)
+
+	pool := k.GetRewardPool(ctx)
+	require.Equal(t, expected, pool.Outstanding)
+	require.Equal(t, sdk.NewCoins(
+		sdk.NewInt64Coin(types.DefaultRewardDenom, 900),
+		sdk.NewInt64Coin(ibcDenom, 180),
+		sdk.NewInt64Coin("other", 50),
+	), pool.Available)
+}
+
+// TestEpochRewardHistory tests the per-epoch reward records and the pruning of the earnings ledger
+func TestEpochRewardHistory(t *testing.T) {
+	k, ctx, _, stakingKeeper, _, _, posKeeper := Setup(t)
+
+	addr := "cosmos1abcdef"
+	addrAcc, _ := sdk.AccAddressFromBech32(addr)
+
+	params := types.DefaultRewardParams()
+	params.RewardPerEpoch = servCoins(1000)
+	params.HistoryRetentionEpochs = 2
+	k.SetRewardParams(ctx, params)
+
+	posKeeper.SetServiceScore(addr, sdk.NewInt(100))
+	posKeeper.SetTotalServiceScore(sdk.NewInt(1000))
+	stakingKeeper.SetDelegatorStake(addrAcc, sdk.NewInt(1000))
+	stakingKeeper.SetTotalBondedTokens(sdk.NewInt(10000))
+
+	for epoch := uint64(1); epoch <= 3; epoch++ {
+		k.SetRewardPool(ctx, types.RewardPool{Available: servCoins(1000), Outstanding: sdk.NewCoins()})
+		k.UpdateRewards(ctx)
+	}
+
+	// Every epoch is recorded with the params in force
+	record, found := k.GetEpochRewardRecord(ctx, 2)
+	require.True(t, found)
+	require.Equal(t, uint64(2), record.EpochNumber)
+	require.Equal(t, servCoins(100), record.TotalDistributed)
+	require.Equal(t, uint64(1), record.Participants)
+	require.Equal(t, params, record.Params)
+
+	// Records cannot be overwritten
+	require.Error(t, k.SetEpochRewardRecord(ctx, record))
+
+	// Only the last HistoryRetentionEpochs epochs of earnings are kept
+	_, found = k.GetAddressEpochReward(ctx, addr, 1)
+	require.False(t, found)
+
+	var epochs []uint64
+	k.IterateAddressEpochRewards(ctx, addr, func(earning types.AddressEpochReward) bool {
+		epochs = append(epochs, earning.EpochNumber)
+		return false
+	})
+	require.Equal(t, []uint64{2, 3}, epochs)
+
+	// The epoch record outlives the pruned earnings
+	_, found = k.GetEpochRewardRecord(ctx, 1)
+	require.True(t, found)
+}
+
+// TestEmissionSchedule tests the emission of each emission mode
+func TestEmissionSchedule(t *testing.T) {
+	k, ctx, _, stakingKeeper, _, _, _ := Setup(t)
+
+	params := types.DefaultRewardParams()
+	params.RewardPerEpoch = servCoins(1000)
+
+	// Fixed emission is RewardPerEpoch every epoch
+	k.SetRewardParams(ctx, params)
+	require.Equal(t, servCoins(1000), k.EpochEmission(ctx, 100))
+
+	// Halving halves the emission every period
+	params.Emission.Mode = types.EmissionModeHalving
+	params.Emission.HalvingPeriodEpochs = 10
+	k.SetRewardParams(ctx, params)
+	require.Equal(t, servCoins(1000), k.EpochEmission(ctx, 9))
+	require.Equal(t, servCoins(500), k.EpochEmission(ctx, 10))
+	require.Equal(t, servCoins(250), k.EpochEmission(ctx, 25))
+
+	// Exponential decay reduces the emission every epoch
+	params.Emission.Mode = types.EmissionModeExponentialDecay
+	params.Emission.DecayRate = sdk.NewDecWithPrec(1, 1) // 0.1
+	k.SetRewardParams(ctx, params)
+	require.Equal(t, servCoins(810), k.EpochEmission(ctx, 2))
+
+	// Target inflation emits 10% of the bonded supply per year
+	params.Emission.Mode = types.EmissionModeTargetInflation
+	params.Emission.TargetInflation = sdk.NewDecWithPrec(1, 1)
+	params.Emission.EpochsPerYear = 100
+	k.SetRewardParams(ctx, params)
+	stakingKeeper.SetTotalBondedTokens(sdk.NewInt(5000000))
+	require.Equal(t, servCoins(5000), k.EpochEmission(ctx, 1))
+
+	// It mints the mint denom whatever the reward source, so that must be valid
+	params.RewardSource = types.RewardSourceFeeShare
+	require.NoError(t, params.Validate())
+	params.MintDenom = ""
+	require.Error(t, params.Validate())
+	params.Emission.Mode = types.EmissionModeFixed
+	require.NoError(t, params.Validate())
+}
+
+// TestEmissionLifetimeCap tests that emissions stop at the lifetime cap
+func TestEmissionLifetimeCap(t *testing.T) {
+	k, ctx, _, stakingKeeper, _, _, posKeeper := Setup(t)
+
+	addr := "cosmos1abcdef"
+	addrAcc, _ := sdk.AccAddressFromBech32(addr)
+
+	params := types.DefaultRewardParams()
+	params.RewardPerEpoch = servCoins(1000)
+	params.Emission.LifetimeCap = servCoins(2500)
+	k.SetRewardParams(ctx, params)
+
+	// The projection runs into the cap in the third epoch
+	projection := k.ProjectEmissions(ctx, 4)
+	require.Len(t, projection, 4)
+	require.Equal(t, uint64(1), projection[0].EpochNumber)
+	require.Equal(t, servCoins(1000), projection[1].Amount)
+	require.Equal(t, servCoins(500), projection[2].Amount)
+	require.True(t, projection[3].Amount.IsZero())
+
+	// What is emitted counts towards the cap, whether or not it is distributed
+	posKeeper.SetServiceScore(addr, sdk.NewInt(1000))
+	posKeeper.SetTotalServiceScore(sdk.NewInt(1000))
+	stakingKeeper.SetDelegatorStake(addrAcc, sdk.NewInt(10000))
+	stakingKeeper.SetTotalBondedTokens(sdk.NewInt(10000))
+	k.SetEmissionState(ctx, types.EmissionState{TotalEmitted: servCoins(2000)})
+	k.SetRewardPool(ctx, types.RewardPool{Available: servCoins(1000), Outstanding: sdk.NewCoins()})
+
+	k.UpdateRewards(ctx)
+	require.Equal(t, servCoins(500), k.GetAccumulatedRewards(ctx, addr).Rewards)
+	require.Equal(t, servCoins(2500), k.GetEmissionState(ctx).TotalEmitted)
+	require.Equal(t, servCoins(500), k.GetRewardPool(ctx).Available)
+	require.True(t, k.EpochEmission(ctx, 2).IsZero())
+}
+
+// TestClaimRewardsVesting tests that the locked part of a claim vests and is paid out with later claims
+func TestClaimRewardsVesting(t *testing.T) {
+	k, ctx, bankKeeper, _, _, _, _ := Setup(t)
+
+	addr := authtypes.NewModuleAddress("provider").String()
+
+	params := types.DefaultRewardParams()
+	params.Vesting = types.VestingParams{
+		Mode:           types.VestingModePeriodic,
+		LockedFraction: sdk.NewDecWithPrec(5, 1), // 0.5
+		Duration:       time.Hour * 30,
+		Periods:        3,
+	}
+	k.SetRewardParams(ctx, params)
+
+	k.SetRewardMetrics(ctx, types.RewardMetrics{
+		TotalServiceScore: sdk.NewInt(1000),
+		TotalStaked:       sdk.NewInt(10000),
+		EpochNumber:       1,
+	})
+	k.SetAccumulatedRewards(ctx, types.AccumulatedRewards{Address: addr, Rewards: servCoins(900)})
+	k.SetRewardPool(ctx, types.RewardPool{Outstanding: servCoins(900)})
+
+	// Half of the claim is paid out, the other half starts vesting
+	claimed, err := k.ClaimRewards(ctx, addr)
+	require.NoError(t, err)
+	require.Equal(t, servCoins(450), claimed)
+	require.Equal(t, servCoins(450), k.GetRewardPool(ctx).Vesting)
+
+	locked, unlocked := k.VestingBalances(ctx, addr)
+	require.Equal(t, servCoins(450), locked)
+	require.True(t, unlocked.IsZero())
+
+	// Periodic vesting only unlocks whole periods
+	ctx = ctx.WithBlockTime(ctx.BlockTime().Add(time.Hour * 15))
+	locked, unlocked = k.VestingBalances(ctx, addr)
+	require.Equal(t, servCoins(300), locked)
+	require.Equal(t, servCoins(150), unlocked)
+
+	// The next claim releases the unlocked part
+	k.SetRewardMetrics(ctx, types.RewardMetrics{
+		TotalServiceScore: sdk.NewInt(1000),
+		TotalStaked:       sdk.NewInt(10000),
+		EpochNumber:       2,
+	})
+	claimed, err = k.ClaimRewards(ctx, addr)
+	require.NoError(t, err)
+	require.Equal(t, servCoins(150), claimed)
+	require.Equal(t, servCoins(150), bankKeeper.SentCoins)
+	require.Equal(t, servCoins(300), k.GetRewardPool(ctx).Vesting)
+
+	_, unlocked = k.VestingBalances(ctx, addr)
+	require.True(t, unlocked.IsZero())
+
+	// Fully vested claims are dropped once released
+	ctx = ctx.WithBlockTime(ctx.BlockTime().Add(time.Hour * 15))
+	k.SetRewardMetrics(ctx, types.RewardMetrics{
+		TotalServiceScore: sdk.NewInt(1000),
+		TotalStaked:       sdk.NewInt(10000),
+		EpochNumber:       3,
+	})
+	claimed, err = k.ClaimRewards(ctx, addr)
+	require.NoError(t, err)
+	require.Equal(t, servCoins(300), claimed)
+	require.True(t, k.GetRewardPool(ctx).Vesting.IsZero())
+	require.Empty(t, k.GetVestingRewards(ctx, addr).Entries)
+}
+
+// TestClaimAndDelegate tests that claimed rewards in the bond denom are delegated
+func TestClaimAndDelegate(t *testing.T) {
+	k, ctx, bankKeeper, stakingKeeper, _, _, _ := Setup(t)
+
+	addrAcc := authtypes.NewModuleAddress("provider")
+	addr := addrAcc.String()
+	valAddr := sdk.ValAddress(addrAcc)
+
+	k.SetRewardMetrics(ctx, types.RewardMetrics{
+		TotalServiceScore: sdk.NewInt(1000),
+		TotalStaked:       sdk.NewInt(10000),
+		EpochNumber:       1,
+	})
+	rewards := servCoins(1000).Add(sdk.NewInt64Coin("other", 10))
+	k.SetAccumulatedRewards(ctx, types.AccumulatedRewards{Address: addr, Rewards: rewards})
+	k.SetRewardPool(ctx, types.RewardPool{Outstanding: rewards})
+
+	// Unknown validators are rejected before anything is claimed
+	_, _, err := k.ClaimAndDelegate(ctx, addr, valAddr)
+	require.Error(t, err)
+	require.Equal(t, rewards, k.GetAccumulatedRewards(ctx, addr).Rewards)
+
+	stakingKeeper.SetValidator(valAddr)
+	claimed, delegated, err := k.ClaimAndDelegate(ctx, addr, valAddr)
+	require.NoError(t, err)
+	require.Equal(t, rewards, claimed)
+	require.Equal(t, rewards, bankKeeper.SentCoins)
+	require.Equal(t, sdk.NewInt64Coin(types.DefaultRewardDenom, 1000), delegated)
+	require.Equal(t, sdk.NewInt(1000), stakingKeeper.Delegations[addr+"/"+valAddr.String()])
+}
+
+// TestAutoCompound tests that auto-compounding runs after an epoch closes within the per-block gas budget
+func TestAutoCompound(t *testing.T) {
+	k, ctx, _, stakingKeeper, _, _, _ := Setup(t)
+	msgServer := keeper.NewMsgServer(*k)
+
+	addrs := []sdk.AccAddress{
+		authtypes.NewModuleAddress("provider1"),
+		authtypes.NewModuleAddress("provider2"),
+	}
+	valAddr := sdk.ValAddress(addrs[0])
+	stakingKeeper.SetValidator(valAddr)
+
+	params := types.DefaultRewardParams()
+	params.AutoCompoundGasBudget = 1 // one address per block
+	k.SetRewardParams(ctx, params)
+	k.SetRewardMetrics(ctx, types.RewardMetrics{
+		TotalServiceScore: sdk.NewInt(1000),
+		TotalStaked:       sdk.NewInt(10000),
+		EpochNumber:       1,
+	})
+
+	for _, addr := range addrs {
+		_, err := msgServer.SetAutoCompound(sdk.WrapSDKContext(ctx), types.NewMsgSetAutoCompound(addr.String(), valAddr.String(), true))
+		require.NoError(t, err)
+		k.SetAccumulatedRewards(ctx, types.AccumulatedRewards{Address: addr.String(), Rewards: servCoins(100)})
+	}
+	k.SetRewardPool(ctx, types.RewardPool{Outstanding: servCoins(200)})
+
+	// Nothing is compounded before an epoch closes
+	k.ProcessAutoCompound(ctx)
+	require.Empty(t, stakingKeeper.Delegations)
+
+	k.StartAutoCompound(ctx)
+	k.ProcessAutoCompound(ctx)
+	require.Len(t, stakingKeeper.Delegations, 1)
+
+	k.ProcessAutoCompound(ctx)
+	require.Len(t, stakingKeeper.Delegations, 2)
+	for _, addr := range addrs {
+		require.True(t, k.GetAccumulatedRewards(ctx, addr.String()).Rewards.IsZero())
+	}
+
+	// Opting out removes the setting
+	_, err := msgServer.SetAutoCompound(sdk.WrapSDKContext(ctx), types.NewMsgSetAutoCompound(addrs[0].String(), "", false))
+	require.NoError(t, err)
+	_, found := k.GetAutoCompound(ctx, addrs[0].String())
+	require.False(t, found)
+}
+
+// TestRewardWithdrawAddress tests that claims are sent to the configured withdraw address
+func TestRewardWithdrawAddress(t *testing.T) {
+	k, ctx, bankKeeper, _, _, _, _ := Setup(t)
+	msgServer := keeper.NewMsgServer(*k)
+
+	addr := authtypes.NewModuleAddress("provider")
+	treasury := authtypes.NewModuleAddress("treasury")
+	moduleAddr := authtypes.NewModuleAddress(types.ModuleName)
+	bankKeeper.Blocked[moduleAddr.String()] = true
+
+	require.Equal(t, addr, k.GetRewardWithdrawAddress(ctx, addr))
+
+	// Module accounts cannot receive rewards
+	_, err := msgServer.SetRewardWithdrawAddress(sdk.WrapSDKContext(ctx), types.NewMsgSetRewardWithdrawAddress(addr.String(), moduleAddr.String()))
+	require.Error(t, err)
+
+	_, err = msgServer.SetRewardWithdrawAddress(sdk.WrapSDKContext(ctx), types.NewMsgSetRewardWithdrawAddress(addr.String(), treasury.String()))
+	require.NoError(t, err)
+	require.Equal(t, treasury, k.GetRewardWithdrawAddress(ctx, addr))
+
+	k.SetRewardMetrics(ctx, types.RewardMetrics{
+		TotalServiceScore: sdk.NewInt(1000),
+		TotalStaked:       sdk.NewInt(10000),
+		EpochNumber:       1,
+	})
+	k.SetAccumulatedRewards(ctx, types.AccumulatedRewards{Address: addr.String(), Rewards: servCoins(100)})
+	k.SetRewardPool(ctx, types.RewardPool{Outstanding: servCoins(100)})
+
+	_, err = k.ClaimRewards(ctx, addr.String())
+	require.NoError(t, err)
+	require.Equal(t, treasury, bankKeeper.SentCoinsToAddr)
+	require.Equal(t, servCoins(100), bankKeeper.SentCoins)
+
+	// The mapping is exported for genesis
+	var exported []sdk.AccAddress
+	k.IterateRewardWithdrawAddresses(ctx, func(a, withdrawAddr sdk.AccAddress) bool {
+		require.Equal(t, addr, a)
+		exported = append(exported, withdrawAddr)
+		return false
+	})
+	require.Equal(t, []sdk.AccAddress{treasury}, exported)
+
+	// Setting the own address removes the withdraw address
+	require.NoError(t, k.SetRewardWithdrawAddress(ctx, addr, addr))
+	require.Equal(t, addr, k.GetRewardWithdrawAddress(ctx, addr))
+}
+
+// TestExpireUnclaimedRewards tests that rewards left unclaimed for too long are swept back
+func TestExpireUnclaimedRewards(t *testing.T) {
+	k, ctx, _, _, _, distrKeeper, _ := Setup(t)
+
+	stale := authtypes.NewModuleAddress("stale").String()
+	recent := authtypes.NewModuleAddress("recent").String()
+
+	params := types.DefaultRewardParams()
+	params.UnclaimedExpiryEpochs = 10
+	params.ExpiryWarningEpochs = 2
+	k.SetRewardParams(ctx, params)
+
+	k.SetAccumulatedRewards(ctx, types.AccumulatedRewards{Address: stale, Rewards: servCoins(100), UnclaimedSince: 1})
+	k.SetAccumulatedRewards(ctx, types.AccumulatedRewards{Address: recent, Rewards: servCoins(50), UnclaimedSince: 5})
+	k.SetRewardPool(ctx, types.RewardPool{Available: sdk.NewCoins(), Outstanding: servCoins(150)})
+
+	// The stale balance is warned about two epochs before it expires
+	ctx = ctx.WithEventManager(sdk.NewEventManager())
+	k.ExpireUnclaimedRewards(ctx, 9)
+	require.Equal(t, servCoins(100), k.GetAccumulatedRewards(ctx, stale).Rewards)
+	warnings := 0
+	for _, event := range ctx.EventManager().Events() {
+		if event.Type == types.EventTypeRewardsExpiring {
+			warnings++
+		}
+	}
+	require.Equal(t, 1, warnings)
+
+	// Only the stale balance expires, back into the reward pool
+	k.ExpireUnclaimedRewards(ctx, 11)
+	require.True(t, k.GetAccumulatedRewards(ctx, stale).Rewards.IsZero())
+	require.Equal(t, servCoins(50), k.GetAccumulatedRewards(ctx, recent).Rewards)
+
+	pool := k.GetRewardPool(ctx)
+	require.Equal(t, servCoins(100), pool.Available)
+	require.Equal(t, servCoins(50), pool.Outstanding)
+
+	// Claiming resets the expiry of a balance
+	k.SetRewardMetrics(ctx, types.RewardMetrics{
+		TotalServiceScore: sdk.NewInt(1000),
+		TotalStaked:       sdk.NewInt(10000),
+		EpochNumber:       12,
+	})
+	_, err := k.ClaimRewards(ctx, recent)
+	require.NoError(t, err)
+
+	// Expired rewards can also go to the community pool
+	params.ExpiryDestination = types.ExpiryDestinationCommunityPool
+	k.SetRewardParams(ctx, params)
+	k.SetAccumulatedRewards(ctx, types.AccumulatedRewards{Address: stale, Rewards: servCoins(30), UnclaimedSince: 12})
+	k.SetRewardPool(ctx, types.RewardPool{Available: sdk.NewCoins(), Outstanding: servCoins(30)})
+
+	k.ExpireUnclaimedRewards(ctx, 22)
+	require.Equal(t, servCoins(30), distrKeeper.Funded)
+	require.True(t, k.GetRewardPool(ctx).Outstanding.IsZero())
+}
+
+// TestRewardBreakdown tests the reward breakdown and simulation
+func TestRewardBreakdown(t *testing.T) {
+	k, ctx, _, stakingKeeper, _, _, posKeeper := Setup(t)
+
+	addr := authtypes.NewModuleAddress("provider")
+	params := types.DefaultRewardParams()
+	params.ServiceScoreWeight = sdk.NewDecWithPrec(6, 1)
+	params.StakingWeight = sdk.NewDecWithPrec(4, 1)
+	params.RewardPerEpoch = servCoins(1000)
+	k.SetRewardParams(ctx, params)
+
+	posKeeper.SetServiceScore(addr.String(), sdk.NewInt(100))
+	posKeeper.SetTotalServiceScore(sdk.NewInt(1000))
+	stakingKeeper.SetDelegatorStake(addr, sdk.NewInt(1000))
+	stakingKeeper.SetTotalBondedTokens(sdk.NewInt(10000))
+
+	breakdown := k.RewardBreakdown(ctx, addr.String())
+	require.Equal(t, sdk.NewDecWithPrec(1, 1), breakdown.ServiceShare)
+	require.Equal(t, sdk.NewDecWithPrec(1, 1), breakdown.StakeShare)
+	require.Equal(t, servCoins(1000), breakdown.EpochBudget)
+	require.Equal(t, sdk.NewDecCoins(sdk.NewInt64DecCoin(types.DefaultRewardDenom, 60)), breakdown.ServiceReward)
+	require.Equal(t, sdk.NewDecCoins(sdk.NewInt64DecCoin(types.DefaultRewardDenom, 40)), breakdown.StakingReward)
+	require.Equal(t, servCoins(100), breakdown.Reward)
+	require.Empty(t, breakdown.Modifiers)
+
+	// Doubling the score replaces the current score in the total:
+	// 1000 * 0.6 * 200 / 1100 + 40 = 149
+	score := sdk.NewInt(200)
+	simulated := k.SimulateRewards(ctx, addr.String(), &score, nil)
+	require.Equal(t, sdk.NewInt(1100), simulated.TotalServiceScore)
+	require.Equal(t, sdk.NewInt(1000), simulated.Stake)
+	require.Equal(t, servCoins(149), simulated.Reward)
+
+	// A new participant is added to the totals:
+	// 1000 * 0.6 * 1000 / 2000 + 1000 * 0.4 * 10000 / 20000 = 500
+	score, stake := sdk.NewInt(1000), sdk.NewInt(10000)
+	simulated = k.SimulateRewards(ctx, "", &score, &stake)
+	require.Equal(t, sdk.NewInt(2000), simulated.TotalServiceScore)
+	require.Equal(t, sdk.NewInt(20000), simulated.TotalStaked)
+	require.Equal(t, servCoins(500), simulated.Reward)
+}
+
+// TestAllocateEpochRewardsRemainder tests that undistributed rewards carry into the next epoch
+func TestAllocateEpochRewardsRemainder(t *testing.T) {
+	k, ctx, _, stakingKeeper, _, _, posKeeper := Setup(t)
+
+	params := types.DefaultRewardParams()
+	params.RewardPerEpoch = servCoins(1000)
+	k.SetRewardParams(ctx, params)
+
+	// Without any service or stake the whole budget is carried over
+	k.SetRewardMetrics(ctx, types.RewardMetrics{
+		TotalServiceScore: sdk.ZeroInt(),
+		TotalStaked:       sdk.ZeroInt(),
+		EpochNumber:       1,
+	})
+	k.SetRewardPool(ctx, types.RewardPool{Available: servCoins(1000), Outstanding: sdk.NewCoins()})
+
+	allocated, _ := k.AllocateEpochRewards(ctx)
+	require.True(t, allocated.IsZero())
+	require.Equal(t, servCoins(1000), k.GetRewardPool(ctx).Remainder)
+	require.Equal(t, servCoins(1000), k.GetEmissionState(ctx).TotalEmitted)
+
+	// Three equal providers share the emission plus the remainder:
+	// 2000 * 0.6 / 3 + 2000 * 0.4 / 3 = 666.67, truncated to 666 each
+	for i := 0; i < 3; i++ {
+		addr := authtypes.NewModuleAddress(fmt.Sprintf("provider%d", i))
+		posKeeper.SetServiceScore(addr.String(), sdk.NewInt(1))
+		stakingKeeper.SetDelegatorStake(addr, sdk.NewInt(1))
+	}
+	k.SetRewardMetrics(ctx, types.RewardMetrics{
+		TotalServiceScore: sdk.NewInt(3),
+		TotalStaked:       sdk.NewInt(3),
+		EpochNumber:       2,
+	})
+	pool := k.GetRewardPool(ctx)
+	pool.Available = pool.Available.Add(servCoins(1000)...)
+	k.SetRewardPool(ctx, pool)
+
+	allocated, participants := k.AllocateEpochRewards(ctx)
+	require.Equal(t, servCoins(1998), allocated)
+	require.Equal(t, uint64(3), participants)
+
+	// The truncation dust is kept for the next epoch
+	pool = k.GetRewardPool(ctx)
+	require.Equal(t, servCoins(2), pool.Remainder)
+	require.Equal(t, servCoins(2), pool.Available)
+	require.Equal(t, servCoins(2000), k.GetEmissionState(ctx).TotalEmitted)
+}
+
+// TestAllocateEpochRewardsConservation checks that over any sequence of epochs
+// the rewards distributed plus the remainder equal the rewards emitted
+func TestAllocateEpochRewardsConservation(t *testing.T) {
+	rapid.Check(t, func(rt *rapid.T) {
+		k, ctx, _, stakingKeeper, _, _, posKeeper := Setup(t)
+
+		weight := rapid.Int64Range(0, 100).Draw(rt, "serviceScoreWeight")
+		params := types.DefaultRewardParams()
+		params.ServiceScoreWeight = sdk.NewDecWithPrec(weight, 2)
+		params.StakingWeight = sdk.OneDec().Sub(params.ServiceScoreWeight)
+		params.RewardPerEpoch = servCoins(rapid.Int64Range(0, 1000000000).Draw(rt, "rewardPerEpoch"))
+		k.SetRewardParams(ctx, params)
+
+		var addrs []string
+		sumScore, sumStake := sdk.ZeroInt(), sdk.ZeroInt()
+		providers := rapid.IntRange(1, 6).Draw(rt, "providers")
+		for i := 0; i < providers; i++ {
+			addr := authtypes.NewModuleAddress(fmt.Sprintf("provider%d", i))
+			score := sdk.NewInt(rapid.Int64Range(0, 1000000).Draw(rt, "score"))
+			stake := sdk.NewInt(rapid.Int64Range(0, 1000000000).Draw(rt, "stake"))
+			posKeeper.SetServiceScore(addr.String(), score)
+			stakingKeeper.SetDelegatorStake(addr, stake)
+			addrs = append(addrs, addr.String())
+			sumScore = sumScore.Add(score)
+			sumStake = sumStake.Add(stake)
+		}
+
+		// Totals may be zero, include non-providers or lag behind the providers
+		totalScore := sdk.NewInt(rapid.Int64Range(0, sumScore.Int64()+1000000).Draw(rt, "totalServiceScore"))
+		totalStaked := sdk.NewInt(rapid.Int64Range(0, sumStake.Int64()+1000000000).Draw(rt, "totalStaked"))
+		posKeeper.SetTotalServiceScore(totalScore)
+		stakingKeeper.SetTotalBondedTokens(totalStaked)
+
+		funded := sdk.NewCoins()
+		epochs := rapid.Uint64Range(1, 5).Draw(rt, "epochs")
+		for epoch := uint64(1); epoch <= epochs; epoch++ {
+			k.SetRewardMetrics(ctx, types.RewardMetrics{
+				TotalServiceScore: totalScore,
+				TotalStaked:       totalStaked,
+				EpochNumber:       epoch,
+			})
+
+			funding := servCoins(rapid.Int64Range(0, 2000000000).Draw(rt, "funding"))
+			funded = funded.Add(funding...)
+			pool := k.GetRewardPool(ctx)
+			pool.Available = pool.Available.Add(funding...)
+			k.SetRewardPool(ctx, pool)
+
+			k.AllocateEpochRewards(ctx)
+
+			distributed := sdk.NewCoins()
+			for _, addr := range addrs {
+				distributed = distributed.Add(k.GetAccumulatedRewards(ctx, addr).Rewards...)
+			}
+
+			pool = k.GetRewardPool(ctx)
+			emitted := k.GetEmissionState(ctx).TotalEmitted
+			require.Equal(rt, emitted.String(), distributed.Add(pool.Remainder...).String())
+			require.Equal(rt, distributed.String(), pool.Outstanding.String())
+			require.Equal(rt, funded.String(), pool.Available.Add(pool.Outstanding...).String())
+			require.True(rt, pool.Remainder.IsAllLTE(pool.Available))
+		}
+	})
+}
+
+// TestProviderCommission tests sharing provider rewards with the delegators of its validator
+func TestProviderCommission(t *testing.T) {
+	k, ctx, bankKeeper, stakingKeeper, _, _, posKeeper := Setup(t)
+	msgServer := keeper.NewMsgServer(*k)
+
+	provider := authtypes.NewModuleAddress("provider")
+	valAddr := sdk.ValAddress(provider)
+	stakingKeeper.SetValidator(valAddr)
+
+	k.SetRewardMetrics(ctx, types.RewardMetrics{EpochNumber: 1})
+
+	// The first commission needs its max rates
+	rate, maxRate, maxChangeRate := sdk.NewDecWithPrec(1, 1), sdk.NewDecWithPrec(2, 1), sdk.NewDecWithPrec(5, 2)
+	_, err := msgServer.SetProviderCommission(sdk.WrapSDKContext(ctx), types.NewMsgSetProviderCommission(provider.String(), rate, nil, nil))
+	require.Error(t, err)
+	_, err = msgServer.SetProviderCommission(sdk.WrapSDKContext(ctx), types.NewMsgSetProviderCommission(provider.String(), rate, &maxRate, &maxChangeRate))
+	require.NoError(t, err)
+
+	// The rate can only change once per epoch
+	require.Error(t, k.UpdateProviderCommission(ctx, provider.String(), sdk.NewDecWithPrec(12, 2), nil, nil))
+
+	delegators := []sdk.AccAddress{authtypes.NewModuleAddress("delegator1"), authtypes.NewModuleAddress("delegator2")}
+	for i, amount := range []int64{300, 100} {
+		_, err := stakingKeeper.Delegate(ctx, delegators[i], sdk.NewInt(amount), stakingtypes.Unbonded, stakingKeeper.Validators[valAddr.String()], true)
+		require.NoError(t, err)
+	}
+
+	params := types.DefaultRewardParams()
+	params.RewardPerEpoch = servCoins(1000)
+	k.SetRewardParams(ctx, params)
+	k.SetRewardMetrics(ctx, types.RewardMetrics{
+		TotalServiceScore: sdk.NewInt(1000),
+		TotalStaked:       sdk.NewInt(10000),
+		EpochNumber:       1,
+	})
+	posKeeper.SetServiceScore(provider.String(), sdk.NewInt(1000))
+	stakingKeeper.SetDelegatorStake(provider, sdk.NewInt(10000))
+	k.SetRewardPool(ctx, types.RewardPool{Available: servCoins(1000), Outstanding: sdk.NewCoins()})
+
+	// The provider keeps 10% and the delegators split 900 by their shares
+	allocated, _ := k.AllocateEpochRewards(ctx)
+	require.Equal(t, servCoins(1000), allocated)
+	require.Equal(t, servCoins(100), k.GetAccumulatedRewards(ctx, provider.String()).Rewards)
+	require.Equal(t, servCoins(675), k.DelegatorRewards(ctx, delegators[0], provider.String()))
+	require.Equal(t, servCoins(225), k.DelegatorRewards(ctx, delegators[1], provider.String()))
+
+	res, err := msgServer.ClaimDelegatorRewards(sdk.WrapSDKContext(ctx), types.NewMsgClaimDelegatorRewards(delegators[0].String(), provider.String()))
+	require.NoError(t, err)
+	require.Equal(t, servCoins(675), res.Amount)
+	require.Equal(t, delegators[0], bankKeeper.SentCoinsToAddr)
+	require.True(t, k.DelegatorRewards(ctx, delegators[0], provider.String()).IsZero())
+	require.Equal(t, servCoins(325), k.GetRewardPool(ctx).Outstanding)
+
+	// New delegations only earn what is shared after they start
+	late := authtypes.NewModuleAddress("delegator3")
+	_, err = stakingKeeper.Delegate(ctx, late, sdk.NewInt(100), stakingtypes.Unbonded, stakingKeeper.Validators[valAddr.String()], true)
+	require.NoError(t, err)
+	require.NoError(t, k.StakingHooks().AfterDelegationModified(ctx, late, valAddr))
+	require.True(t, k.DelegatorRewards(ctx, late, provider.String()).IsZero())
+
+	// In the next epoch the rate can move by at most the max change rate
+	k.SetRewardMetrics(ctx, types.RewardMetrics{EpochNumber: 2})
+	require.Error(t, k.UpdateProviderCommission(ctx, provider.String(), sdk.NewDecWithPrec(2, 1), nil, nil))
+	require.Error(t, k.UpdateProviderCommission(ctx, provider.String(), sdk.NewDecWithPrec(15, 2), &rate, nil))
+	require.NoError(t, k.UpdateProviderCommission(ctx, provider.String(), sdk.NewDecWithPrec(15, 2), nil, nil))
+
+	// The breakdown reports the commission
+	breakdown := k.RewardBreakdown(ctx, provider.String())
+	require.Len(t, breakdown.Modifiers, 1)
+	require.Equal(t, types.ModifierCommission, breakdown.Modifiers[0].Name)
+	require.Equal(t, sdk.NewDecWithPrec(15, 2), breakdown.Modifiers[0].Factor)
+}
+
+// equalCurve is a custom reward curve sharing the budget equally among participants
+type equalCurve struct{}
+
+func (equalCurve) Name() string { return "equal" }
+
+func (equalCurve) Shares(params types.RewardParams, participants []types.Participant, totalScore, totalStaked sdk.Int) []sdk.Dec {
+	shares := make([]sdk.Dec, len(participants))
+	for i := range participants {
+		shares[i] = sdk.OneDec().QuoInt64(int64(len(participants)))
+	}
+	return shares
+}
+
+// TestRewardCurves tests sharing the budget under the built-in and registered reward curves
+func TestRewardCurves(t *testing.T) {
+	k, ctx, _, _, _, _, posKeeper := Setup(t)
+	msgServer := keeper.NewMsgServer(*k)
+
+	params := types.DefaultRewardParams()
+	params.ServiceScoreWeight = sdk.OneDec()
+	params.StakingWeight = sdk.ZeroDec()
+	params.RewardPerEpoch = servCoins(1000)
+
+	large := authtypes.NewModuleAddress("large")
+	small := authtypes.NewModuleAddress("small")
+	posKeeper.SetServiceScore(large.String(), sdk.NewInt(900))
+	posKeeper.SetServiceScore(small.String(), sdk.NewInt(100))
+	posKeeper.SetTotalServiceScore(sdk.NewInt(1000))
+
+	for _, tc := range []struct {
+		curve        string
+		large, small int64
+	}{
+		// 1000 * 900 / 1000 and 1000 * 100 / 1000
+		{types.RewardCurveLinear, 900, 100},
+		// 1000 * 30 / 40 and 1000 * 10 / 40
+		{types.RewardCurveSqrt, 750, 250},
+		// The large provider is capped at half of the budget
+		{types.RewardCurveCapped, 500, 100},
+	} {
+		params.RewardCurve = tc.curve
+		params.MaxProviderShare = sdk.NewDecWithPrec(5, 1)
+		_, err := msgServer.UpdateRewardParams(sdk.WrapSDKContext(ctx), types.NewMsgUpdateRewardParams(k.GetAuthority(), params))
+		require.NoError(t, err)
+
+		require.Equal(t, servCoins(tc.large), k.RewardBreakdown(ctx, large.String()).Reward, tc.curve)
+		require.Equal(t, servCoins(tc.small), k.RewardBreakdown(ctx, small.String()).Reward, tc.curve)
+	}
+
+	// The capped curve reports its effect on the linear share
+	modifiers := k.RewardBreakdown(ctx, large.String()).Modifiers
+	require.Len(t, modifiers, 1)
+	require.Equal(t, types.ModifierRewardCurve, modifiers[0].Name)
+	require.Equal(t, sdk.NewDecWithPrec(5, 1).Quo(sdk.NewDecWithPrec(9, 1)), modifiers[0].Factor)
+
+	// Curves must be registered before they can be selected
+	params.RewardCurve = "equal"
+	_, err := msgServer.UpdateRewardParams(sdk.WrapSDKContext(ctx), types.NewMsgUpdateRewardParams(k.GetAuthority(), params))
+	require.Error(t, err)
+
+	k.RegisterRewardCurve(equalCurve{})
+	require.True(t, k.HasRewardCurve("equal"))
+	require.Panics(t, func() { k.RegisterRewardCurve(equalCurve{}) })
+
+	_, err = msgServer.UpdateRewardParams(sdk.WrapSDKContext(ctx), types.NewMsgUpdateRewardParams(k.GetAuthority(), params))
+	require.NoError(t, err)
+	require.Equal(t, servCoins(500), k.RewardBreakdown(ctx, large.String()).Reward)
+	require.Equal(t, servCoins(500), k.RewardBreakdown(ctx, small.String()).Reward)
+}
+
+// TestClawback tests clawing back earned rewards from unclaimed rewards and through debt
+func TestClawback(t *testing.T) {
+	k, ctx, _, stakingKeeper, slashingKeeper, _, posKeeper := Setup(t)
+
+	addr := authtypes.NewModuleAddress("provider").String()
+	for epoch := uint64(1); epoch <= 3; epoch++ {
+		k.SetAddressEpochReward(ctx, types.AddressEpochReward{Address: addr, EpochNumber: epoch, Amount: servCoins(100)})
+	}
+	k.SetAccumulatedRewards(ctx, types.AccumulatedRewards{Address: addr, Rewards: servCoins(150), UnclaimedSince: 2})
+	k.SetRewardPool(ctx, types.RewardPool{Available: sdk.NewCoins(), Outstanding: servCoins(150)})
+
+	// Half of epochs 2 and 3 is taken from the unclaimed rewards
+	clawedBack, err := k.Clawback(ctx, addr, 2, sdk.NewDecWithPrec(5, 1), types.ClawbackReasonSlashed)
+	require.NoError(t, err)
+	require.Equal(t, servCoins(100), clawedBack)
+	require.Equal(t, servCoins(50), k.GetAccumulatedRewards(ctx, addr).Rewards)
+	require.True(t, k.GetClawbackDebt(ctx, addr).Amount.IsZero())
+
+	pool := k.GetRewardPool(ctx)
+	require.Equal(t, servCoins(50), pool.Outstanding)
+	require.Equal(t, servCoins(100), pool.Available)
+	require.Equal(t, servCoins(100), pool.Remainder)
+
+	// What exceeds the unclaimed rewards becomes debt
+	clawedBack, err = k.Clawback(ctx, addr, 2, sdk.NewDecWithPrec(5, 1), types.ClawbackReasonSlashed)
+	require.NoError(t, err)
+	require.Equal(t, servCoins(100), clawedBack)
+	require.True(t, k.GetAccumulatedRewards(ctx, addr).Rewards.IsZero())
+	require.Equal(t, servCoins(50), k.GetClawbackDebt(ctx, addr).Amount)
+	require.Equal(t, servCoins(100), k.GetClawbackRecord(ctx, 2, addr).Amount)
+	require.True(t, k.GetClawbackRecord(ctx, 1, addr).Amount.IsZero())
+
+	// Epochs are never clawed back beyond what was earned in them
+	clawedBack, err = k.Clawback(ctx, addr, 2, sdk.OneDec(), types.ClawbackReasonSlashed)
+	require.NoError(t, err)
+	require.True(t, clawedBack.IsZero())
+
+	_, err = k.Clawback(ctx, addr, 1, sdk.ZeroDec(), types.ClawbackReasonSlashed)
+	require.Error(t, err)
+
+	// The debt is repaid out of the next reward:
+	// 0.6 * (1000 + 150) = 690, of which 50 repays the debt
+	params := types.DefaultRewardParams()
+	params.RewardPerEpoch = servCoins(1000)
+	params.SlashClawbackEpochs = 1
+	k.SetRewardParams(ctx, params)
+
+	posKeeper.SetServiceScore(addr, sdk.NewInt(1))
+	k.SetRewardMetrics(ctx, types.RewardMetrics{
+		TotalServiceScore: sdk.NewInt(1),
+		TotalStaked:       sdk.ZeroInt(),
+		EpochNumber:       4,
+	})
+	pool = k.GetRewardPool(ctx)
+	pool.Available = pool.Available.Add(servCoins(1000)...)
+	k.SetRewardPool(ctx, pool)
+
+	allocated, _ := k.AllocateEpochRewards(ctx)
+	require.Equal(t, servCoins(640), allocated)
+	require.Equal(t, servCoins(640), k.GetAccumulatedRewards(ctx, addr).Rewards)
+	require.True(t, k.GetClawbackDebt(ctx, addr).Amount.IsZero())
+	require.Equal(t, servCoins(510), k.GetRewardPool(ctx).Remainder)
+
+	valAddr := sdk.ValAddress(sdk.MustAccAddressFromBech32(addr))
+	validator, err := stakingtypes.NewValidator(valAddr, ed25519.GenPrivKey().PubKey(), stakingtypes.Description{})
+	require.NoError(t, err)
+	stakingKeeper.Validators[valAddr.String()] = validator
+	consAddr, err := validator.GetConsAddr()
+	require.NoError(t, err)
+
+	// Slashing the validator of the provider for downtime claws nothing back
+	require.NoError(t, k.StakingHooks().BeforeValidatorSlashed(ctx, valAddr, sdk.NewDecWithPrec(1, 2)))
+	k.ProcessSlashClawbacks(ctx)
+	require.Equal(t, servCoins(640), k.GetAccumulatedRewards(ctx, addr).Rewards)
+	require.True(t, k.GetClawbackRecord(ctx, 4, addr).Amount.IsZero())
+
+	// Slashing it for double signing, which tombstones it, claws back the
+	// double sign slash fraction of the last epoch
+	slashingKeeper.DoubleSignFraction = sdk.NewDecWithPrec(1, 1)
+	require.NoError(t, k.StakingHooks().BeforeValidatorSlashed(ctx, valAddr, sdk.NewDecWithPrec(1, 1)))
+	slashingKeeper.Tombstone(consAddr)
+	k.ProcessSlashClawbacks(ctx)
+	require.Equal(t, servCoins(576), k.GetAccumulatedRewards(ctx, addr).Rewards)
+	require.Equal(t, servCoins(64), k.GetClawbackRecord(ctx, 4, addr).Amount)
+
+	// A slash is only settled once
+	k.ProcessSlashClawbacks(ctx)
+	require.Equal(t, servCoins(576), k.GetAccumulatedRewards(ctx, addr).Rewards)
+}
+
+// TestClawbackRevokedProof tests clawing back the rewards earned with a revoked proof
+func TestClawbackRevokedProof(t *testing.T) {
+	k, ctx, _, _, _, _, _ := Setup(t)
+
+	addr := authtypes.NewModuleAddress("provider").String()
+	verifiedAt := ctx.BlockTime()
+	for epoch := uint64(1); epoch <= 3; epoch++ {
+		// Only epochs settled after the proof was verified are clawed back
+		settledAt := verifiedAt.Add(time.Duration(int64(epoch)-2) * time.Hour)
+		require.NoError(t, k.SetEpochRewardRecord(ctx, types.EpochRewardRecord{EpochNumber: epoch, Time: settledAt}))
+		k.SetAddressEpochReward(ctx, types.AddressEpochReward{Address: addr, EpochNumber: epoch, Amount: servCoins(1000)})
+	}
+	k.SetAccumulatedRewards(ctx, types.AccumulatedRewards{Address: addr, Rewards: servCoins(3000), UnclaimedSince: 1})
+	k.SetRewardPool(ctx, types.RewardPool{Available: sdk.NewCoins(), Outstanding: servCoins(3000)})
+
+	// A quarter of the score is revoked, which claws back a quarter of the
+	// service part of epochs 2 and 3: 1000 * 0.25 * 0.6 = 150 each
+	k.ProofOfServiceHooks().AfterProofRevoked(ctx, addr, "proof-1", sdk.NewInt(25), sdk.NewInt(100), verifiedAt)
+	require.Equal(t, servCoins(2700), k.GetAccumulatedRewards(ctx, addr).Rewards)
+	require.True(t, k.GetClawbackRecord(ctx, 1, addr).Amount.IsZero())
+	require.Equal(t, servCoins(150), k.GetClawbackRecord(ctx, 2, addr).Amount)
+	require.Equal(t, servCoins(150), k.GetClawbackRecord(ctx, 3, addr).Amount)
+}
+
+// TestRewardPoolOutstanding tests that claims never overdraw the outstanding
+// rewards of the pool, which genesis must account for
+func TestRewardPoolOutstanding(t *testing.T) {
+	k, ctx, bankKeeper, _, _, _, _ := Setup(t)
+
+	addr := authtypes.NewModuleAddress("provider").String()
+	k.SetRewardMetrics(ctx, types.RewardMetrics{
+		TotalServiceScore: sdk.NewInt(1000),
+		TotalStaked:       sdk.NewInt(10000),
+		EpochNumber:       1,
+	})
+
+	// Balances credited before the reward pool were never counted as outstanding
+	k.SetAccumulatedRewards(ctx, types.AccumulatedRewards{Address: addr, Rewards: servCoins(1000)})
+	k.SetRewardPool(ctx, types.DefaultRewardPool())
+
+	_, err := k.ClaimRewards(ctx, addr)
+	require.Error(t, err)
+	require.Equal(t, servCoins(1000), k.GetAccumulatedRewards(ctx, addr).Rewards)
+	require.True(t, bankKeeper.SentCoins.Empty())
+
+	// Such a state is rejected in genesis
+	genesis := types.DefaultGenesis()
+	genesis.AccumulatedRewards = []types.AccumulatedRewards{{Address: addr, Rewards: servCoins(1000)}}
+	require.Error(t, genesis.Validate())
+	genesis.RewardPool.Outstanding = servCoins(1000)
+	require.NoError(t, genesis.Validate())
+}
+
+// TestGenesisRewardPoolBalances tests that genesis rejects a reward pool whose
+// balances do not match the rewards they account for
+func TestGenesisRewardPoolBalances(t *testing.T) {
+	addr := authtypes.NewModuleAddress("provider").String()
+	start := time.Unix(1000, 0).UTC()
+
+	genesis := types.DefaultGenesis()
+	genesis.AccumulatedRewards = []types.AccumulatedRewards{{Address: addr, Rewards: servCoins(1000)}}
+	genesis.DelegatorRewardsPools = []types.DelegatorRewardsPool{{
+		Provider:    addr,
+		Ratio:       sdk.NewDecCoins(),
+		Outstanding: servCoins(200),
+	}}
+	genesis.VestingRewards = []types.VestingRewards{{
+		Address: addr,
+		Entries: []types.VestingEntry{
+			{Amount: servCoins(300), StartTime: start, EndTime: start.Add(time.Hour)},
+			{Amount: servCoins(100), StartTime: start, EndTime: start.Add(time.Hour * 2)},
+		},
+		Released: servCoins(50),
+	}}
+	genesis.RewardPool.Outstanding = servCoins(1200)
+	genesis.RewardPool.Vesting = servCoins(350)
+	require.NoError(t, genesis.Validate())
+
+	// Outstanding rewards that are not owed to anyone
+	genesis.RewardPool.Outstanding = servCoins(1300)
+	require.Error(t, genesis.Validate())
+	genesis.RewardPool.Outstanding = servCoins(1200)
+
+	// Vesting balance that ignores released rewards
+	genesis.RewardPool.Vesting = servCoins(400)
+	require.Error(t, genesis.Validate())
+
+	// Vesting balance short of the unreleased rewards
+	genesis.RewardPool.Vesting = servCoins(300)
+	require.Error(t, genesis.Validate())
+}
+
+// TestMigrate1to2 tests that the migration rewrites the v1 encoding of the
+// store as coins and seeds the outstanding rewards of the pool
+func TestMigrate1to2(t *testing.T) {
+	k, ctx, bankKeeper, _, _, _, _, storeKey := SetupWithStoreKey(t)
+	cdc := MakeTestEncodingConfig().Marshaler
+
+	addrA := authtypes.NewModuleAddress("provider_a").String()
+	addrB := authtypes.NewModuleAddress("provider_b").String()
+	k.SetRewardMetrics(ctx, types.RewardMetrics{
+		TotalServiceScore: sdk.NewInt(1000),
+		TotalStaked:       sdk.NewInt(10000),
+		EpochNumber:       5,
+	})
+
+	// A v1 store holds Int amounts of serv, which the v2 schema cannot decode
+	store := ctx.KVStore(storeKey)
+	store.Set(types.RewardParamsKey, cdc.MustMarshal(&types.LegacyRewardParams{
+		ServiceScoreWeight: sdk.NewDecWithPrec(7, 1),
+		StakingWeight:      sdk.NewDecWithPrec(3, 1),
+		RewardPerEpoch:     sdk.NewInt(2000000),
+		EpochDuration:      100,
+	}))
+	store.Set(types.GetAccumulatedRewardsKey(addrA), cdc.MustMarshal(&types.LegacyAccumulatedRewards{
+		Address:   addrA,
+		Rewards:   sdk.NewInt(1000),
+		LastClaim: 3,
+	}))
+	store.Set(types.GetAccumulatedRewardsKey(addrB), cdc.MustMarshal(&types.LegacyAccumulatedRewards{
+		Address: addrB,
+		Rewards: sdk.NewInt(500),
+	}))
+
+	moduleAddr := authtypes.NewModuleAddress(types.ModuleName)
+	bankKeeper.SetBalance(moduleAddr, servCoins(400))
+	require.NoError(t, keeper.NewMigrator(*k).Migrate1to2(ctx))
+
+	// The params keep their weights and reward, every new parameter takes its default
+	expected := types.DefaultRewardParams()
+	expected.ServiceScoreWeight = sdk.NewDecWithPrec(7, 1)
+	expected.StakingWeight = sdk.NewDecWithPrec(3, 1)
+	expected.RewardPerEpoch = servCoins(2000000)
+	params := k.GetRewardParams(ctx)
+	require.Equal(t, expected, params)
+	require.Equal(t, epochstypes.DayEpochIdentifier, params.EpochIdentifier)
+	require.Equal(t, types.RewardSourceMint, params.RewardSource)
+	require.NoError(t, params.Validate())
+
+	// Rewards are coins of serv, aged from the epoch of the upgrade
+	rewardsA := k.GetAccumulatedRewards(ctx, addrA)
+	require.Equal(t, servCoins(1000), rewardsA.Rewards)
+	require.Equal(t, uint64(3), rewardsA.LastClaim)
+	require.Equal(t, uint64(5), rewardsA.UnclaimedSince)
+	require.Equal(t, servCoins(500), k.GetAccumulatedRewards(ctx, addrB).Rewards)
+
+	// The outstanding rewards are seeded and what the module lacks is minted
+	require.Equal(t, servCoins(1500), k.GetRewardPool(ctx).Outstanding)
+	require.Equal(t, servCoins(1100), bankKeeper.MintedCoins)
+
+	claimed, err := k.ClaimRewards(ctx, addrA)
+	require.NoError(t, err)
+	require.Equal(t, servCoins(1000), claimed)
+	require.Equal(t, servCoins(500), k.GetRewardPool(ctx).Outstanding)
+
+	// A store that is not v1 encoded fails the migration instead of being misread
+	store.Set(types.GetAccumulatedRewardsKey(addrA), []byte("not a v1 record"))
+	require.Error(t, keeper.NewMigrator(*k).Migrate1to2(ctx))
+}
+
+// TestReleaseVestedRewards tests releasing unlocked rewards between claims
+func TestReleaseVestedRewards(t *testing.T) {
+	k, ctx, bankKeeper, _, _, _, _ := Setup(t)
+
+	addr := authtypes.NewModuleAddress("provider").String()
+
+	params := types.DefaultRewardParams()
+	params.Vesting = types.VestingParams{
+		Mode:           types.VestingModeContinuous,
+		LockedFraction: sdk.OneDec(),
+		Duration:       time.Hour * 10,
+	}
+	k.SetRewardParams(ctx, params)
+
+	k.SetRewardMetrics(ctx, types.RewardMetrics{
+		TotalServiceScore: sdk.NewInt(1000),
+		TotalStaked:       sdk.NewInt(10000),
+		EpochNumber:       1,
+	})
+	k.SetAccumulatedRewards(ctx, types.AccumulatedRewards{Address: addr, Rewards: servCoins(1000)})
+	k.SetRewardPool(ctx, types.RewardPool{Outstanding: servCoins(1000)})
+
+	claimed, err := k.ClaimRewards(ctx, addr)
+	require.NoError(t, err)
+	require.True(t, claimed.IsZero())
+
+	// Nothing has unlocked yet
+	_, err = k.ReleaseVestedRewards(ctx, addr)
+	require.Error(t, err)
+
+	// Unlocked rewards are released in the same epoch as the claim, as often as needed
+	ctx = ctx.WithBlockTime(ctx.BlockTime().Add(time.Hour * 3))
+	released, err := k.ReleaseVestedRewards(ctx, addr)
+	require.NoError(t, err)
+	require.Equal(t, servCoins(300), released)
+	require.Equal(t, servCoins(300), bankKeeper.SentCoins)
+	require.Equal(t, servCoins(700), k.GetRewardPool(ctx).Vesting)
+
+	ctx = ctx.WithBlockTime(ctx.BlockTime().Add(time.Hour * 7))
+	released, err = k.ReleaseVestedRewards(ctx, addr)
+	require.NoError(t, err)
+	require.Equal(t, servCoins(700), released)
+	require.True(t, k.GetRewardPool(ctx).Vesting.IsZero())
+	require.Empty(t, k.GetVestingRewards(ctx, addr).Entries)
+
+	// Vesting rewards the pool does not account for fail to release instead of panicking
+	k.SetVestingRewards(ctx, types.VestingRewards{
+		Address: addr,
+		Entries: []types.VestingEntry{{Amount: servCoins(100), StartTime: ctx.BlockTime(), EndTime: ctx.BlockTime()}},
+	})
+	_, err = k.ReleaseVestedRewards(ctx, addr)
+	require.Error(t, err)
+	require.Equal(t, servCoins(700), bankKeeper.SentCoins)
+	require.True(t, k.GetRewardPool(ctx).Vesting.IsZero())
+}
+
+// TestExpireAgedRewards tests that only the aged part of an unclaimed balance expires
+func TestExpireAgedRewards(t *testing.T) {
+	k, ctx, _, _, _, _, _ := Setup(t)
+
+	addr := authtypes.NewModuleAddress("provider").String()
+
+	params := types.DefaultRewardParams()
+	params.UnclaimedExpiryEpochs = 3
+	params.ExpiryWarningEpochs = 1
+	k.SetRewardParams(ctx, params)
+
+	for epoch, amount := range map[uint64]int64{2: 100, 4: 50, 5: 30} {
+		k.SetAddressEpochReward(ctx, types.AddressEpochReward{Address: addr, EpochNumber: epoch, Amount: servCoins(amount)})
+	}
+	k.SetAccumulatedRewards(ctx, types.AccumulatedRewards{Address: addr, Rewards: servCoins(180), UnclaimedSince: 2})
+	k.SetRewardPool(ctx, types.RewardPool{Available: sdk.NewCoins(), Outstanding: servCoins(180)})
+
+	// Only the rewards of epoch 2 are warned about
+	ctx = ctx.WithEventManager(sdk.NewEventManager())
+	k.ExpireUnclaimedRewards(ctx, 4)
+	var warned []string
+	for _, event := range ctx.EventManager().Events() {
+		if event.Type != types.EventTypeRewardsExpiring {
+			continue
+		}
+		for _, attr := range event.Attributes {
+			if attr.Key == types.AttributeKeyAmount {
+				warned = append(warned, attr.Value)
+			}
+		}
+	}
+	require.Equal(t, []string{servCoins(100).String()}, warned)
+
+	// The rewards of epoch 2 expire, the fresher rewards age from epoch 4
+	k.ExpireUnclaimedRewards(ctx, 5)
+	rewards := k.GetAccumulatedRewards(ctx, addr)
+	require.Equal(t, servCoins(80), rewards.Rewards)
+	require.Equal(t, uint64(4), rewards.UnclaimedSince)
+
+	k.ExpireUnclaimedRewards(ctx, 6)
+	require.Equal(t, servCoins(80), k.GetAccumulatedRewards(ctx, addr).Rewards)
+
+	k.ExpireUnclaimedRewards(ctx, 7)
+	rewards = k.GetAccumulatedRewards(ctx, addr)
+	require.Equal(t, servCoins(30), rewards.Rewards)
+	require.Equal(t, uint64(5), rewards.UnclaimedSince)
+
+	pool := k.GetRewardPool(ctx)
+	require.Equal(t, servCoins(150), pool.Available)
+	require.Equal(t, servCoins(30), pool.Outstanding)
+}
+
+// TestDelegationChangeSettlement tests that failing to pay out delegator
+// rewards never blocks a delegation change
+func TestDelegationChangeSettlement(t *testing.T) {
+	k, ctx, bankKeeper, stakingKeeper, _, _, _ := Setup(t)
+
+	provider := authtypes.NewModuleAddress("provider")
+	valAddr := sdk.ValAddress(provider)
+	stakingKeeper.SetValidator(valAddr)
+
+	delegator := authtypes.NewModuleAddress("delegator")
+	_, err := stakingKeeper.Delegate(ctx, delegator, sdk.NewInt(100), stakingtypes.Unbonded, stakingKeeper.Validators[valAddr.String()], true)
+	require.NoError(t, err)
+
+	k.SetRewardMetrics(ctx, types.RewardMetrics{EpochNumber: 1})
+	k.SetDelegatorRewardsPool(ctx, types.DelegatorRewardsPool{
+		Provider:    provider.String(),
+		Ratio:       sdk.NewDecCoins(sdk.NewDecCoin(types.DefaultRewardDenom, sdk.NewInt(2))),
+		Outstanding: servCoins(200),
+	})
+	k.SetRewardPool(ctx, types.RewardPool{Available: sdk.NewCoins(), Outstanding: servCoins(200)})
+
+	// The module account cannot pay out, the delegation change still goes through
+	bankKeeper.SendErr = fmt.Errorf("insufficient funds")
+	require.NoError(t, k.StakingHooks().BeforeDelegationSharesModified(ctx, delegator, valAddr))
+	require.NoError(t, k.StakingHooks().AfterDelegationModified(ctx, delegator, valAddr))
+
+	// The accrued rewards are credited instead and remain outstanding
+	require.True(t, k.DelegatorRewards(ctx, delegator, provider.String()).IsZero())
+	require.Equal(t, servCoins(200), k.GetAccumulatedRewards(ctx, delegator.String()).Rewards)
+	require.Equal(t, servCoins(200), k.GetRewardPool(ctx).Outstanding)
+
+	// They are paid out with the next claim
+	bankKeeper.SendErr = nil
+	claimed, err := k.ClaimRewards(ctx, delegator.String())
+	require.NoError(t, err)
+	require.Equal(t, servCoins(200), claimed)
+	require.True(t, k.GetRewardPool(ctx).Outstanding.IsZero())
+}
@@ -7,9 +7,12 @@ import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	paramskeeper "github.com/cosmos/cosmos-sdk/x/params/keeper"
 	paramstypes "github.com/cosmos/cosmos-sdk/x/params/types"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+	"github.com/serv-chain/serv/x/servrewards/types"
 	"github.com/tendermint/tendermint/libs/log"
 	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
 	tmdb "github.com/tendermint/tm-db"
+	"sort"
 	"testing"
 )
 
@@ -18,11 +21,19 @@ type MockBankKeeper struct {
 	MintedCoins     sdk.Coins
 	SentCoins       sdk.Coins
 	SentCoinsToAddr sdk.AccAddress
+	ModuleTransfers sdk.Coins
+	Balances        map[string]sdk.Coins
+	Supply          sdk.Coins
+	Blocked         map[string]bool
+	SendErr         error // Returned by sends to accounts when set
 }
 
 // NewMockBankKeeper returns a new mock bank keeper
 func NewMockBankKeeper() *MockBankKeeper {
-	return &MockBankKeeper{}
+	return &MockBankKeeper{
+		Balances: make(map[string]sdk.Coins),
+		Blocked:  make(map[string]bool),
+	}
 }
 
 // MintCoins implements the BankKeeper interface
@@ -33,15 +44,77 @@ func (k *MockBankKeeper) MintCoins(ctx sdk.Context, moduleName string, amt sdk.C
 
 // SendCoinsFromModuleToAccount implements the BankKeeper interface
 func (k *MockBankKeeper) SendCoinsFromModuleToAccount(ctx sdk.Context, senderModule string, recipientAddr sdk.AccAddress, amt sdk.Coins) error {
+	if k.SendErr != nil {
+		return k.SendErr
+	}
 	k.SentCoins = amt
 	k.SentCoinsToAddr = recipientAddr
 	return nil
 }
 
+// SendCoinsFromModuleToModule implements the BankKeeper interface
+func (k *MockBankKeeper) SendCoinsFromModuleToModule(ctx sdk.Context, senderModule, recipientModule string, amt sdk.Coins) error {
+	k.ModuleTransfers = k.ModuleTransfers.Add(amt...)
+	return nil
+}
+
+// GetBalance implements the BankKeeper interface
+func (k *MockBankKeeper) GetBalance(ctx sdk.Context, addr sdk.AccAddress, denom string) sdk.Coin {
+	return sdk.NewCoin(denom, k.Balances[addr.String()].AmountOf(denom))
+}
+
+// SetBalance sets the balance of an address for testing
+func (k *MockBankKeeper) SetBalance(addr sdk.AccAddress, coins sdk.Coins) {
+	k.Balances[addr.String()] = coins
+}
+
+// GetSupply implements the BankKeeper interface
+func (k *MockBankKeeper) GetSupply(ctx sdk.Context, denom string) sdk.Coin {
+	return sdk.NewCoin(denom, k.Supply.AmountOf(denom))
+}
+
+// BlockedAddr implements the BankKeeper interface
+func (k *MockBankKeeper) BlockedAddr(addr sdk.AccAddress) bool {
+	return k.Blocked[addr.String()]
+}
+
+// MockDistrKeeper is a mock of the distribution keeper for testing
+type MockDistrKeeper struct {
+	CommunityPool sdk.DecCoins
+	Distributed   sdk.Coins
+	Funded        sdk.Coins
+}
+
+// NewMockDistrKeeper returns a new mock distribution keeper
+func NewMockDistrKeeper() *MockDistrKeeper {
+	return &MockDistrKeeper{}
+}
+
+// GetFeePoolCommunityCoins implements the DistrKeeper interface
+func (k *MockDistrKeeper) GetFeePoolCommunityCoins(ctx sdk.Context) sdk.DecCoins {
+	return k.CommunityPool
+}
+
+// DistributeFromFeePool implements the DistrKeeper interface
+func (k *MockDistrKeeper) DistributeFromFeePool(ctx sdk.Context, amount sdk.Coins, receiveAddr sdk.AccAddress) error {
+	k.CommunityPool = k.CommunityPool.Sub(sdk.NewDecCoinsFromCoins(amount...))
+	k.Distributed = k.Distributed.Add(amount...)
+	return nil
+}
+
+// FundCommunityPool implements the DistrKeeper interface
+func (k *MockDistrKeeper) FundCommunityPool(ctx sdk.Context, amount sdk.Coins, sender sdk.AccAddress) error {
+	k.CommunityPool = k.CommunityPool.Add(sdk.NewDecCoinsFromCoins(amount...)...)
+	k.Funded = k.Funded.Add(amount...)
+	return nil
+}
+
 // MockStakingKeeper is a mock of the staking keeper for testing
 type MockStakingKeeper struct {
 	DelegatorStakes map[string]sdk.Int
 	TotalBonded     sdk.Int
+	Validators      map[string]stakingtypes.Validator
+	Delegations     map[string]sdk.Int
 }
 
 // NewMockStakingKeeper returns a new mock staking keeper
@@ -49,12 +122,18 @@ func NewMockStakingKeeper() *MockStakingKeeper {
 	return &MockStakingKeeper{
 		DelegatorStakes: make(map[string]sdk.Int),
 		TotalBonded:     sdk.ZeroInt(),
+		Validators:      make(map[string]stakingtypes.Validator),
+		Delegations:     make(map[string]sdk.Int),
 	}
 }
 
 // GetDelegatorStake implements the StakingKeeper interface
 func (k *MockStakingKeeper) GetDelegatorStake(ctx sdk.Context, delegator sdk.AccAddress) sdk.Int {
-	return k.DelegatorStakes[delegator.String()]
+	stake, found := k.DelegatorStakes[delegator.String()]
+	if !found {
+		return sdk.ZeroInt()
+	}
+	return stake
 }
 
 // SetDelegatorStake sets the delegator stake for testing
@@ -72,6 +151,74 @@ func (k *MockStakingKeeper) SetTotalBondedTokens(bonded sdk.Int) {
 	k.TotalBonded = bonded
 }
 
+// BondDenom implements the StakingKeeper interface
+func (k *MockStakingKeeper) BondDenom(ctx sdk.Context) string {
+	return types.DefaultRewardDenom
+}
+
+// GetValidator implements the StakingKeeper interface
+func (k *MockStakingKeeper) GetValidator(ctx sdk.Context, addr sdk.ValAddress) (stakingtypes.Validator, bool) {
+	validator, found := k.Validators[addr.String()]
+	return validator, found
+}
+
+// SetValidator adds a validator for testing
+func (k *MockStakingKeeper) SetValidator(addr sdk.ValAddress) {
+	k.Validators[addr.String()] = stakingtypes.Validator{OperatorAddress: addr.String(), DelegatorShares: sdk.ZeroDec()}
+}
+
+// GetDelegation implements the StakingKeeper interface
+func (k *MockStakingKeeper) GetDelegation(ctx sdk.Context, delAddr sdk.AccAddress, valAddr sdk.ValAddress) (stakingtypes.Delegation, bool) {
+	amount, found := k.Delegations[delAddr.String()+"/"+valAddr.String()]
+	if !found {
+		return stakingtypes.Delegation{}, false
+	}
+	return stakingtypes.NewDelegation(delAddr, valAddr, sdk.NewDecFromInt(amount)), true
+}
+
+// Delegate implements the StakingKeeper interface
+func (k *MockStakingKeeper) Delegate(ctx sdk.Context, delAddr sdk.AccAddress, bondAmt sdk.Int, tokenSrc stakingtypes.BondStatus, validator stakingtypes.Validator, subtractAccount bool) (sdk.Dec, error) {
+	key := delAddr.String() + "/" + validator.OperatorAddress
+	if _, found := k.Delegations[key]; !found {
+		k.Delegations[key] = sdk.ZeroInt()
+	}
+	k.Delegations[key] = k.Delegations[key].Add(bondAmt)
+	if validator, found := k.Validators[validator.OperatorAddress]; found {
+		validator.DelegatorShares = validator.DelegatorShares.Add(sdk.NewDecFromInt(bondAmt))
+		k.Validators[validator.OperatorAddress] = validator
+	}
+	return sdk.NewDecFromInt(bondAmt), nil
+}
+
+// MockSlashingKeeper is a mock of the slashing keeper for testing
+type MockSlashingKeeper struct {
+	Tombstoned         map[string]bool
+	DoubleSignFraction sdk.Dec
+}
+
+// NewMockSlashingKeeper returns a new mock slashing keeper with a double sign slash fraction of 5%
+func NewMockSlashingKeeper() *MockSlashingKeeper {
+	return &MockSlashingKeeper{
+		Tombstoned:         make(map[string]bool),
+		DoubleSignFraction: sdk.NewDecWithPrec(5, 2),
+	}
+}
+
+// IsTombstoned implements the SlashingKeeper interface
+func (k *MockSlashingKeeper) IsTombstoned(ctx sdk.Context, consAddr sdk.ConsAddress) bool {
+	return k.Tombstoned[consAddr.String()]
+}
+
+// Tombstone marks a validator as tombstoned for testing
+func (k *MockSlashingKeeper) Tombstone(consAddr sdk.ConsAddress) {
+	k.Tombstoned[consAddr.String()] = true
+}
+
+// SlashFractionDoubleSign implements the SlashingKeeper interface
+func (k *MockSlashingKeeper) SlashFractionDoubleSign(ctx sdk.Context) sdk.Dec {
+	return k.DoubleSignFraction
+}
+
 // MockPosKeeper is a mock of the proof of service keeper for testing
 type MockPosKeeper struct {
 	ServiceScores map[string]sdk.Int
@@ -88,7 +235,11 @@ func NewMockPosKeeper() *MockPosKeeper {
 
 // GetServiceScore implements the ProofOfServiceKeeper interface
 func (k *MockPosKeeper) GetServiceScore(ctx sdk.Context, addr string) sdk.Int {
-	return k.ServiceScores[addr]
+	score, found := k.ServiceScores[addr]
+	if !found {
+		return sdk.ZeroInt()
+	}
+	return score
 }
 
 // SetServiceScore sets the service score for testing
@@ -101,6 +252,21 @@ func (k *MockPosKeeper) GetTotalServiceScore(ctx sdk.Context) sdk.Int {
 	return k.TotalScore
 }
 
+// IterateServiceScores implements the ProofOfServiceKeeper interface
+func (k *MockPosKeeper) IterateServiceScores(ctx sdk.Context, cb func(provider string, score sdk.Int) (stop bool)) {
+	providers := make([]string, 0, len(k.ServiceScores))
+	for provider := range k.ServiceScores {
+		providers = append(providers, provider)
+	}
+	sort.Strings(providers)
+
+	for _, provider := range providers {
+		if cb(provider, k.ServiceScores[provider]) {
+			return
+		}
+	}
+}
+
 // SetTotalServiceScore sets the total service score for testing
 func (k *MockPosKeeper) SetTotalServiceScore(score sdk.Int) {
 	k.TotalScore = score
@@ -0,0 +1,131 @@
+package keeper
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/serv-chain/serv/x/servrewards/types"
+)
+
+// GetVestingRewards returns the vesting claims of an address
+func (k Keeper) GetVestingRewards(ctx sdk.Context, addr string) types.VestingRewards {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(types.GetVestingRewardsKey(addr))
+	if bz == nil {
+		return types.VestingRewards{
+			Address:  addr,
+			Entries:  []types.VestingEntry{},
+			Released: sdk.NewCoins(),
+		}
+	}
+
+	var vesting types.VestingRewards
+	k.cdc.MustUnmarshal(bz, &vesting)
+	return vesting
+}
+
+// SetVestingRewards sets the vesting claims of an address, removing them once
+// nothing is left to vest
+func (k Keeper) SetVestingRewards(ctx sdk.Context, vesting types.VestingRewards) {
+	store := ctx.KVStore(k.storeKey)
+	key := types.GetVestingRewardsKey(vesting.Address)
+	if len(vesting.Entries) == 0 {
+		store.Delete(key)
+		return
+	}
+
+	bz := k.cdc.MustMarshal(&vesting)
+	store.Set(key, bz)
+}
+
+// IterateVestingRewards iterates over the vesting claims of all addresses
+func (k Keeper) IterateVestingRewards(ctx sdk.Context, cb func(vesting types.VestingRewards) (stop bool)) {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, types.VestingRewardsPrefix)
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		var vesting types.VestingRewards
+		k.cdc.MustUnmarshal(iterator.Value(), &vesting)
+		if cb(vesting) {
+			break
+		}
+	}
+}
+
+// VestingBalances returns the locked reward balance of an address and the
+// unlocked balance it can release with its next claim or MsgReleaseVestedRewards
+func (k Keeper) VestingBalances(ctx sdk.Context, addr string) (locked, unlocked sdk.Coins) {
+	return k.GetVestingRewards(ctx, addr).Balances(ctx.BlockTime())
+}
+
+// ReleaseVestedRewards pays out whatever the vesting claims of an address have
+// unlocked so far to its reward withdraw address. Unlike a claim it pays out
+// no new rewards, so it is not limited to once per epoch. It returns the
+// amount released.
+func (k Keeper) ReleaseVestedRewards(ctx sdk.Context, addr string) (sdk.Coins, error) {
+	accAddr, err := sdk.AccAddressFromBech32(addr)
+	if err != nil {
+		return sdk.NewCoins(), err
+	}
+
+	unlocked := k.releaseVestedRewards(ctx, addr)
+	if unlocked.IsZero() {
+		return unlocked, fmt.Errorf("no vested rewards to release")
+	}
+
+	pool := k.GetRewardPool(ctx)
+	if err := subVesting(&pool, unlocked); err != nil {
+		return sdk.NewCoins(), err
+	}
+
+	recipient := k.GetRewardWithdrawAddress(ctx, accAddr)
+	if err := k.bankKeeper.SendCoinsFromModuleToAccount(ctx, types.ModuleName, recipient, unlocked); err != nil {
+		return sdk.NewCoins(), err
+	}
+	k.SetRewardPool(ctx, pool)
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			types.EventTypeVestedRewardsReleased,
+			sdk.NewAttribute(types.AttributeKeyAddress, addr),
+			sdk.NewAttribute(types.AttributeKeyWithdrawAddress, recipient.String()),
+			sdk.NewAttribute(types.AttributeKeyAmount, unlocked.String()),
+		),
+	)
+
+	return unlocked, nil
+}
+
+// lockRewards starts vesting amount for an address under the current vesting params
+func (k Keeper) lockRewards(ctx sdk.Context, addr string, amount sdk.Coins) {
+	if amount.IsZero() {
+		return
+	}
+
+	params := k.GetRewardParams(ctx)
+	vesting := k.GetVestingRewards(ctx, addr)
+	vesting.Entries = append(vesting.Entries, params.Vesting.NewVestingEntry(amount, ctx.BlockTime()))
+	k.SetVestingRewards(ctx, vesting)
+}
+
+// releaseVestedRewards marks everything unlocked so far as released and
+// returns the newly released amount. Fully unlocked entries are dropped.
+func (k Keeper) releaseVestedRewards(ctx sdk.Context, addr string) sdk.Coins {
+	vesting := k.GetVestingRewards(ctx, addr)
+	_, unlocked := vesting.Balances(ctx.BlockTime())
+	vesting.Released = vesting.Released.Add(unlocked...)
+
+	entries := make([]types.VestingEntry, 0, len(vesting.Entries))
+	for _, entry := range vesting.Entries {
+		if ctx.BlockTime().Before(entry.EndTime) {
+			entries = append(entries, entry)
+			continue
+		}
+		vesting.Released = vesting.Released.Sub(entry.Amount...)
+	}
+	vesting.Entries = entries
+
+	k.SetVestingRewards(ctx, vesting)
+	return unlocked
+}
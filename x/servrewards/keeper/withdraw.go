@@ -0,0 +1,61 @@
+package keeper
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/serv-chain/serv/x/servrewards/types"
+)
+
+// GetRewardWithdrawAddress returns the address the claimed rewards of addr
+// are sent to, which is addr itself unless a withdraw address is set
+func (k Keeper) GetRewardWithdrawAddress(ctx sdk.Context, addr sdk.AccAddress) sdk.AccAddress {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(types.GetWithdrawAddressKey(addr.String()))
+	if bz == nil {
+		return addr
+	}
+
+	return sdk.AccAddress(bz)
+}
+
+// SetRewardWithdrawAddress sets the address the claimed rewards of addr are
+// sent to. Blocked addresses, such as module accounts, are rejected. Setting
+// addr itself removes the withdraw address.
+func (k Keeper) SetRewardWithdrawAddress(ctx sdk.Context, addr, withdrawAddr sdk.AccAddress) error {
+	if k.bankKeeper.BlockedAddr(withdrawAddr) {
+		return fmt.Errorf("%s is not allowed to receive rewards", withdrawAddr)
+	}
+
+	store := ctx.KVStore(k.storeKey)
+	key := types.GetWithdrawAddressKey(addr.String())
+	if withdrawAddr.Equals(addr) {
+		store.Delete(key)
+	} else {
+		store.Set(key, withdrawAddr.Bytes())
+	}
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			types.EventTypeSetWithdrawAddress,
+			sdk.NewAttribute(types.AttributeKeyAddress, addr.String()),
+			sdk.NewAttribute(types.AttributeKeyWithdrawAddress, withdrawAddr.String()),
+		),
+	)
+
+	return nil
+}
+
+// IterateRewardWithdrawAddresses iterates over all addresses with a withdraw address set
+func (k Keeper) IterateRewardWithdrawAddresses(ctx sdk.Context, cb func(addr, withdrawAddr sdk.AccAddress) (stop bool)) {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, types.WithdrawAddressPrefix)
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		addr := sdk.MustAccAddressFromBech32(string(iterator.Key()[len(types.WithdrawAddressPrefix):]))
+		if cb(addr, sdk.AccAddress(iterator.Value())) {
+			break
+		}
+	}
+}
@@ -0,0 +1,101 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Names of the modifiers reported in a RewardBreakdown
+const (
+	// ModifierVesting is the fraction of a claim paid out immediately, the rest vests
+	ModifierVesting = "vesting"
+	// ModifierCommission is the fraction of a provider's reward it keeps, the rest is shared with its delegators
+	ModifierCommission = "commission"
+	// ModifierRewardCurve is the share under the active reward curve relative to the linear share
+	ModifierRewardCurve = "reward_curve"
+)
+
+// RewardModifier is an adjustment applied to rewards on top of the service and staking shares
+type RewardModifier struct {
+	Name        string  `json:"name"`
+	Factor      sdk.Dec `json:"factor"`
+	Description string  `json:"description"`
+}
+
+// RewardBreakdown explains how the reward of an address is made up
+type RewardBreakdown struct {
+	Address            string           `json:"address"`
+	ServiceScore       sdk.Int          `json:"service_score"`
+	TotalServiceScore  sdk.Int          `json:"total_service_score"`
+	ServiceShare       sdk.Dec          `json:"service_share"` // ServiceScore / TotalServiceScore
+	Stake              sdk.Int          `json:"stake"`
+	TotalStaked        sdk.Int          `json:"total_staked"`
+	StakeShare         sdk.Dec          `json:"stake_share"` // Stake / TotalStaked
+	ServiceScoreWeight sdk.Dec          `json:"service_score_weight"`
+	StakingWeight      sdk.Dec          `json:"staking_weight"`
+	EpochBudget        sdk.Coins        `json:"epoch_budget"`   // Amount shared out in the epoch
+	ServiceReward      sdk.DecCoins     `json:"service_reward"` // EpochBudget * ServiceScoreWeight * ServiceShare
+	StakingReward      sdk.DecCoins     `json:"staking_reward"` // EpochBudget * StakingWeight * StakeShare
+	Reward             sdk.Coins        `json:"reward"`         // ServiceReward + StakingReward, truncated
+	Modifiers          []RewardModifier `json:"modifiers"`
+}
+
+// NewRewardBreakdown computes the share of budget earned by an address with
+// the given service score and stake. Shares of an empty total are zero.
+func NewRewardBreakdown(params RewardParams, addr string, budget sdk.Coins, score, totalScore, stake, totalStaked sdk.Int) RewardBreakdown {
+	serviceShare := sdk.ZeroDec()
+	if totalScore.IsPositive() {
+		serviceShare = sdk.NewDecFromInt(score).Quo(sdk.NewDecFromInt(totalScore))
+	}
+
+	stakeShare := sdk.ZeroDec()
+	if totalStaked.IsPositive() {
+		stakeShare = sdk.NewDecFromInt(stake).Quo(sdk.NewDecFromInt(totalStaked))
+	}
+
+	serviceReward := sdk.NewDecCoins()
+	stakingReward := sdk.NewDecCoins()
+	reward := sdk.NewCoins()
+	for _, coin := range budget {
+		amount := sdk.NewDecFromInt(coin.Amount)
+
+		// Divide last to keep the precision of the shares
+		service := sdk.ZeroDec()
+		if totalScore.IsPositive() {
+			service = amount.Mul(params.ServiceScoreWeight).MulInt(score).Quo(sdk.NewDecFromInt(totalScore))
+		}
+		staking := sdk.ZeroDec()
+		if totalStaked.IsPositive() {
+			staking = amount.Mul(params.StakingWeight).MulInt(stake).Quo(sdk.NewDecFromInt(totalStaked))
+		}
+
+		serviceReward = serviceReward.Add(sdk.NewDecCoinFromDec(coin.Denom, service))
+		stakingReward = stakingReward.Add(sdk.NewDecCoinFromDec(coin.Denom, staking))
+		reward = reward.Add(sdk.NewCoin(coin.Denom, service.Add(staking).TruncateInt()))
+	}
+
+	modifiers := []RewardModifier{}
+	if params.Vesting.Mode != VestingModeNone {
+		modifiers = append(modifiers, RewardModifier{
+			Name:        ModifierVesting,
+			Factor:      sdk.OneDec().Sub(params.Vesting.LockedFraction),
+			Description: "fraction of a claim paid out immediately, the rest vests over " + params.Vesting.Duration.String(),
+		})
+	}
+
+	return RewardBreakdown{
+		Address:            addr,
+		ServiceScore:       score,
+		TotalServiceScore:  totalScore,
+		ServiceShare:       serviceShare,
+		Stake:              stake,
+		TotalStaked:        totalStaked,
+		StakeShare:         stakeShare,
+		ServiceScoreWeight: params.ServiceScoreWeight,
+		StakingWeight:      params.StakingWeight,
+		EpochBudget:        budget,
+		ServiceReward:      serviceReward,
+		StakingReward:      stakingReward,
+		Reward:             reward,
+		Modifiers:          modifiers,
+	}
+}
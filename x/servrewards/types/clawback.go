@@ -0,0 +1,41 @@
+package types
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Reasons rewards are clawed back for
+const (
+	// ClawbackReasonProofRevoked claws back rewards earned with a revoked proof of service
+	ClawbackReasonProofRevoked = "proof_revoked"
+	// ClawbackReasonSlashed claws back rewards earned by a validator slashed for double signing
+	ClawbackReasonSlashed = "slashed"
+)
+
+// ClawbackRecord is the part of the earnings of an address in an epoch that
+// has been clawed back, whether taken from its unclaimed rewards or recorded
+// as debt. Earnings are never clawed back beyond what was earned.
+type ClawbackRecord struct {
+	Address     string    `json:"address"`
+	EpochNumber uint64    `json:"epoch_number"`
+	Amount      sdk.Coins `json:"amount"`
+}
+
+// ClawbackDebt is the part of the clawed back rewards of an address that
+// exceeded its unclaimed rewards. It is repaid out of future rewards before
+// they are credited.
+type ClawbackDebt struct {
+	Address string    `json:"address"`
+	Amount  sdk.Coins `json:"amount"`
+}
+
+// ValidateClawbackFraction checks that fraction is a valid share of earnings to claw back
+func ValidateClawbackFraction(fraction sdk.Dec) error {
+	if fraction.IsNil() || !fraction.IsPositive() || fraction.GT(sdk.OneDec()) {
+		return fmt.Errorf("clawback fraction must be in (0, 1]: %s", fraction)
+	}
+
+	return nil
+}
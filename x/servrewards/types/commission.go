@@ -0,0 +1,104 @@
+package types
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// ProviderCommission opts a provider into sharing its rewards with the
+// delegators of its validator. The provider keeps Rate of every reward and the
+// rest is split among the delegators by their delegation shares.
+type ProviderCommission struct {
+	Provider      string  `json:"provider"`
+	Rate          sdk.Dec `json:"rate"`
+	MaxRate       sdk.Dec `json:"max_rate"`        // Highest Rate the provider can ever set
+	MaxChangeRate sdk.Dec `json:"max_change_rate"` // Largest change of Rate per epoch
+	UpdateEpoch   uint64  `json:"update_epoch"`    // Epoch in which Rate was last changed
+}
+
+// DelegatorRewardsPool holds the part of the rewards of a provider shared with
+// the delegators of its validator
+type DelegatorRewardsPool struct {
+	Provider    string       `json:"provider"`
+	Ratio       sdk.DecCoins `json:"ratio"`       // Cumulative rewards per delegation share
+	Outstanding sdk.Coins    `json:"outstanding"` // Shared but not yet withdrawn by delegators
+}
+
+// DelegatorStartingRatio is the Ratio of a provider's delegator rewards pool at
+// the last withdrawal of a delegator. Everything accrued per share since is
+// owed to the delegator.
+type DelegatorStartingRatio struct {
+	Provider  string       `json:"provider"`
+	Delegator string       `json:"delegator"`
+	Ratio     sdk.DecCoins `json:"ratio"`
+}
+
+// NewProviderCommission creates a new ProviderCommission instance
+func NewProviderCommission(provider string, rate, maxRate, maxChangeRate sdk.Dec, epoch uint64) ProviderCommission {
+	return ProviderCommission{
+		Provider:      provider,
+		Rate:          rate,
+		MaxRate:       maxRate,
+		MaxChangeRate: maxChangeRate,
+		UpdateEpoch:   epoch,
+	}
+}
+
+// Validate performs basic validation of a provider commission
+func (c ProviderCommission) Validate() error {
+	if c.MaxRate.IsNil() || c.MaxRate.IsNegative() || c.MaxRate.GT(sdk.OneDec()) {
+		return fmt.Errorf("max commission rate must be between 0 and 1: %s", c.MaxRate)
+	}
+
+	if c.Rate.IsNil() || c.Rate.IsNegative() || c.Rate.GT(c.MaxRate) {
+		return fmt.Errorf("commission rate must be between 0 and the max rate %s: %s", c.MaxRate, c.Rate)
+	}
+
+	if c.MaxChangeRate.IsNil() || c.MaxChangeRate.IsNegative() || c.MaxChangeRate.GT(c.MaxRate) {
+		return fmt.Errorf("max commission change rate must be between 0 and the max rate %s: %s", c.MaxRate, c.MaxChangeRate)
+	}
+
+	return nil
+}
+
+// ValidateNewRate checks that the commission rate may change to rate in epoch
+func (c ProviderCommission) ValidateNewRate(rate sdk.Dec, epoch uint64) error {
+	if epoch <= c.UpdateEpoch {
+		return fmt.Errorf("commission rate can only be changed once per epoch")
+	}
+
+	if rate.IsNegative() || rate.GT(c.MaxRate) {
+		return fmt.Errorf("commission rate must be between 0 and the max rate %s: %s", c.MaxRate, rate)
+	}
+
+	if rate.Sub(c.Rate).Abs().GT(c.MaxChangeRate) {
+		return fmt.Errorf("commission rate cannot change by more than %s per epoch", c.MaxChangeRate)
+	}
+
+	return nil
+}
+
+// Split splits reward into the commission kept by the provider and the part
+// shared with delegators
+func (c ProviderCommission) Split(reward sdk.Coins) (commission, shared sdk.Coins) {
+	commission = sdk.NewCoins()
+	for _, coin := range reward {
+		amount := sdk.NewDecFromInt(coin.Amount).Mul(c.Rate).TruncateInt()
+		commission = commission.Add(sdk.NewCoin(coin.Denom, amount))
+	}
+
+	return commission, reward.Sub(commission...)
+}
+
+// Rewards returns what a delegator with shares is owed since it last withdrew at startingRatio
+func (p DelegatorRewardsPool) Rewards(shares sdk.Dec, startingRatio sdk.DecCoins) sdk.Coins {
+	// The ratio only grows, but guard against a starting ratio from a different pool
+	accrued, hasNeg := p.Ratio.SafeSub(startingRatio)
+	if hasNeg {
+		return sdk.NewCoins()
+	}
+
+	rewards, _ := accrued.MulDecTruncate(shares).TruncateDecimal()
+	return rewards.Min(p.Outstanding)
+}
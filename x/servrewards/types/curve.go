@@ -0,0 +1,176 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Names of the built-in reward curves
+const (
+	// RewardCurveLinear shares the budget in proportion to score and stake
+	RewardCurveLinear = "linear"
+	// RewardCurveSqrt shares the service part of the budget by the square root of the score
+	RewardCurveSqrt = "sqrt"
+	// RewardCurveQuadratic favours providers backed by both service and stake, like quadratic funding
+	RewardCurveQuadratic = "quadratic"
+	// RewardCurveCapped shares linearly but caps the share of any single provider at MaxProviderShare
+	RewardCurveCapped = "capped"
+)
+
+// Participant is a service provider taking part in the allocation of an epoch
+type Participant struct {
+	Address      string
+	ServiceScore sdk.Int
+	Stake        sdk.Int
+}
+
+// RewardCurve decides the share of the epoch budget every participant earns.
+// Shares must be non-negative and add up to at most one; whatever they leave
+// is carried into the next epoch with the remainder. totalScore and
+// totalStaked are the network totals, which may include stake and score of
+// addresses that are not participants.
+type RewardCurve interface {
+	// Name is the name RewardParams.RewardCurve selects the curve by
+	Name() string
+	// Shares returns the share of every participant, in the order given
+	Shares(params RewardParams, participants []Participant, totalScore, totalStaked sdk.Int) []sdk.Dec
+}
+
+// BuiltinRewardCurves returns the reward curves every keeper starts with
+func BuiltinRewardCurves() []RewardCurve {
+	return []RewardCurve{LinearCurve{}, SqrtCurve{}, QuadraticCurve{}, CappedCurve{}}
+}
+
+// LinearCurve blends the score share and stake share of a participant by the
+// service score and staking weights
+type LinearCurve struct{}
+
+var _ RewardCurve = LinearCurve{}
+
+// Name implements RewardCurve
+func (LinearCurve) Name() string { return RewardCurveLinear }
+
+// Shares implements RewardCurve
+func (LinearCurve) Shares(params RewardParams, participants []Participant, totalScore, totalStaked sdk.Int) []sdk.Dec {
+	shares := make([]sdk.Dec, len(participants))
+	for i, p := range participants {
+		shares[i] = weightedShare(params.ServiceScoreWeight, sdk.NewDecFromInt(p.ServiceScore), sdk.NewDecFromInt(totalScore)).
+			Add(weightedShare(params.StakingWeight, sdk.NewDecFromInt(p.Stake), sdk.NewDecFromInt(totalStaked)))
+	}
+
+	return shares
+}
+
+// SqrtCurve shares the service part of the budget by the square root of the
+// score, so every extra point of score earns less than the one before. The
+// staking part stays linear.
+type SqrtCurve struct{}
+
+var _ RewardCurve = SqrtCurve{}
+
+// Name implements RewardCurve
+func (SqrtCurve) Name() string { return RewardCurveSqrt }
+
+// Shares implements RewardCurve
+func (SqrtCurve) Shares(params RewardParams, participants []Participant, totalScore, totalStaked sdk.Int) []sdk.Dec {
+	roots := make([]sdk.Dec, len(participants))
+	sumRoots := sdk.ZeroDec()
+	for i, p := range participants {
+		roots[i] = sqrt(sdk.NewDecFromInt(p.ServiceScore))
+		sumRoots = sumRoots.Add(roots[i])
+	}
+
+	shares := make([]sdk.Dec, len(participants))
+	for i, p := range participants {
+		shares[i] = weightedShare(params.ServiceScoreWeight, roots[i], sumRoots).
+			Add(weightedShare(params.StakingWeight, sdk.NewDecFromInt(p.Stake), sdk.NewDecFromInt(totalStaked)))
+	}
+
+	return shares
+}
+
+// QuadraticCurve treats the score share and stake share of a participant as
+// two contributions and weighs it by the square of the sum of their roots,
+// like quadratic funding does. Providers backed by both service and stake
+// earn more than providers with the same linear share in only one of them.
+// The weights are scaled to distribute what the linear curve would.
+type QuadraticCurve struct{}
+
+var _ RewardCurve = QuadraticCurve{}
+
+// Name implements RewardCurve
+func (QuadraticCurve) Name() string { return RewardCurveQuadratic }
+
+// Shares implements RewardCurve
+func (QuadraticCurve) Shares(params RewardParams, participants []Participant, totalScore, totalStaked sdk.Int) []sdk.Dec {
+	weights := make([]sdk.Dec, len(participants))
+	sumWeights, sumLinear := sdk.ZeroDec(), sdk.ZeroDec()
+	for i, p := range participants {
+		service := weightedShare(params.ServiceScoreWeight, sdk.NewDecFromInt(p.ServiceScore), sdk.NewDecFromInt(totalScore))
+		staking := weightedShare(params.StakingWeight, sdk.NewDecFromInt(p.Stake), sdk.NewDecFromInt(totalStaked))
+
+		root := sqrt(service).Add(sqrt(staking))
+		weights[i] = root.Mul(root)
+		sumWeights = sumWeights.Add(weights[i])
+		sumLinear = sumLinear.Add(service).Add(staking)
+	}
+
+	shares := make([]sdk.Dec, len(participants))
+	for i := range participants {
+		shares[i] = weightedShare(sumLinear, weights[i], sumWeights)
+	}
+
+	return shares
+}
+
+// CappedCurve shares linearly, but no participant earns more than
+// MaxProviderShare of the budget. What the cap cuts off is carried over.
+type CappedCurve struct{}
+
+var _ RewardCurve = CappedCurve{}
+
+// Name implements RewardCurve
+func (CappedCurve) Name() string { return RewardCurveCapped }
+
+// Shares implements RewardCurve
+func (CappedCurve) Shares(params RewardParams, participants []Participant, totalScore, totalStaked sdk.Int) []sdk.Dec {
+	shares := LinearCurve{}.Shares(params, participants, totalScore, totalStaked)
+	for i := range shares {
+		shares[i] = sdk.MinDec(shares[i], params.MaxProviderShare)
+	}
+
+	return shares
+}
+
+// ShareOfBudget returns share of every denom of budget, truncated
+func ShareOfBudget(budget sdk.Coins, share sdk.Dec) sdk.Coins {
+	reward := sdk.NewCoins()
+	for _, coin := range budget {
+		reward = reward.Add(sdk.NewCoin(coin.Denom, sdk.NewDecFromInt(coin.Amount).Mul(share).TruncateInt()))
+	}
+
+	return reward
+}
+
+// weightedShare returns weight * part / total, or zero for an empty total
+func weightedShare(weight, part, total sdk.Dec) sdk.Dec {
+	if !total.IsPositive() {
+		return sdk.ZeroDec()
+	}
+
+	// Divide last to keep the precision of the share
+	return weight.Mul(part).Quo(total)
+}
+
+// sqrt returns the square root of a non-negative decimal
+func sqrt(d sdk.Dec) sdk.Dec {
+	if !d.IsPositive() {
+		return sdk.ZeroDec()
+	}
+
+	root, err := d.ApproxSqrt()
+	if err != nil {
+		return sdk.ZeroDec()
+	}
+
+	return root
+}
@@ -0,0 +1,163 @@
+package types
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Emission modes of the servrewards emission schedule
+const (
+	// EmissionModeFixed emits RewardPerEpoch every epoch
+	EmissionModeFixed = "fixed"
+	// EmissionModeHalving halves RewardPerEpoch every HalvingPeriodEpochs epochs
+	EmissionModeHalving = "halving"
+	// EmissionModeExponentialDecay reduces RewardPerEpoch by DecayRate every epoch
+	EmissionModeExponentialDecay = "exponential_decay"
+	// EmissionModeTargetInflation emits TargetInflation of the bonded supply per
+	// year in MintDenom, spread over EpochsPerYear epochs
+	EmissionModeTargetInflation = "target_inflation"
+)
+
+// maxHalvings is the number of halvings after which any sdk.Int amount is zero
+const maxHalvings = 256
+
+// MaxEmissionProjectionEpochs is the most epochs a single emission projection may cover
+const MaxEmissionProjectionEpochs = 3650
+
+// EmissionSchedule shapes how much of RewardPerEpoch is emitted in each epoch
+type EmissionSchedule struct {
+	Mode                string    `json:"mode"`
+	StartEpoch          uint64    `json:"start_epoch"`           // Epoch halvings and decay are counted from
+	HalvingPeriodEpochs uint64    `json:"halving_period_epochs"` // Epochs between halvings (halving mode)
+	DecayRate           sdk.Dec   `json:"decay_rate"`            // Per-epoch decay (exponential_decay mode)
+	TargetInflation     sdk.Dec   `json:"target_inflation"`      // Yearly emission relative to bonded supply (target_inflation mode)
+	EpochsPerYear       uint64    `json:"epochs_per_year"`       // Number of reward epochs in a year (target_inflation mode)
+	LifetimeCap         sdk.Coins `json:"lifetime_cap"`          // Max total emission per denom, denoms not listed are uncapped
+}
+
+// EmissionState tracks the total amount emitted over the lifetime of the module
+type EmissionState struct {
+	TotalEmitted sdk.Coins `json:"total_emitted"`
+}
+
+// EpochEmission is the emission of a single epoch
+type EpochEmission struct {
+	EpochNumber uint64    `json:"epoch_number"`
+	Amount      sdk.Coins `json:"amount"`
+}
+
+// DefaultEmissionSchedule returns a schedule that emits RewardPerEpoch forever
+func DefaultEmissionSchedule() EmissionSchedule {
+	return EmissionSchedule{
+		Mode:                EmissionModeFixed,
+		StartEpoch:          0,
+		HalvingPeriodEpochs: 0,
+		DecayRate:           sdk.ZeroDec(),
+		TargetInflation:     sdk.ZeroDec(),
+		EpochsPerYear:       365,
+		LifetimeCap:         sdk.NewCoins(),
+	}
+}
+
+// DefaultEmissionState returns an emission state with nothing emitted
+func DefaultEmissionState() EmissionState {
+	return EmissionState{
+		TotalEmitted: sdk.NewCoins(),
+	}
+}
+
+// Validate performs basic validation of the emission schedule
+func (s EmissionSchedule) Validate() error {
+	switch s.Mode {
+	case EmissionModeFixed:
+	case EmissionModeHalving:
+		if s.HalvingPeriodEpochs == 0 {
+			return fmt.Errorf("halving period must be positive")
+		}
+	case EmissionModeExponentialDecay:
+		if s.DecayRate.IsNegative() || s.DecayRate.GTE(sdk.OneDec()) {
+			return fmt.Errorf("decay rate must be at least 0 and less than 1: %s", s.DecayRate)
+		}
+	case EmissionModeTargetInflation:
+		if s.TargetInflation.IsNegative() || s.TargetInflation.GT(sdk.OneDec()) {
+			return fmt.Errorf("target inflation must be between 0 and 1: %s", s.TargetInflation)
+		}
+		if s.EpochsPerYear == 0 {
+			return fmt.Errorf("epochs per year must be positive")
+		}
+	default:
+		return fmt.Errorf("unknown emission mode: %q", s.Mode)
+	}
+
+	if err := s.LifetimeCap.Validate(); err != nil {
+		return fmt.Errorf("invalid lifetime cap: %w", err)
+	}
+
+	return nil
+}
+
+// Emission returns the uncapped emission of an epoch. base is RewardPerEpoch
+// and bonded the bonded token supply, which only the target_inflation mode
+// uses to replace the mintDenom amount of base.
+func (s EmissionSchedule) Emission(base sdk.Coins, mintDenom string, epoch uint64, bonded sdk.Int) sdk.Coins {
+	elapsed := uint64(0)
+	if epoch > s.StartEpoch {
+		elapsed = epoch - s.StartEpoch
+	}
+
+	emission := sdk.NewCoins()
+	switch s.Mode {
+	case EmissionModeHalving:
+		halvings := elapsed / s.HalvingPeriodEpochs
+		if halvings >= maxHalvings {
+			return emission
+		}
+		for _, coin := range base {
+			amount := coin.Amount
+			for i := uint64(0); i < halvings && amount.IsPositive(); i++ {
+				amount = amount.QuoRaw(2)
+			}
+			emission = emission.Add(sdk.NewCoin(coin.Denom, amount))
+		}
+
+	case EmissionModeExponentialDecay:
+		factor := sdk.OneDec().Sub(s.DecayRate).Power(elapsed)
+		for _, coin := range base {
+			amount := sdk.NewDecFromInt(coin.Amount).Mul(factor).TruncateInt()
+			emission = emission.Add(sdk.NewCoin(coin.Denom, amount))
+		}
+
+	case EmissionModeTargetInflation:
+		for _, coin := range base {
+			if coin.Denom != mintDenom {
+				emission = emission.Add(coin)
+			}
+		}
+		amount := sdk.NewDecFromInt(bonded).Mul(s.TargetInflation).QuoInt64(int64(s.EpochsPerYear)).TruncateInt()
+		emission = emission.Add(sdk.NewCoin(mintDenom, amount))
+
+	default:
+		emission = emission.Add(base...)
+	}
+
+	return emission
+}
+
+// ApplyCap limits emission to what is left of the lifetime cap after emitted
+func (s EmissionSchedule) ApplyCap(emission, emitted sdk.Coins) sdk.Coins {
+	capped := sdk.NewCoins()
+	for _, coin := range emission {
+		amount := coin.Amount
+		if limit := s.LifetimeCap.AmountOf(coin.Denom); limit.IsPositive() {
+			remaining := limit.Sub(emitted.AmountOf(coin.Denom))
+			if !remaining.IsPositive() {
+				continue
+			}
+			amount = sdk.MinInt(amount, remaining)
+		}
+		capped = capped.Add(sdk.NewCoin(coin.Denom, amount))
+	}
+
+	return capped
+}
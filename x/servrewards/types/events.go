@@ -0,0 +1,51 @@
+package types
+
+// servrewards module event types
+const (
+	EventTypeRewardClaimed            = "reward_claimed"
+	EventTypeEpochCompleted           = "epoch_completed"
+	EventTypeRewardPoolFunded         = "reward_pool_funded"
+	EventTypeRewardsAllocated         = "rewards_allocated"
+	EventTypeRewardDelegated          = "reward_delegated"
+	EventTypeAutoCompound             = "auto_compound"
+	EventTypeSetWithdrawAddress       = "set_withdraw_address"
+	EventTypeRewardsExpiring          = "rewards_expiring"
+	EventTypeRewardsExpired           = "rewards_expired"
+	EventTypeSetCommission            = "set_commission"
+	EventTypeDelegatorRewardsShared   = "delegator_rewards_shared"
+	EventTypeDelegatorRewardsClaimed  = "delegator_rewards_claimed"
+	EventTypeDelegatorRewardsCredited = "delegator_rewards_credited"
+	EventTypeRewardsClawedBack        = "rewards_clawed_back"
+	EventTypeClawbackDebtRepaid       = "clawback_debt_repaid"
+	EventTypeVestedRewardsReleased    = "vested_rewards_released"
+
+	AttributeKeyAddress            = "address"
+	AttributeKeyAmount             = "amount"
+	AttributeKeyEpoch              = "epoch"
+	AttributeKeyTotalServiceScore  = "total_service_score"
+	AttributeKeyTotalStaked        = "total_staked"
+	AttributeKeyServiceScoreWeight = "service_score_weight"
+	AttributeKeyStakingWeight      = "staking_weight"
+	AttributeKeyRewardPerEpoch     = "reward_per_epoch"
+	AttributeKeyEpochIdentifier    = "epoch_identifier"
+	AttributeKeyRewardSource       = "reward_source"
+	AttributeKeyAvailable          = "available"
+	AttributeKeyParticipants       = "participants"
+	AttributeKeyRemainder          = "remainder"
+	AttributeKeyLocked             = "locked"
+	AttributeKeyUnlocked           = "unlocked"
+	AttributeKeyValidator          = "validator"
+	AttributeKeyEnabled            = "enabled"
+	AttributeKeyDelegated          = "delegated"
+	AttributeKeyWithdrawAddress    = "withdraw_address"
+	AttributeKeyExpiryEpoch        = "expiry_epoch"
+	AttributeKeyDestination        = "destination"
+	AttributeKeyProvider           = "provider"
+	AttributeKeyDelegator          = "delegator"
+	AttributeKeyCommissionRate     = "commission_rate"
+	AttributeKeyCommission         = "commission"
+	AttributeKeyShared             = "shared"
+	AttributeKeyFraction           = "fraction"
+	AttributeKeyReason             = "reason"
+	AttributeKeyDebt               = "debt"
+)
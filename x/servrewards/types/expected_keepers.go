@@ -2,24 +2,47 @@ package types
 
 import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
 )
 
 // BankKeeper defines the expected bank keeper
 type BankKeeper interface {
 	MintCoins(ctx sdk.Context, moduleName string, amt sdk.Coins) error
 	SendCoinsFromModuleToAccount(ctx sdk.Context, senderModule string, recipientAddr sdk.AccAddress, amt sdk.Coins) error
+	SendCoinsFromModuleToModule(ctx sdk.Context, senderModule, recipientModule string, amt sdk.Coins) error
+	GetBalance(ctx sdk.Context, addr sdk.AccAddress, denom string) sdk.Coin
+	GetSupply(ctx sdk.Context, denom string) sdk.Coin
+	BlockedAddr(addr sdk.AccAddress) bool
+}
+
+// DistrKeeper defines the expected distribution keeper
+type DistrKeeper interface {
+	GetFeePoolCommunityCoins(ctx sdk.Context) sdk.DecCoins
+	DistributeFromFeePool(ctx sdk.Context, amount sdk.Coins, receiveAddr sdk.AccAddress) error
+	FundCommunityPool(ctx sdk.Context, amount sdk.Coins, sender sdk.AccAddress) error
 }
 
 // StakingKeeper defines the expected staking keeper
 type StakingKeeper interface {
 	GetDelegatorStake(ctx sdk.Context, delegator sdk.AccAddress) sdk.Int
 	GetTotalBondedTokens(ctx sdk.Context) sdk.Int
+	BondDenom(ctx sdk.Context) string
+	GetValidator(ctx sdk.Context, addr sdk.ValAddress) (validator stakingtypes.Validator, found bool)
+	GetDelegation(ctx sdk.Context, delAddr sdk.AccAddress, valAddr sdk.ValAddress) (delegation stakingtypes.Delegation, found bool)
+	Delegate(ctx sdk.Context, delAddr sdk.AccAddress, bondAmt sdk.Int, tokenSrc stakingtypes.BondStatus, validator stakingtypes.Validator, subtractAccount bool) (newShares sdk.Dec, err error)
+}
+
+// SlashingKeeper defines the expected slashing keeper
+type SlashingKeeper interface {
+	IsTombstoned(ctx sdk.Context, consAddr sdk.ConsAddress) bool
+	SlashFractionDoubleSign(ctx sdk.Context) sdk.Dec
 }
 
 // ProofOfServiceKeeper defines the expected proof of service keeper
 type ProofOfServiceKeeper interface {
 	GetServiceScore(ctx sdk.Context, addr string) sdk.Int
 	GetTotalServiceScore(ctx sdk.Context) sdk.Int
+	IterateServiceScores(ctx sdk.Context, cb func(provider string, score sdk.Int) (stop bool))
 }
 
 // ServRewardsHooks event hooks for servrewards module
@@ -2,6 +2,8 @@ package types
 
 import (
 	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
 )
 
 // DefaultGenesis returns the default genesis state for the servrewards module.
@@ -9,7 +11,19 @@ func DefaultGenesis() *GenesisState {
 	return &GenesisState{
 		RewardMetrics:      DefaultRewardMetrics(),
 		RewardParams:       DefaultRewardParams(),
+		RewardPool:         DefaultRewardPool(),
 		AccumulatedRewards: []AccumulatedRewards{},
+		EpochRewardRecords:  []EpochRewardRecord{},
+		AddressEpochRewards: []AddressEpochReward{},
+		EmissionState:       DefaultEmissionState(),
+		VestingRewards:      []VestingRewards{},
+		AutoCompound:        []AutoCompoundSetting{},
+		WithdrawAddresses:   []RewardWithdrawAddress{},
+		ProviderCommissions:     []ProviderCommission{},
+		DelegatorRewardsPools:   []DelegatorRewardsPool{},
+		DelegatorStartingRatios: []DelegatorStartingRatio{},
+		ClawbackRecords:         []ClawbackRecord{},
+		ClawbackDebts:           []ClawbackDebt{},
 	}
 }
 
@@ -17,38 +31,221 @@ func DefaultGenesis() *GenesisState {
 type GenesisState struct {
 	RewardMetrics      RewardMetrics       `json:"reward_metrics"`
 	RewardParams       RewardParams        `json:"reward_params"`
+	RewardPool         RewardPool          `json:"reward_pool"`
 	AccumulatedRewards []AccumulatedRewards `json:"accumulated_rewards"`
+	EpochRewardRecords  []EpochRewardRecord  `json:"epoch_reward_records"`
+	AddressEpochRewards []AddressEpochReward `json:"address_epoch_rewards"`
+	EmissionState       EmissionState        `json:"emission_state"`
+	VestingRewards      []VestingRewards     `json:"vesting_rewards"`
+	AutoCompound        []AutoCompoundSetting `json:"auto_compound"`
+	WithdrawAddresses   []RewardWithdrawAddress `json:"withdraw_addresses"`
+	ProviderCommissions     []ProviderCommission     `json:"provider_commissions"`
+	DelegatorRewardsPools   []DelegatorRewardsPool   `json:"delegator_rewards_pools"`
+	DelegatorStartingRatios []DelegatorStartingRatio `json:"delegator_starting_ratios"`
+	ClawbackRecords         []ClawbackRecord         `json:"clawback_records"`
+	ClawbackDebts           []ClawbackDebt           `json:"clawback_debts"`
 }
 
 // Validate performs basic genesis state validation.
 func (gs GenesisState) Validate() error {
 	// Validate reward parameters
-	if gs.RewardParams.ServiceScoreWeight.IsNegative() {
-		return fmt.Errorf("service score weight cannot be negative: %s", gs.RewardParams.ServiceScoreWeight)
+	if err := gs.RewardParams.Validate(); err != nil {
+		return err
+	}
+	
+	// Validate reward pool
+	if err := gs.RewardPool.Available.Validate(); err != nil {
+		return fmt.Errorf("invalid available reward pool balance: %w", err)
 	}
 	
-	if gs.RewardParams.StakingWeight.IsNegative() {
-		return fmt.Errorf("staking weight cannot be negative: %s", gs.RewardParams.StakingWeight)
+	if err := gs.RewardPool.Outstanding.Validate(); err != nil {
+		return fmt.Errorf("invalid outstanding reward pool balance: %w", err)
 	}
 	
-	if gs.RewardParams.RewardPerEpoch.IsNegative() {
-		return fmt.Errorf("reward per epoch cannot be negative: %s", gs.RewardParams.RewardPerEpoch)
+	if err := gs.RewardPool.Vesting.Validate(); err != nil {
+		return fmt.Errorf("invalid vesting reward pool balance: %w", err)
 	}
 	
-	if gs.RewardParams.EpochDuration == 0 {
-		return fmt.Errorf("epoch duration must be positive")
+	if err := gs.RewardPool.Remainder.Validate(); err != nil {
+		return fmt.Errorf("invalid reward pool remainder: %w", err)
 	}
 	
-	// Ensure weights sum to 1
-	sumWeights := gs.RewardParams.ServiceScoreWeight.Add(gs.RewardParams.StakingWeight)
-	if !sumWeights.Equal(OneDec()) {
-		return fmt.Errorf("service score weight and staking weight must sum to 1, got: %s", sumWeights)
+	if !gs.RewardPool.Remainder.IsAllLTE(gs.RewardPool.Available) {
+		return fmt.Errorf("reward pool remainder %s exceeds available balance %s", gs.RewardPool.Remainder, gs.RewardPool.Available)
+	}
+	
+	// Validate emission state
+	if err := gs.EmissionState.TotalEmitted.Validate(); err != nil {
+		return fmt.Errorf("invalid total emitted: %w", err)
 	}
 	
 	// Validate accumulated rewards
 	for _, reward := range gs.AccumulatedRewards {
-		if reward.Rewards.IsNegative() {
-			return fmt.Errorf("accumulated rewards cannot be negative: %s", reward.Rewards)
+		if err := reward.Rewards.Validate(); err != nil {
+			return fmt.Errorf("invalid accumulated rewards for %s: %w", reward.Address, err)
+		}
+	}
+	
+	// Validate vesting rewards
+	vestingAddrs := make(map[string]bool)
+	escrowed := sdk.NewCoins()
+	for _, vesting := range gs.VestingRewards {
+		if _, exists := vestingAddrs[vesting.Address]; exists {
+			return fmt.Errorf("duplicate vesting rewards for %s", vesting.Address)
+		}
+		vestingAddrs[vesting.Address] = true
+		
+		if err := vesting.Validate(); err != nil {
+			return fmt.Errorf("invalid vesting rewards for %s: %w", vesting.Address, err)
+		}
+		for _, entry := range vesting.Entries {
+			escrowed = escrowed.Add(entry.Amount...)
+		}
+		escrowed = escrowed.Sub(vesting.Released...)
+	}
+	
+	// The vesting balance of the pool is what the module escrows for vesting
+	// claims that have not been released yet
+	if !escrowed.IsEqual(gs.RewardPool.Vesting) {
+		return fmt.Errorf("vesting reward pool balance %s does not match unreleased vesting rewards %s", gs.RewardPool.Vesting, escrowed)
+	}
+	
+	// Validate auto-compound settings
+	compounders := make(map[string]bool)
+	for _, setting := range gs.AutoCompound {
+		if _, exists := compounders[setting.Address]; exists {
+			return fmt.Errorf("duplicate auto-compound setting for %s", setting.Address)
+		}
+		compounders[setting.Address] = true
+		
+		if _, err := sdk.ValAddressFromBech32(setting.Validator); err != nil {
+			return fmt.Errorf("invalid auto-compound validator for %s: %w", setting.Address, err)
+		}
+	}
+	
+	// Validate withdraw addresses
+	withdrawers := make(map[string]bool)
+	for _, withdraw := range gs.WithdrawAddresses {
+		if _, exists := withdrawers[withdraw.Address]; exists {
+			return fmt.Errorf("duplicate withdraw address for %s", withdraw.Address)
+		}
+		withdrawers[withdraw.Address] = true
+		
+		if _, err := sdk.AccAddressFromBech32(withdraw.Address); err != nil {
+			return fmt.Errorf("invalid address %s: %w", withdraw.Address, err)
+		}
+		if _, err := sdk.AccAddressFromBech32(withdraw.WithdrawAddress); err != nil {
+			return fmt.Errorf("invalid withdraw address for %s: %w", withdraw.Address, err)
+		}
+	}
+	
+	// Validate provider commissions
+	commissions := make(map[string]bool)
+	for _, commission := range gs.ProviderCommissions {
+		if _, exists := commissions[commission.Provider]; exists {
+			return fmt.Errorf("duplicate commission for %s", commission.Provider)
+		}
+		commissions[commission.Provider] = true
+		
+		if err := commission.Validate(); err != nil {
+			return fmt.Errorf("invalid commission for %s: %w", commission.Provider, err)
+		}
+	}
+	
+	// Validate delegator rewards
+	delegatorPools := make(map[string]bool)
+	for _, pool := range gs.DelegatorRewardsPools {
+		if _, exists := delegatorPools[pool.Provider]; exists {
+			return fmt.Errorf("duplicate delegator rewards pool for %s", pool.Provider)
+		}
+		delegatorPools[pool.Provider] = true
+		
+		if err := pool.Ratio.Validate(); err != nil {
+			return fmt.Errorf("invalid delegator rewards ratio for %s: %w", pool.Provider, err)
+		}
+		if err := pool.Outstanding.Validate(); err != nil {
+			return fmt.Errorf("invalid outstanding delegator rewards for %s: %w", pool.Provider, err)
+		}
+	}
+	
+	// Rewards owed to addresses and delegators are paid out of the outstanding
+	// balance of the pool, which counts exactly those rewards
+	owed := sdk.NewCoins()
+	for _, reward := range gs.AccumulatedRewards {
+		owed = owed.Add(reward.Rewards...)
+	}
+	for _, pool := range gs.DelegatorRewardsPools {
+		owed = owed.Add(pool.Outstanding...)
+	}
+	if !owed.IsEqual(gs.RewardPool.Outstanding) {
+		return fmt.Errorf("outstanding reward pool balance %s does not match owed rewards %s", gs.RewardPool.Outstanding, owed)
+	}
+	
+	startingRatios := make(map[string]bool)
+	for _, ratio := range gs.DelegatorStartingRatios {
+		key := fmt.Sprintf("%s/%s", ratio.Provider, ratio.Delegator)
+		if _, exists := startingRatios[key]; exists {
+			return fmt.Errorf("duplicate starting ratio of %s for %s", ratio.Delegator, ratio.Provider)
+		}
+		startingRatios[key] = true
+		
+		if _, exists := delegatorPools[ratio.Provider]; !exists {
+			return fmt.Errorf("starting ratio of %s for %s without delegator rewards pool", ratio.Delegator, ratio.Provider)
+		}
+		if err := ratio.Ratio.Validate(); err != nil {
+			return fmt.Errorf("invalid starting ratio of %s for %s: %w", ratio.Delegator, ratio.Provider, err)
+		}
+	}
+	
+	// Validate clawbacks
+	clawbacks := make(map[string]bool)
+	for _, record := range gs.ClawbackRecords {
+		key := fmt.Sprintf("%s/%d", record.Address, record.EpochNumber)
+		if _, exists := clawbacks[key]; exists {
+			return fmt.Errorf("duplicate clawback of %s in epoch %d", record.Address, record.EpochNumber)
+		}
+		clawbacks[key] = true
+		
+		if err := record.Amount.Validate(); err != nil {
+			return fmt.Errorf("invalid clawback of %s in epoch %d: %w", record.Address, record.EpochNumber, err)
+		}
+	}
+	
+	debtors := make(map[string]bool)
+	for _, debt := range gs.ClawbackDebts {
+		if _, exists := debtors[debt.Address]; exists {
+			return fmt.Errorf("duplicate clawback debt for %s", debt.Address)
+		}
+		debtors[debt.Address] = true
+		
+		if err := debt.Amount.Validate(); err != nil {
+			return fmt.Errorf("invalid clawback debt for %s: %w", debt.Address, err)
+		}
+	}
+	
+	// Validate reward history
+	epochs := make(map[uint64]bool)
+	for _, record := range gs.EpochRewardRecords {
+		if _, exists := epochs[record.EpochNumber]; exists {
+			return fmt.Errorf("duplicate epoch reward record: %d", record.EpochNumber)
+		}
+		epochs[record.EpochNumber] = true
+		
+		if err := record.TotalDistributed.Validate(); err != nil {
+			return fmt.Errorf("invalid total distributed in epoch %d: %w", record.EpochNumber, err)
+		}
+	}
+	
+	earnings := make(map[string]bool)
+	for _, earning := range gs.AddressEpochRewards {
+		key := fmt.Sprintf("%s/%d", earning.Address, earning.EpochNumber)
+		if _, exists := earnings[key]; exists {
+			return fmt.Errorf("duplicate earnings of %s in epoch %d", earning.Address, earning.EpochNumber)
+		}
+		earnings[key] = true
+		
+		if err := earning.Amount.Validate(); err != nil {
+			return fmt.Errorf("invalid earnings of %s in epoch %d: %w", earning.Address, earning.EpochNumber, err)
 		}
 	}
 	
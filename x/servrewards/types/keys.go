@@ -1,5 +1,12 @@
 package types
 
+import (
+	"encoding/binary"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/address"
+)
+
 const (
 	// ModuleName defines the module name
 	ModuleName = "servrewards"
@@ -15,6 +22,9 @@ const (
 
 	// MemStoreKey defines the in-memory store key
 	MemStoreKey = "mem_servrewards"
+
+	// DefaultRewardDenom is the native denomination rewards are paid out in by default
+	DefaultRewardDenom = "serv"
 )
 
 var (
@@ -26,9 +36,147 @@ var (
 
 	// RewardParamsKey is the key to store reward parameters
 	RewardParamsKey = []byte{0x03}
+
+	// RewardPoolKey is the key to store the reward pool
+	RewardPoolKey = []byte{0x04}
+
+	// EpochRewardRecordPrefix is the prefix for storing per-epoch reward records
+	EpochRewardRecordPrefix = []byte{0x05}
+
+	// AddressEpochRewardPrefix is the prefix for storing per-address, per-epoch earnings
+	AddressEpochRewardPrefix = []byte{0x06}
+
+	// EpochAddressRewardIndexPrefix is the prefix for indexing per-address earnings by epoch, used for pruning
+	EpochAddressRewardIndexPrefix = []byte{0x07}
+
+	// EmissionStateKey is the key to store the lifetime emission state
+	EmissionStateKey = []byte{0x08}
+
+	// VestingRewardsPrefix is the prefix for storing vesting claims
+	VestingRewardsPrefix = []byte{0x09}
+
+	// AutoCompoundPrefix is the prefix for storing auto-compound settings
+	AutoCompoundPrefix = []byte{0x0A}
+
+	// AutoCompoundCursorKey is the key to store the next address of a pending auto-compound pass
+	AutoCompoundCursorKey = []byte{0x0B}
+
+	// WithdrawAddressPrefix is the prefix for storing reward withdraw addresses
+	WithdrawAddressPrefix = []byte{0x0C}
+
+	// UnclaimedRewardsQueuePrefix is the prefix for indexing unclaimed rewards by the epoch they were first credited in
+	UnclaimedRewardsQueuePrefix = []byte{0x0D}
+
+	// ProviderCommissionPrefix is the prefix for storing provider commissions
+	ProviderCommissionPrefix = []byte{0x0E}
+
+	// DelegatorRewardsPoolPrefix is the prefix for storing the rewards providers share with their delegators
+	DelegatorRewardsPoolPrefix = []byte{0x0F}
+
+	// DelegatorStartingRatioPrefix is the prefix for storing the ratio at which delegators last withdrew
+	DelegatorStartingRatioPrefix = []byte{0x10}
+
+	// ClawbackRecordPrefix is the prefix for storing the earnings clawed back per epoch and address
+	ClawbackRecordPrefix = []byte{0x11}
+
+	// ClawbackDebtPrefix is the prefix for storing clawed back rewards still owed by addresses
+	ClawbackDebtPrefix = []byte{0x12}
+
+	// SlashedValidatorPrefix is the prefix for marking validators slashed in the current block
+	SlashedValidatorPrefix = []byte{0x13}
 )
 
 // GetAccumulatedRewardsKey returns the key for storing accumulated rewards for an address
 func GetAccumulatedRewardsKey(addr string) []byte {
 	return append(AccumulatedRewardsPrefix, []byte(addr)...)
 }
+
+// GetVestingRewardsKey returns the key for storing the vesting claims of an address
+func GetVestingRewardsKey(addr string) []byte {
+	return append(VestingRewardsPrefix, []byte(addr)...)
+}
+
+// GetAutoCompoundKey returns the key for storing the auto-compound setting of an address
+func GetAutoCompoundKey(addr string) []byte {
+	return append(AutoCompoundPrefix, []byte(addr)...)
+}
+
+// GetWithdrawAddressKey returns the key for storing the reward withdraw address of an address
+func GetWithdrawAddressKey(addr string) []byte {
+	return append(WithdrawAddressPrefix, []byte(addr)...)
+}
+
+// GetProviderCommissionKey returns the key for storing the commission of a provider
+func GetProviderCommissionKey(provider string) []byte {
+	return append(ProviderCommissionPrefix, []byte(provider)...)
+}
+
+// GetDelegatorRewardsPoolKey returns the key for storing the rewards a provider shares with its delegators
+func GetDelegatorRewardsPoolKey(provider string) []byte {
+	return append(DelegatorRewardsPoolPrefix, []byte(provider)...)
+}
+
+// GetDelegatorStartingRatiosPrefix returns the prefix of the starting ratios of all delegators of a provider
+func GetDelegatorStartingRatiosPrefix(provider string) []byte {
+	return append(DelegatorStartingRatioPrefix, address.MustLengthPrefix([]byte(provider))...)
+}
+
+// GetDelegatorStartingRatioKey returns the key for storing the starting ratio of a delegator of a provider
+func GetDelegatorStartingRatioKey(provider, delegator string) []byte {
+	return append(GetDelegatorStartingRatiosPrefix(provider), []byte(delegator)...)
+}
+
+// GetUnclaimedRewardsQueueKey returns the index key of the unclaimed rewards of an address credited since an epoch
+func GetUnclaimedRewardsQueueKey(since uint64, addr string) []byte {
+	key := append(UnclaimedRewardsQueuePrefix, sdk.Uint64ToBigEndian(since)...)
+	return append(key, []byte(addr)...)
+}
+
+// ParseUnclaimedRewardsQueueKey returns the epoch and address of an unclaimed rewards index key
+func ParseUnclaimedRewardsQueueKey(key []byte) (uint64, string) {
+	key = key[len(UnclaimedRewardsQueuePrefix):]
+	return binary.BigEndian.Uint64(key[:8]), string(key[8:])
+}
+
+// GetEpochRewardRecordKey returns the key for storing the reward record of an epoch
+func GetEpochRewardRecordKey(epoch uint64) []byte {
+	return append(EpochRewardRecordPrefix, sdk.Uint64ToBigEndian(epoch)...)
+}
+
+// GetAddressEpochRewardsPrefix returns the prefix of all earnings of an address, ordered by epoch
+func GetAddressEpochRewardsPrefix(addr string) []byte {
+	return append(AddressEpochRewardPrefix, address.MustLengthPrefix([]byte(addr))...)
+}
+
+// GetAddressEpochRewardKey returns the key for storing the earnings of an address in an epoch
+func GetAddressEpochRewardKey(addr string, epoch uint64) []byte {
+	return append(GetAddressEpochRewardsPrefix(addr), sdk.Uint64ToBigEndian(epoch)...)
+}
+
+// GetEpochAddressRewardIndexKey returns the index key of the earnings of an address in an epoch
+func GetEpochAddressRewardIndexKey(epoch uint64, addr string) []byte {
+	key := append(EpochAddressRewardIndexPrefix, sdk.Uint64ToBigEndian(epoch)...)
+	return append(key, []byte(addr)...)
+}
+
+// ParseEpochAddressRewardIndexKey returns the epoch and address of an index key
+func ParseEpochAddressRewardIndexKey(key []byte) (uint64, string) {
+	key = key[len(EpochAddressRewardIndexPrefix):]
+	return binary.BigEndian.Uint64(key[:8]), string(key[8:])
+}
+
+// GetClawbackRecordKey returns the key for storing the earnings of an address clawed back in an epoch
+func GetClawbackRecordKey(epoch uint64, addr string) []byte {
+	key := append(ClawbackRecordPrefix, sdk.Uint64ToBigEndian(epoch)...)
+	return append(key, []byte(addr)...)
+}
+
+// GetClawbackDebtKey returns the key for storing the clawback debt of an address
+func GetClawbackDebtKey(addr string) []byte {
+	return append(ClawbackDebtPrefix, []byte(addr)...)
+}
+
+// GetSlashedValidatorKey returns the key for marking a validator slashed in the current block
+func GetSlashedValidatorKey(valAddr sdk.ValAddress) []byte {
+	return append(SlashedValidatorPrefix, valAddr.Bytes()...)
+}
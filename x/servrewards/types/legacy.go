@@ -0,0 +1,52 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// LegacyRewardParams is the encoding of RewardParams in consensus version 1,
+// when every reward was paid in DefaultRewardDenom
+type LegacyRewardParams struct {
+	ServiceScoreWeight sdk.Dec `json:"service_score_weight"`
+	StakingWeight      sdk.Dec `json:"staking_weight"`
+	RewardPerEpoch     sdk.Int `json:"reward_per_epoch"`
+	EpochDuration      uint64  `json:"epoch_duration"` // In blocks
+}
+
+// LegacyAccumulatedRewards is the encoding of AccumulatedRewards in consensus
+// version 1, when every reward was paid in DefaultRewardDenom
+type LegacyAccumulatedRewards struct {
+	Address   string  `json:"address"`
+	Rewards   sdk.Int `json:"rewards"`
+	LastClaim uint64  `json:"last_claim"` // Last epoch when rewards were claimed
+}
+
+// Migrate returns the reward parameters of consensus version 2 that keep the
+// weights and reward per epoch of the legacy ones, with defaults for every
+// parameter added since
+func (p LegacyRewardParams) Migrate() RewardParams {
+	params := DefaultRewardParams()
+	params.ServiceScoreWeight = p.ServiceScoreWeight
+	params.StakingWeight = p.StakingWeight
+	params.RewardPerEpoch = legacyCoins(p.RewardPerEpoch)
+	return params
+}
+
+// Migrate returns the accumulated rewards of consensus version 2, credited
+// since the epoch unclaimedSince
+func (r LegacyAccumulatedRewards) Migrate(unclaimedSince uint64) AccumulatedRewards {
+	return AccumulatedRewards{
+		Address:        r.Address,
+		Rewards:        legacyCoins(r.Rewards),
+		LastClaim:      r.LastClaim,
+		UnclaimedSince: unclaimedSince,
+	}
+}
+
+// legacyCoins returns a legacy amount as coins of DefaultRewardDenom
+func legacyCoins(amount sdk.Int) sdk.Coins {
+	if amount.IsNil() || !amount.IsPositive() {
+		return sdk.NewCoins()
+	}
+	return sdk.NewCoins(sdk.NewCoin(DefaultRewardDenom, amount))
+}
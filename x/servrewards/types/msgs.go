@@ -8,10 +8,22 @@ import (
 const (
 	TypeMsgClaimReward       = "claim_reward"
 	TypeMsgUpdateRewardParams = "update_reward_params"
+	TypeMsgClaimAndDelegate   = "claim_and_delegate"
+	TypeMsgSetAutoCompound    = "set_auto_compound"
+	TypeMsgSetRewardWithdrawAddress = "set_reward_withdraw_address"
+	TypeMsgSetProviderCommission    = "set_provider_commission"
+	TypeMsgClaimDelegatorRewards    = "claim_delegator_rewards"
+	TypeMsgReleaseVestedRewards     = "release_vested_rewards"
 )
 
 var _ sdk.Msg = &MsgClaimReward{}
 var _ sdk.Msg = &MsgUpdateRewardParams{}
+var _ sdk.Msg = &MsgClaimAndDelegate{}
+var _ sdk.Msg = &MsgSetAutoCompound{}
+var _ sdk.Msg = &MsgSetRewardWithdrawAddress{}
+var _ sdk.Msg = &MsgSetProviderCommission{}
+var _ sdk.Msg = &MsgClaimDelegatorRewards{}
+var _ sdk.Msg = &MsgReleaseVestedRewards{}
 
 // MsgClaimReward defines a message for claiming accumulated rewards
 type MsgClaimReward struct {
@@ -57,27 +69,15 @@ func (msg MsgClaimReward) GetSigners() []sdk.AccAddress {
 
 // MsgUpdateRewardParams defines a message for updating reward parameters (governance)
 type MsgUpdateRewardParams struct {
-	Authority          string  `json:"authority"`
-	ServiceScoreWeight sdk.Dec `json:"service_score_weight"`
-	StakingWeight      sdk.Dec `json:"staking_weight"`
-	RewardPerEpoch     sdk.Int `json:"reward_per_epoch"`
-	EpochDuration      uint64  `json:"epoch_duration"`
+	Authority string       `json:"authority"`
+	Params    RewardParams `json:"params"`
 }
 
 // NewMsgUpdateRewardParams creates a new MsgUpdateRewardParams instance
-func NewMsgUpdateRewardParams(
-	authority string,
-	serviceScoreWeight sdk.Dec,
-	stakingWeight sdk.Dec,
-	rewardPerEpoch sdk.Int,
-	epochDuration uint64,
-) *MsgUpdateRewardParams {
+func NewMsgUpdateRewardParams(authority string, params RewardParams) *MsgUpdateRewardParams {
 	return &MsgUpdateRewardParams{
-		Authority:          authority,
-		ServiceScoreWeight: serviceScoreWeight,
-		StakingWeight:      stakingWeight,
-		RewardPerEpoch:     rewardPerEpoch,
-		EpochDuration:      epochDuration,
+		Authority: authority,
+		Params:    params,
 	}
 }
 
@@ -97,39 +97,323 @@ func (msg MsgUpdateRewardParams) ValidateBasic() error {
 		return sdkerrors.Wrapf(sdkerrors.ErrInvalidAddress, "invalid authority address: %s", err)
 	}
 
-	if msg.ServiceScoreWeight.IsNegative() {
-		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "service score weight cannot be negative")
+	if err := msg.Params.Validate(); err != nil {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, err.Error())
 	}
 
-	if msg.StakingWeight.IsNegative() {
-		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "staking weight cannot be negative")
+	return nil
+}
+
+// GetSignBytes implements sdk.Msg
+func (msg MsgUpdateRewardParams) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(&msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// GetSigners implements sdk.Msg
+func (msg MsgUpdateRewardParams) GetSigners() []sdk.AccAddress {
+	addr, _ := sdk.AccAddressFromBech32(msg.Authority)
+	return []sdk.AccAddress{addr}
+}
+
+// MsgClaimAndDelegate defines a message for claiming accumulated rewards and delegating them to a validator
+type MsgClaimAndDelegate struct {
+	Delegator        string `json:"delegator"`
+	ValidatorAddress string `json:"validator_address"`
+}
+
+// NewMsgClaimAndDelegate creates a new MsgClaimAndDelegate instance
+func NewMsgClaimAndDelegate(delegator, validator string) *MsgClaimAndDelegate {
+	return &MsgClaimAndDelegate{
+		Delegator:        delegator,
+		ValidatorAddress: validator,
+	}
+}
+
+// Route implements sdk.Msg
+func (msg MsgClaimAndDelegate) Route() string {
+	return RouterKey
+}
+
+// Type implements sdk.Msg
+func (msg MsgClaimAndDelegate) Type() string {
+	return TypeMsgClaimAndDelegate
+}
+
+// ValidateBasic implements sdk.Msg
+func (msg MsgClaimAndDelegate) ValidateBasic() error {
+	if _, err := sdk.AccAddressFromBech32(msg.Delegator); err != nil {
+		return sdkerrors.Wrapf(sdkerrors.ErrInvalidAddress, "invalid delegator address: %s", err)
+	}
+
+	if _, err := sdk.ValAddressFromBech32(msg.ValidatorAddress); err != nil {
+		return sdkerrors.Wrapf(sdkerrors.ErrInvalidAddress, "invalid validator address: %s", err)
+	}
+
+	return nil
+}
+
+// GetSignBytes implements sdk.Msg
+func (msg MsgClaimAndDelegate) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(&msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// GetSigners implements sdk.Msg
+func (msg MsgClaimAndDelegate) GetSigners() []sdk.AccAddress {
+	addr, _ := sdk.AccAddressFromBech32(msg.Delegator)
+	return []sdk.AccAddress{addr}
+}
+
+// MsgSetAutoCompound defines a message for opting in or out of auto-compounding rewards
+type MsgSetAutoCompound struct {
+	Delegator        string `json:"delegator"`
+	ValidatorAddress string `json:"validator_address"` // Ignored when disabling
+	Enabled          bool   `json:"enabled"`
+}
+
+// NewMsgSetAutoCompound creates a new MsgSetAutoCompound instance
+func NewMsgSetAutoCompound(delegator, validator string, enabled bool) *MsgSetAutoCompound {
+	return &MsgSetAutoCompound{
+		Delegator:        delegator,
+		ValidatorAddress: validator,
+		Enabled:          enabled,
 	}
+}
+
+// Route implements sdk.Msg
+func (msg MsgSetAutoCompound) Route() string {
+	return RouterKey
+}
+
+// Type implements sdk.Msg
+func (msg MsgSetAutoCompound) Type() string {
+	return TypeMsgSetAutoCompound
+}
 
-	if msg.RewardPerEpoch.IsNegative() {
-		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "reward per epoch cannot be negative")
+// ValidateBasic implements sdk.Msg
+func (msg MsgSetAutoCompound) ValidateBasic() error {
+	if _, err := sdk.AccAddressFromBech32(msg.Delegator); err != nil {
+		return sdkerrors.Wrapf(sdkerrors.ErrInvalidAddress, "invalid delegator address: %s", err)
 	}
 
-	if msg.EpochDuration == 0 {
-		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "epoch duration must be positive")
+	if msg.Enabled {
+		if _, err := sdk.ValAddressFromBech32(msg.ValidatorAddress); err != nil {
+			return sdkerrors.Wrapf(sdkerrors.ErrInvalidAddress, "invalid validator address: %s", err)
+		}
 	}
 
-	// Ensure weights sum to 1
-	sumWeights := msg.ServiceScoreWeight.Add(msg.StakingWeight)
-	if !sumWeights.Equal(sdk.NewDec(1)) {
-		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "service score weight and staking weight must sum to 1")
+	return nil
+}
+
+// GetSignBytes implements sdk.Msg
+func (msg MsgSetAutoCompound) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(&msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// GetSigners implements sdk.Msg
+func (msg MsgSetAutoCompound) GetSigners() []sdk.AccAddress {
+	addr, _ := sdk.AccAddressFromBech32(msg.Delegator)
+	return []sdk.AccAddress{addr}
+}
+
+// MsgSetRewardWithdrawAddress defines a message for setting the address claimed rewards are sent to
+type MsgSetRewardWithdrawAddress struct {
+	Address         string `json:"address"`
+	WithdrawAddress string `json:"withdraw_address"`
+}
+
+// NewMsgSetRewardWithdrawAddress creates a new MsgSetRewardWithdrawAddress instance
+func NewMsgSetRewardWithdrawAddress(addr, withdrawAddr string) *MsgSetRewardWithdrawAddress {
+	return &MsgSetRewardWithdrawAddress{
+		Address:         addr,
+		WithdrawAddress: withdrawAddr,
+	}
+}
+
+// Route implements sdk.Msg
+func (msg MsgSetRewardWithdrawAddress) Route() string {
+	return RouterKey
+}
+
+// Type implements sdk.Msg
+func (msg MsgSetRewardWithdrawAddress) Type() string {
+	return TypeMsgSetRewardWithdrawAddress
+}
+
+// ValidateBasic implements sdk.Msg
+func (msg MsgSetRewardWithdrawAddress) ValidateBasic() error {
+	if _, err := sdk.AccAddressFromBech32(msg.Address); err != nil {
+		return sdkerrors.Wrapf(sdkerrors.ErrInvalidAddress, "invalid address: %s", err)
+	}
+
+	if _, err := sdk.AccAddressFromBech32(msg.WithdrawAddress); err != nil {
+		return sdkerrors.Wrapf(sdkerrors.ErrInvalidAddress, "invalid withdraw address: %s", err)
 	}
 
 	return nil
 }
 
 // GetSignBytes implements sdk.Msg
-func (msg MsgUpdateRewardParams) GetSignBytes() []byte {
+func (msg MsgSetRewardWithdrawAddress) GetSignBytes() []byte {
 	bz := ModuleCdc.MustMarshalJSON(&msg)
 	return sdk.MustSortJSON(bz)
 }
 
 // GetSigners implements sdk.Msg
-func (msg MsgUpdateRewardParams) GetSigners() []sdk.AccAddress {
-	addr, _ := sdk.AccAddressFromBech32(msg.Authority)
+func (msg MsgSetRewardWithdrawAddress) GetSigners() []sdk.AccAddress {
+	addr, _ := sdk.AccAddressFromBech32(msg.Address)
+	return []sdk.AccAddress{addr}
+}
+
+// MsgSetProviderCommission defines a message for setting up or changing the commission of a provider
+type MsgSetProviderCommission struct {
+	Provider      string   `json:"provider"`
+	Rate          sdk.Dec  `json:"rate"`
+	MaxRate       *sdk.Dec `json:"max_rate"`        // Required to set up a commission, fixed afterwards
+	MaxChangeRate *sdk.Dec `json:"max_change_rate"` // Required to set up a commission, fixed afterwards
+}
+
+// NewMsgSetProviderCommission creates a new MsgSetProviderCommission instance
+func NewMsgSetProviderCommission(provider string, rate sdk.Dec, maxRate, maxChangeRate *sdk.Dec) *MsgSetProviderCommission {
+	return &MsgSetProviderCommission{
+		Provider:      provider,
+		Rate:          rate,
+		MaxRate:       maxRate,
+		MaxChangeRate: maxChangeRate,
+	}
+}
+
+// Route implements sdk.Msg
+func (msg MsgSetProviderCommission) Route() string {
+	return RouterKey
+}
+
+// Type implements sdk.Msg
+func (msg MsgSetProviderCommission) Type() string {
+	return TypeMsgSetProviderCommission
+}
+
+// ValidateBasic implements sdk.Msg
+func (msg MsgSetProviderCommission) ValidateBasic() error {
+	if _, err := sdk.AccAddressFromBech32(msg.Provider); err != nil {
+		return sdkerrors.Wrapf(sdkerrors.ErrInvalidAddress, "invalid provider address: %s", err)
+	}
+
+	if msg.Rate.IsNil() || msg.Rate.IsNegative() || msg.Rate.GT(sdk.OneDec()) {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "commission rate must be between 0 and 1")
+	}
+
+	if msg.MaxRate != nil && msg.MaxChangeRate != nil {
+		commission := NewProviderCommission(msg.Provider, msg.Rate, *msg.MaxRate, *msg.MaxChangeRate, 0)
+		if err := commission.Validate(); err != nil {
+			return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, err.Error())
+		}
+	}
+
+	return nil
+}
+
+// GetSignBytes implements sdk.Msg
+func (msg MsgSetProviderCommission) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(&msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// GetSigners implements sdk.Msg
+func (msg MsgSetProviderCommission) GetSigners() []sdk.AccAddress {
+	addr, _ := sdk.AccAddressFromBech32(msg.Provider)
+	return []sdk.AccAddress{addr}
+}
+
+// MsgClaimDelegatorRewards defines a message for claiming the rewards a provider shares with a delegator
+type MsgClaimDelegatorRewards struct {
+	Delegator string `json:"delegator"`
+	Provider  string `json:"provider"`
+}
+
+// NewMsgClaimDelegatorRewards creates a new MsgClaimDelegatorRewards instance
+func NewMsgClaimDelegatorRewards(delegator, provider string) *MsgClaimDelegatorRewards {
+	return &MsgClaimDelegatorRewards{
+		Delegator: delegator,
+		Provider:  provider,
+	}
+}
+
+// Route implements sdk.Msg
+func (msg MsgClaimDelegatorRewards) Route() string {
+	return RouterKey
+}
+
+// Type implements sdk.Msg
+func (msg MsgClaimDelegatorRewards) Type() string {
+	return TypeMsgClaimDelegatorRewards
+}
+
+// ValidateBasic implements sdk.Msg
+func (msg MsgClaimDelegatorRewards) ValidateBasic() error {
+	if _, err := sdk.AccAddressFromBech32(msg.Delegator); err != nil {
+		return sdkerrors.Wrapf(sdkerrors.ErrInvalidAddress, "invalid delegator address: %s", err)
+	}
+
+	if _, err := sdk.AccAddressFromBech32(msg.Provider); err != nil {
+		return sdkerrors.Wrapf(sdkerrors.ErrInvalidAddress, "invalid provider address: %s", err)
+	}
+
+	return nil
+}
+
+// GetSignBytes implements sdk.Msg
+func (msg MsgClaimDelegatorRewards) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(&msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// GetSigners implements sdk.Msg
+func (msg MsgClaimDelegatorRewards) GetSigners() []sdk.AccAddress {
+	addr, _ := sdk.AccAddressFromBech32(msg.Delegator)
+	return []sdk.AccAddress{addr}
+}
+
+// MsgReleaseVestedRewards defines a message for releasing the unlocked part of vesting claims
+type MsgReleaseVestedRewards struct {
+	Address string `json:"address"`
+}
+
+// NewMsgReleaseVestedRewards creates a new MsgReleaseVestedRewards instance
+func NewMsgReleaseVestedRewards(addr string) *MsgReleaseVestedRewards {
+	return &MsgReleaseVestedRewards{
+		Address: addr,
+	}
+}
+
+// Route implements sdk.Msg
+func (msg MsgReleaseVestedRewards) Route() string {
+	return RouterKey
+}
+
+// Type implements sdk.Msg
+func (msg MsgReleaseVestedRewards) Type() string {
+	return TypeMsgReleaseVestedRewards
+}
+
+// ValidateBasic implements sdk.Msg
+func (msg MsgReleaseVestedRewards) ValidateBasic() error {
+	if _, err := sdk.AccAddressFromBech32(msg.Address); err != nil {
+		return sdkerrors.Wrapf(sdkerrors.ErrInvalidAddress, "invalid address: %s", err)
+	}
+	return nil
+}
+
+// GetSignBytes implements sdk.Msg
+func (msg MsgReleaseVestedRewards) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(&msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// GetSigners implements sdk.Msg
+func (msg MsgReleaseVestedRewards) GetSigners() []sdk.AccAddress {
+	addr, _ := sdk.AccAddressFromBech32(msg.Address)
 	return []sdk.AccAddress{addr}
 }
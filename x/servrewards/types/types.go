@@ -1,10 +1,34 @@
 package types
 
 import (
+	"fmt"
+	"time"
+
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	epochstypes "github.com/serv-chain/serv/x/epochs/types"
 	"github.com/gogo/protobuf/proto"
 )
 
+// Reward sources from which the reward pool can be funded
+const (
+	// RewardSourceMint mints new reward tokens each epoch, bounded by InflationCap
+	RewardSourceMint = "mint"
+	// RewardSourceFeeShare diverts FeeShare of the fees collected every block
+	RewardSourceFeeShare = "fee_share"
+	// RewardSourceCommunityPool withdraws from the distribution community pool each epoch
+	RewardSourceCommunityPool = "community_pool"
+	// RewardSourceModuleAccount pays out of tokens sent to the module account beforehand
+	RewardSourceModuleAccount = "module_account"
+)
+
+// Destinations of expired unclaimed rewards
+const (
+	// ExpiryDestinationRewardPool makes expired rewards available for allocation again
+	ExpiryDestinationRewardPool = "reward_pool"
+	// ExpiryDestinationCommunityPool sends expired rewards to the distribution community pool
+	ExpiryDestinationCommunityPool = "community_pool"
+)
+
 // RewardMetrics represents the metrics used to calculate rewards
 type RewardMetrics struct {
 	TotalServiceScore sdk.Int `json:"total_service_score"`
@@ -16,15 +40,70 @@ type RewardMetrics struct {
 type RewardParams struct {
 	ServiceScoreWeight sdk.Dec `json:"service_score_weight"`
 	StakingWeight      sdk.Dec `json:"staking_weight"`
-	RewardPerEpoch     sdk.Int `json:"reward_per_epoch"`
-	EpochDuration      uint64  `json:"epoch_duration"` // In blocks
+	RewardPerEpoch     sdk.Coins `json:"reward_per_epoch"` // Amount distributed per epoch for each reward denom, shaped by Emission
+	EpochIdentifier    string    `json:"epoch_identifier"` // x/epochs epoch at whose end rewards are allocated
+	RewardSource       string    `json:"reward_source"`    // Where epoch rewards are funded from
+	FeeShare           sdk.Dec   `json:"fee_share"`        // Fraction of collected fees diverted to the pool (fee_share source)
+	InflationCap       sdk.Dec   `json:"inflation_cap"`    // Max fraction of the MintDenom supply minted per epoch (mint source)
+	MintDenom          string    `json:"mint_denom"`       // Only reward denom that may be minted (mint source), emitted by target inflation
+	HistoryRetentionEpochs uint64 `json:"history_retention_epochs"` // Epochs the per-address earnings ledger is kept for, 0 keeps it forever
+	Emission               EmissionSchedule `json:"emission"`           // Emission curve applied to RewardPerEpoch
+	Vesting                VestingParams    `json:"vesting"`            // Vesting applied to claimed rewards
+	AutoCompoundGasBudget  uint64           `json:"auto_compound_gas_budget"` // Gas spent on auto-compounding per block, 0 disables it
+	UnclaimedExpiryEpochs  uint64           `json:"unclaimed_expiry_epochs"`  // Epochs rewards may stay unclaimed before they expire, 0 disables expiry
+	ExpiryWarningEpochs    uint64           `json:"expiry_warning_epochs"`    // Epochs before expiry at which a warning event is emitted
+	ExpiryDestination      string           `json:"expiry_destination"`       // Where expired rewards are swept to
+	RewardCurve            string           `json:"reward_curve"`             // Reward curve sharing the epoch budget among providers, empty selects linear
+	MaxProviderShare       sdk.Dec          `json:"max_provider_share"`       // Largest share of the epoch budget a provider can earn (capped curve)
+	SlashClawbackEpochs    uint64           `json:"slash_clawback_epochs"`    // Epochs of earnings a double sign slash claws back from its provider, 0 disables it
+}
+
+// RewardPool tracks the reward tokens held by the module account
+type RewardPool struct {
+	Available   sdk.Coins `json:"available"`   // Funded but not yet allocated; rolls over between epochs
+	Outstanding sdk.Coins `json:"outstanding"` // Allocated to addresses but not yet claimed
+	Vesting     sdk.Coins `json:"vesting"`     // Claimed but locked in vesting, or unlocked and not yet released
+	Remainder   sdk.Coins `json:"remainder"`   // Part of Available emitted but not yet distributed; carried into the next epoch budget
 }
 
 // AccumulatedRewards represents the rewards accumulated for an address
 type AccumulatedRewards struct {
-	Address  string  `json:"address"`
-	Rewards  sdk.Int `json:"rewards"`
-	LastClaim uint64  `json:"last_claim"` // Last epoch when rewards were claimed
+	Address   string    `json:"address"`
+	Rewards   sdk.Coins `json:"rewards"`
+	LastClaim uint64    `json:"last_claim"` // Last epoch when rewards were claimed
+	UnclaimedSince uint64 `json:"unclaimed_since"` // Epoch in which the oldest unclaimed reward was credited
+}
+
+// AutoCompoundSetting opts an address into delegating its rewards to Validator at every epoch close
+type AutoCompoundSetting struct {
+	Address   string `json:"address"`
+	Validator string `json:"validator"`
+}
+
+// RewardWithdrawAddress redirects the claimed rewards of Address to WithdrawAddress
+type RewardWithdrawAddress struct {
+	Address         string `json:"address"`
+	WithdrawAddress string `json:"withdraw_address"`
+}
+
+// EpochRewardRecord is the immutable summary of the rewards allocated in an epoch
+type EpochRewardRecord struct {
+	EpochNumber       uint64       `json:"epoch_number"`
+	Height            int64        `json:"height"` // Block height at which the epoch was settled
+	Time              time.Time    `json:"time"`   // Block time at which the epoch was settled
+	TotalServiceScore sdk.Int      `json:"total_service_score"`
+	TotalStaked       sdk.Int      `json:"total_staked"`
+	TotalDistributed  sdk.Coins    `json:"total_distributed"`
+	Participants      uint64       `json:"participants"` // Number of addresses credited in the epoch
+	Params            RewardParams `json:"params"`       // Reward parameters in force during the epoch
+	Remainder         sdk.Coins    `json:"remainder"`    // Part of the epoch budget carried into the next epoch
+}
+
+// AddressEpochReward is the amount an address earned in an epoch
+type AddressEpochReward struct {
+	Address     string    `json:"address"`
+	EpochNumber uint64    `json:"epoch_number"`
+	Amount      sdk.Coins `json:"amount"`
 }
 
 // DefaultRewardParams returns default parameters for reward calculation
@@ -32,9 +111,110 @@ func DefaultRewardParams() RewardParams {
 	return RewardParams{
 		ServiceScoreWeight: sdk.NewDecWithPrec(6, 1), // 0.6
 		StakingWeight:      sdk.NewDecWithPrec(4, 1), // 0.4
-		RewardPerEpoch:     sdk.NewInt(1000000),      // 1 SERV (assuming 6 decimals)
-		EpochDuration:      100,                      // 100 blocks per epoch
+		RewardPerEpoch:     sdk.NewCoins(sdk.NewCoin(DefaultRewardDenom, sdk.NewInt(1000000))), // 1 SERV (assuming 6 decimals)
+		EpochIdentifier:    epochstypes.DayEpochIdentifier,
+		RewardSource:       RewardSourceMint,
+		FeeShare:           sdk.ZeroDec(),
+		InflationCap:       sdk.NewDecWithPrec(1, 4), // 0.01% of supply per epoch
+		MintDenom:          DefaultRewardDenom,
+		HistoryRetentionEpochs: 365, // a year of daily epochs
+		Emission:               DefaultEmissionSchedule(),
+		Vesting:                DefaultVestingParams(),
+		AutoCompoundGasBudget:  10000000,
+		UnclaimedExpiryEpochs:  0,
+		ExpiryWarningEpochs:    30,
+		ExpiryDestination:      ExpiryDestinationRewardPool,
+		RewardCurve:            RewardCurveLinear,
+		MaxProviderShare:       sdk.NewDecWithPrec(1, 1), // 10% of the epoch budget
+		SlashClawbackEpochs:    7,
+	}
+}
+
+// DefaultRewardPool returns an empty reward pool
+func DefaultRewardPool() RewardPool {
+	return RewardPool{
+		Available:   sdk.NewCoins(),
+		Outstanding: sdk.NewCoins(),
+		Vesting:     sdk.NewCoins(),
+		Remainder:   sdk.NewCoins(),
+	}
+}
+
+// Validate performs basic validation of reward parameters
+func (p RewardParams) Validate() error {
+	if p.ServiceScoreWeight.IsNegative() {
+		return fmt.Errorf("service score weight cannot be negative: %s", p.ServiceScoreWeight)
+	}
+
+	if p.StakingWeight.IsNegative() {
+		return fmt.Errorf("staking weight cannot be negative: %s", p.StakingWeight)
+	}
+
+	if err := p.RewardPerEpoch.Validate(); err != nil {
+		return fmt.Errorf("invalid reward per epoch: %w", err)
 	}
+
+	if err := epochstypes.ValidateEpochIdentifierString(p.EpochIdentifier); err != nil {
+		return err
+	}
+
+	// Ensure weights sum to 1
+	sumWeights := p.ServiceScoreWeight.Add(p.StakingWeight)
+	if !sumWeights.Equal(sdk.OneDec()) {
+		return fmt.Errorf("service score weight and staking weight must sum to 1, got: %s", sumWeights)
+	}
+
+	switch p.RewardSource {
+	case RewardSourceMint, RewardSourceFeeShare, RewardSourceCommunityPool, RewardSourceModuleAccount:
+	default:
+		return fmt.Errorf("unknown reward source: %q", p.RewardSource)
+	}
+
+	if p.FeeShare.IsNegative() || p.FeeShare.GT(sdk.OneDec()) {
+		return fmt.Errorf("fee share must be between 0 and 1: %s", p.FeeShare)
+	}
+
+	if p.InflationCap.IsNegative() || p.InflationCap.GT(sdk.OneDec()) {
+		return fmt.Errorf("inflation cap must be between 0 and 1: %s", p.InflationCap)
+	}
+
+	if err := p.Emission.Validate(); err != nil {
+		return fmt.Errorf("invalid emission schedule: %w", err)
+	}
+
+	if err := p.Vesting.Validate(); err != nil {
+		return fmt.Errorf("invalid vesting params: %w", err)
+	}
+
+	switch p.ExpiryDestination {
+	case ExpiryDestinationRewardPool, ExpiryDestinationCommunityPool:
+	default:
+		return fmt.Errorf("unknown expiry destination: %q", p.ExpiryDestination)
+	}
+
+	if p.UnclaimedExpiryEpochs > 0 && p.ExpiryWarningEpochs >= p.UnclaimedExpiryEpochs {
+		return fmt.Errorf("expiry warning epochs must be less than unclaimed expiry epochs: %d >= %d", p.ExpiryWarningEpochs, p.UnclaimedExpiryEpochs)
+	}
+
+	// Unclaimed rewards age by the earnings ledger, which must cover the expiry window
+	if p.UnclaimedExpiryEpochs > 0 && p.HistoryRetentionEpochs > 0 && p.HistoryRetentionEpochs < p.UnclaimedExpiryEpochs {
+		return fmt.Errorf("history retention epochs must be at least unclaimed expiry epochs: %d < %d", p.HistoryRetentionEpochs, p.UnclaimedExpiryEpochs)
+	}
+
+	if p.RewardCurve == RewardCurveCapped {
+		if p.MaxProviderShare.IsNil() || !p.MaxProviderShare.IsPositive() || p.MaxProviderShare.GT(sdk.OneDec()) {
+			return fmt.Errorf("max provider share must be positive and at most 1: %s", p.MaxProviderShare)
+		}
+	}
+
+	// Target inflation emits MintDenom whatever the reward source
+	if p.RewardSource == RewardSourceMint || p.Emission.Mode == EmissionModeTargetInflation {
+		if err := sdk.ValidateDenom(p.MintDenom); err != nil {
+			return fmt.Errorf("invalid mint denom: %w", err)
+		}
+	}
+
+	return nil
 }
 
 // DefaultRewardMetrics returns default metrics for reward calculation
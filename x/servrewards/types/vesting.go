@@ -0,0 +1,172 @@
+package types
+
+import (
+	"fmt"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Vesting modes of claimed rewards
+const (
+	// VestingModeNone pays out claims in full
+	VestingModeNone = "none"
+	// VestingModeContinuous unlocks the locked part of a claim linearly over Duration
+	VestingModeContinuous = "continuous"
+	// VestingModePeriodic unlocks the locked part of a claim in Periods equal steps over Duration
+	VestingModePeriodic = "periodic"
+)
+
+// VestingParams configures which part of a claim is locked and for how long
+type VestingParams struct {
+	Mode           string        `json:"mode"`
+	LockedFraction sdk.Dec       `json:"locked_fraction"` // Fraction of every claim that vests instead of being paid out
+	Duration       time.Duration `json:"duration"`        // Time until a locked claim is fully unlocked
+	Periods        uint64        `json:"periods"`         // Number of unlock steps (periodic mode)
+}
+
+// VestingEntry is the locked part of a single claim
+type VestingEntry struct {
+	Amount    sdk.Coins `json:"amount"`
+	StartTime time.Time `json:"start_time"`
+	EndTime   time.Time `json:"end_time"`
+	Periods   uint64    `json:"periods"` // 0 unlocks continuously
+}
+
+// VestingRewards are the vesting claims of an address. Locked rewards are
+// escrowed by the module account rather than paid into x/auth/vesting
+// accounts, which can only be created for addresses that do not exist yet,
+// while rewards are paid to existing accounts. Escrowed rewards cannot be
+// delegated before they are released, which every claim and
+// MsgReleaseVestedRewards do for whatever has unlocked.
+type VestingRewards struct {
+	Address  string         `json:"address"`
+	Entries  []VestingEntry `json:"entries"`
+	Released sdk.Coins      `json:"released"` // Unlocked amount of Entries already paid out
+}
+
+// DefaultVestingParams returns vesting parameters that pay out claims in full
+func DefaultVestingParams() VestingParams {
+	return VestingParams{
+		Mode:           VestingModeNone,
+		LockedFraction: sdk.ZeroDec(),
+		Duration:       time.Hour * 24 * 90,
+		Periods:        0,
+	}
+}
+
+// Validate performs basic validation of vesting parameters
+func (p VestingParams) Validate() error {
+	switch p.Mode {
+	case VestingModeNone:
+		return nil
+	case VestingModeContinuous:
+	case VestingModePeriodic:
+		if p.Periods == 0 {
+			return fmt.Errorf("vesting periods must be positive")
+		}
+	default:
+		return fmt.Errorf("unknown vesting mode: %q", p.Mode)
+	}
+
+	if p.LockedFraction.IsNegative() || p.LockedFraction.GT(sdk.OneDec()) {
+		return fmt.Errorf("locked fraction must be between 0 and 1: %s", p.LockedFraction)
+	}
+
+	if p.Duration <= 0 {
+		return fmt.Errorf("vesting duration must be positive: %s", p.Duration)
+	}
+
+	return nil
+}
+
+// LockedAmount returns the part of claimed that vests
+func (p VestingParams) LockedAmount(claimed sdk.Coins) sdk.Coins {
+	locked := sdk.NewCoins()
+	if p.Mode == VestingModeNone {
+		return locked
+	}
+
+	for _, coin := range claimed {
+		amount := sdk.NewDecFromInt(coin.Amount).Mul(p.LockedFraction).TruncateInt()
+		locked = locked.Add(sdk.NewCoin(coin.Denom, amount))
+	}
+
+	return locked
+}
+
+// NewVestingEntry returns the vesting entry of amount locked at start
+func (p VestingParams) NewVestingEntry(amount sdk.Coins, start time.Time) VestingEntry {
+	periods := uint64(0)
+	if p.Mode == VestingModePeriodic {
+		periods = p.Periods
+	}
+
+	return VestingEntry{
+		Amount:    amount,
+		StartTime: start,
+		EndTime:   start.Add(p.Duration),
+		Periods:   periods,
+	}
+}
+
+// UnlockedCoins returns the part of the entry unlocked at blockTime
+func (e VestingEntry) UnlockedCoins(blockTime time.Time) sdk.Coins {
+	if !blockTime.Before(e.EndTime) {
+		return e.Amount
+	}
+	if !blockTime.After(e.StartTime) {
+		return sdk.NewCoins()
+	}
+
+	elapsed := sdk.NewDec(int64(blockTime.Sub(e.StartTime)))
+	fraction := elapsed.QuoInt64(int64(e.EndTime.Sub(e.StartTime)))
+	if e.Periods > 0 {
+		// Round down to the last completed period
+		fraction = fraction.MulInt64(int64(e.Periods)).TruncateDec().QuoInt64(int64(e.Periods))
+	}
+
+	unlocked := sdk.NewCoins()
+	for _, coin := range e.Amount {
+		amount := sdk.NewDecFromInt(coin.Amount).Mul(fraction).TruncateInt()
+		unlocked = unlocked.Add(sdk.NewCoin(coin.Denom, amount))
+	}
+
+	return unlocked
+}
+
+// Balances returns the locked amount and the unlocked amount not yet released
+// at blockTime
+func (v VestingRewards) Balances(blockTime time.Time) (locked, unlocked sdk.Coins) {
+	total := sdk.NewCoins()
+	vested := sdk.NewCoins()
+	for _, entry := range v.Entries {
+		total = total.Add(entry.Amount...)
+		vested = vested.Add(entry.UnlockedCoins(blockTime)...)
+	}
+
+	return total.Sub(vested...), vested.Sub(v.Released...)
+}
+
+// Validate performs basic validation of the vesting claims of an address
+func (v VestingRewards) Validate() error {
+	total := sdk.NewCoins()
+	for _, entry := range v.Entries {
+		if err := entry.Amount.Validate(); err != nil {
+			return fmt.Errorf("invalid vesting amount: %w", err)
+		}
+		if entry.EndTime.Before(entry.StartTime) {
+			return fmt.Errorf("vesting ends before it starts: %s < %s", entry.EndTime, entry.StartTime)
+		}
+		total = total.Add(entry.Amount...)
+	}
+
+	if err := v.Released.Validate(); err != nil {
+		return fmt.Errorf("invalid released amount: %w", err)
+	}
+	if !total.IsAllGTE(v.Released) {
+		return fmt.Errorf("released %s exceeds vesting total %s", v.Released, total)
+	}
+
+	return nil
+}